@@ -23,9 +23,25 @@ func NewAppWithConfig(cfg config.Configuration) *fx.App {
 		fx.Provide(func() config.Configuration { return cfg }),
 		fx.Provide(func() config.Database { return cfg.Database }),
 		fx.Provide(func() config.Cache { return cfg.Cache }),
+		fx.Provide(func() config.BlobStore { return cfg.BlobStore }),
+		fx.Provide(func() config.Stream { return cfg.Stream }),
+		fx.Provide(func() config.Webhooks { return cfg.Webhooks }),
+		fx.Provide(func() config.Links { return cfg.Links }),
+		fx.Provide(func() config.Search { return cfg.Search }),
 		internalModule,
 		userModule,
 		tweetModule,
+		recommendationModule,
+		exportModule,
+		notificationModule,
+		streamModule,
+		webhookModule,
+		dmModule,
+		pollModule,
+		mediaModule,
+		linkModule,
+		searchModule,
+		listModule,
 	}
 
 	return fx.New(
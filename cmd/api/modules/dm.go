@@ -0,0 +1,38 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	dmhdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/dm"
+	dmrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/dm"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	"go.uber.org/fx"
+)
+
+var dmFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(dm.UserFinder)),
+	),
+	fx.Annotate(
+		dmrepo.NewConversationRepository,
+		fx.As(new(dm.ConversationRepository)),
+	),
+	fx.Annotate(
+		dm.NewDMUseCase,
+		fx.As(new(dmhdl.DMUseCase)),
+	),
+	dmhdl.NewHandler,
+	dmhdl.NewRouter,
+)
+
+func registerDMEndpoints(router *gin.RouterGroup, handler *dmhdl.DMHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+var dmModule = fx.Options(
+	fx.Invoke(
+		registerDMEndpoints,
+	),
+	dmFactories,
+)
@@ -0,0 +1,54 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/blob/filesystem"
+	exporthdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/export"
+	exportjob "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/job/export"
+	exportrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/export"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/scheduler"
+	"go.uber.org/fx"
+)
+
+var exportFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(export.UserFinder)),
+	),
+	fx.Annotate(
+		exportrepo.NewExportRepository,
+		fx.As(new(export.JobRepository)),
+		fx.As(new(export.DataSource)),
+	),
+	fx.Annotate(
+		filesystem.NewStore,
+		fx.As(new(export.BlobStore)),
+	),
+	fx.Annotate(
+		export.NewExportUseCase,
+		fx.As(new(exporthdl.ExportUseCase)),
+		fx.As(new(exportjob.ExportPurger)),
+	),
+	exporthdl.NewHandler,
+	exporthdl.NewRouter,
+	exportjob.NewPurgeJob,
+)
+
+func registerExportEndpoints(router *gin.RouterGroup, handler *exporthdl.ExportHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+func scheduleExportJobs(lc fx.Lifecycle, cfg config.Configuration, purge *exportjob.PurgeJob) {
+	scheduler.Schedule(lc, "purge_expired_exports", cfg.Exports.PurgeInterval, purge.Run)
+}
+
+var exportModule = fx.Options(
+	fx.Invoke(
+		registerExportEndpoints,
+		scheduleExportJobs,
+	),
+	exportFactories,
+)
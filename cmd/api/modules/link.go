@@ -0,0 +1,38 @@
+package modules
+
+import (
+	linkfetcher "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/httpclient/link"
+	linkjob "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/job/link"
+	linkrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/scheduler"
+	"go.uber.org/fx"
+)
+
+var linkFactories = fx.Provide(
+	fx.Annotate(
+		linkrepo.NewPreviewRepository,
+		fx.As(new(link.PreviewRepository)),
+	),
+	fx.Annotate(
+		linkfetcher.NewFetcher,
+		fx.As(new(link.Fetcher)),
+	),
+	fx.Annotate(
+		link.NewLinkUseCase,
+		fx.As(new(linkjob.PreviewFetcher)),
+		fx.As(new(tweet.LinkPreviewer)),
+	),
+	linkjob.NewFetchJob,
+)
+
+func scheduleLinkJobs(lc fx.Lifecycle, cfg config.Configuration, fetch *linkjob.FetchJob) {
+	scheduler.Schedule(lc, "fetch_link_previews", cfg.Links.PreviewFetchInterval, fetch.Run)
+}
+
+var linkModule = fx.Options(
+	fx.Invoke(scheduleLinkJobs),
+	linkFactories,
+)
@@ -0,0 +1,45 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	listhdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/list"
+	listrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/list"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	timelinerepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/redis/timeline"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"go.uber.org/fx"
+)
+
+var listFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(list.UserFinder)),
+	),
+	fx.Annotate(
+		listrepo.NewListRepository,
+		fx.As(new(list.ListRepository)),
+	),
+	fx.Annotate(
+		timelinerepo.NewCache,
+		fx.As(new(list.TimelineCache)),
+	),
+	fx.Annotate(
+		list.NewListUseCase,
+		fx.As(new(listhdl.ListUseCase)),
+		fx.As(new(tweet.ListReader)),
+	),
+	listhdl.NewHandler,
+	listhdl.NewRouter,
+)
+
+func registerListEndpoints(router *gin.RouterGroup, handler *listhdl.ListHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+var listModule = fx.Options(
+	fx.Invoke(
+		registerListEndpoints,
+	),
+	listFactories,
+)
@@ -0,0 +1,43 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/blob/filesystem"
+	mediahdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/media"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/imaging"
+	mediarepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/media"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	"go.uber.org/fx"
+)
+
+var mediaFactories = fx.Provide(
+	fx.Annotate(
+		mediarepo.NewMediaRepository,
+		fx.As(new(media.MediaRepository)),
+	),
+	fx.Annotate(
+		filesystem.NewStore,
+		fx.As(new(media.BlobStore)),
+	),
+	fx.Annotate(
+		imaging.NewProcessor,
+		fx.As(new(media.Processor)),
+	),
+	fx.Annotate(
+		media.NewMediaUseCase,
+		fx.As(new(mediahdl.MediaUseCase)),
+	),
+	mediahdl.NewHandler,
+	mediahdl.NewRouter,
+)
+
+func registerMediaEndpoints(router *gin.RouterGroup, handler *mediahdl.MediaHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+var mediaModule = fx.Options(
+	fx.Invoke(
+		registerMediaEndpoints,
+	),
+	mediaFactories,
+)
@@ -0,0 +1,42 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	notificationhdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/notification"
+	notificationrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/notification"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"go.uber.org/fx"
+)
+
+var notificationFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(notification.UserFinder)),
+	),
+	fx.Annotate(
+		notificationrepo.NewNotificationRepository,
+		fx.As(new(notification.NotificationRepository)),
+	),
+	fx.Annotate(
+		notification.NewNotificationUseCase,
+		fx.As(new(notificationhdl.NotificationUseCase)),
+		fx.As(new(user.Notifier)),
+		fx.As(new(tweet.Notifier)),
+	),
+	notificationhdl.NewHandler,
+	notificationhdl.NewRouter,
+)
+
+func registerNotificationEndpoints(router *gin.RouterGroup, handler *notificationhdl.NotificationHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+var notificationModule = fx.Options(
+	fx.Invoke(
+		registerNotificationEndpoints,
+	),
+	notificationFactories,
+)
@@ -0,0 +1,35 @@
+package modules
+
+import (
+	tweethdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/tweet"
+	pollrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/poll"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	tallyrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/redis/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"go.uber.org/fx"
+)
+
+var pollFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(poll.UserFinder)),
+	),
+	fx.Annotate(
+		pollrepo.NewPollRepository,
+		fx.As(new(poll.PollRepository)),
+	),
+	fx.Annotate(
+		tallyrepo.NewCache,
+		fx.As(new(poll.TallyCache)),
+	),
+	fx.Annotate(
+		poll.NewPollUseCase,
+		fx.As(new(tweethdl.PollUseCase)),
+		fx.As(new(tweet.PollReader)),
+	),
+)
+
+var pollModule = fx.Options(
+	pollFactories,
+)
@@ -0,0 +1,43 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	recommendationhdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/recommendation"
+	recommendationrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/recommendation"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	suggestionrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/redis/suggestion"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation"
+	"go.uber.org/fx"
+)
+
+var recommendationFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(recommendation.UserFinder)),
+	),
+	fx.Annotate(
+		recommendationrepo.NewRecommendationRepository,
+		fx.As(new(recommendation.CandidateFinder)),
+	),
+	fx.Annotate(
+		suggestionrepo.NewCache,
+		fx.As(new(recommendation.SuggestionCache)),
+	),
+	fx.Annotate(
+		recommendation.NewRecommendationUseCase,
+		fx.As(new(recommendationhdl.RecommendationUseCase)),
+	),
+	recommendationhdl.NewHandler,
+	recommendationhdl.NewRouter,
+)
+
+func registerRecommendationEndpoints(router *gin.RouterGroup, handler *recommendationhdl.RecommendationHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+var recommendationModule = fx.Options(
+	fx.Invoke(
+		registerRecommendationEndpoints,
+	),
+	recommendationFactories,
+)
@@ -0,0 +1,69 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+
+	tweetrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/tweet"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/search/embedded"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"go.uber.org/fx"
+)
+
+var searchFactories = fx.Provide(
+	newSearchIndex,
+	fx.Annotate(
+		tweetrepo.NewTweetRepository,
+		fx.As(new(search.TweetReader)),
+	),
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(search.UserFinder)),
+	),
+	fx.Annotate(
+		search.NewSearchUseCase,
+		fx.As(new(tweet.TweetSearcher)),
+		fx.As(new(user.SearchIndexer)),
+	),
+)
+
+// OpenSearchIndex opens the search.Index of the configured backend along
+// with the function that closes it.
+func OpenSearchIndex(cfg config.Search, conns db.Connections) (search.Index, func() error, error) {
+	switch cfg.Backend {
+	case config.SearchBackendPostgres:
+		return tweetrepo.NewSearchIndex(conns), func() error { return nil }, nil
+	case config.SearchBackendEmbedded:
+		index, err := embedded.Open(cfg.IndexPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return index, index.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown search backend %q", cfg.Backend)
+	}
+}
+
+func newSearchIndex(lc fx.Lifecycle, cfg config.Search, conns db.Connections) (search.Index, error) {
+	index, closeIndex, err := OpenSearchIndex(cfg, conns)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			return closeIndex()
+		},
+	})
+
+	return index, nil
+}
+
+var searchModule = fx.Options(
+	searchFactories,
+)
@@ -0,0 +1,49 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	streamhdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/stream"
+	tweetrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/tweet"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	streambroker "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/redis/stream"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"go.uber.org/fx"
+)
+
+var streamFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(stream.UserFinder)),
+	),
+	fx.Annotate(
+		tweetrepo.NewTweetRepository,
+		fx.As(new(stream.TweetFinder)),
+	),
+	fx.Annotate(
+		streambroker.NewBroker,
+		fx.As(new(stream.Broker)),
+	),
+	fx.Annotate(
+		stream.NewStreamUseCase,
+		fx.As(new(streamhdl.StreamUseCase)),
+		fx.As(new(tweet.EventPublisher)),
+		fx.As(new(notification.Publisher)),
+		fx.As(new(dm.Publisher)),
+	),
+	streamhdl.NewHandler,
+	streamhdl.NewRouter,
+)
+
+func registerStreamEndpoints(router *gin.RouterGroup, handler *streamhdl.StreamHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+var streamModule = fx.Options(
+	fx.Invoke(
+		registerStreamEndpoints,
+	),
+	streamFactories,
+)
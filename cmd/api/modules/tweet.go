@@ -3,10 +3,14 @@ package modules
 import (
 	"github.com/gin-gonic/gin"
 	tweethdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/tweet"
+	tweetjob "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/job/tweet"
+	mediarepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/media"
 	tweetrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/tweet"
 	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
 	timelinerepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/redis/timeline"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/scheduler"
 	"go.uber.org/fx"
 )
 
@@ -16,6 +20,22 @@ var tweetFactories = fx.Provide(
 		fx.As(new(tweet.TweetCreator)),
 		fx.As(new(tweet.TweetReader)),
 	),
+	fx.Annotate(
+		tweetrepo.NewScheduledTweetRepository,
+		fx.As(new(tweet.ScheduledTweetRepository)),
+	),
+	fx.Annotate(
+		tweetrepo.NewDraftRepository,
+		fx.As(new(tweet.DraftRepository)),
+	),
+	fx.Annotate(
+		tweetrepo.NewPinRepository,
+		fx.As(new(tweet.PinRepository)),
+	),
+	fx.Annotate(
+		mediarepo.NewMediaRepository,
+		fx.As(new(tweet.MediaFinder)),
+	),
 	fx.Annotate(
 		userrepo.NewUserRepository,
 		fx.As(new(tweet.UserFinder)),
@@ -27,18 +47,28 @@ var tweetFactories = fx.Provide(
 	fx.Annotate(
 		tweet.NewTweetUseCase,
 		fx.As(new(tweethdl.TweetUseCase)),
+		fx.As(new(tweetjob.ScheduledPublisher)),
+		fx.As(new(tweetjob.DraftPurger)),
 	),
 	tweethdl.NewHandler,
 	tweethdl.NewRouter,
+	tweetjob.NewPublishJob,
+	tweetjob.NewPurgeDraftsJob,
 )
 
 func registerTweetEndpoints(router *gin.RouterGroup, handler *tweethdl.TweetHandlerRouter) {
 	handler.AddRoutes(router)
 }
 
+func scheduleTweetJobs(lc fx.Lifecycle, cfg config.Configuration, publish *tweetjob.PublishJob, purgeDrafts *tweetjob.PurgeDraftsJob) {
+	scheduler.Schedule(lc, "publish_scheduled_tweets", cfg.Tweets.PublishInterval, publish.Run)
+	scheduler.Schedule(lc, "purge_drafts", cfg.Tweets.DraftPurgeInterval, purgeDrafts.Run)
+}
+
 var tweetModule = fx.Options(
 	fx.Invoke(
 		registerTweetEndpoints,
+		scheduleTweetJobs,
 	),
 	tweetFactories,
 )
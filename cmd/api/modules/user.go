@@ -3,9 +3,13 @@ package modules
 import (
 	"github.com/gin-gonic/gin"
 	userhdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/user"
+	userjob "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/job/user"
 	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
 	timelinerepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/redis/timeline"
+	typeaheadrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/redis/typeahead"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/scheduler"
 	"go.uber.org/fx"
 )
 
@@ -19,21 +23,38 @@ var userFactories = fx.Provide(
 		timelinerepo.NewCache,
 		fx.As(new(user.TimelineCache)),
 	),
+	fx.Annotate(
+		typeaheadrepo.NewIndex,
+		fx.As(new(user.TypeaheadIndex)),
+	),
+	func(cfg config.Configuration) user.UsernamePolicy {
+		return user.NewUsernamePolicy(cfg.Users.ReservedUsernames)
+	},
 	fx.Annotate(
 		user.NewUserUseCase,
 		fx.As(new(userhdl.UserUseCase)),
+		fx.As(new(userjob.AccountPurger)),
+		fx.As(new(userjob.TypeaheadIndexer)),
 	),
 	userhdl.NewHandler,
 	userhdl.NewRouter,
+	userjob.NewPurgeJob,
+	userjob.NewTypeaheadJob,
 )
 
 func registerUserEndpoints(router *gin.RouterGroup, handler *userhdl.UserHandlerRouter) {
 	handler.AddRoutesV1(router)
 }
 
+func scheduleUserJobs(lc fx.Lifecycle, cfg config.Configuration, purge *userjob.PurgeJob, typeahead *userjob.TypeaheadJob) {
+	scheduler.Schedule(lc, "purge_deactivated_users", cfg.Users.PurgeInterval, purge.Run)
+	scheduler.Schedule(lc, "index_user_typeahead", cfg.Users.TypeaheadIndexInterval, typeahead.Run)
+}
+
 var userModule = fx.Options(
 	fx.Invoke(
 		registerUserEndpoints,
+		scheduleUserJobs,
 	),
 	userFactories,
 )
@@ -0,0 +1,61 @@
+package modules
+
+import (
+	"github.com/gin-gonic/gin"
+	webhookhdl "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/webhook"
+	webhooksender "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/httpclient/webhook"
+	webhookjob "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/job/webhook"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	webhookrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/webhook"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/scheduler"
+	"go.uber.org/fx"
+)
+
+var webhookFactories = fx.Provide(
+	fx.Annotate(
+		userrepo.NewUserRepository,
+		fx.As(new(webhook.UserFinder)),
+	),
+	fx.Annotate(
+		webhookrepo.NewSubscriptionRepository,
+		fx.As(new(webhook.SubscriptionRepository)),
+	),
+	fx.Annotate(
+		webhookrepo.NewDeliveryRepository,
+		fx.As(new(webhook.DeliveryRepository)),
+	),
+	fx.Annotate(
+		webhooksender.NewSender,
+		fx.As(new(webhook.Sender)),
+	),
+	fx.Annotate(
+		webhook.NewWebhookUseCase,
+		fx.As(new(webhookhdl.WebhookUseCase)),
+		fx.As(new(webhookjob.Deliverer)),
+		fx.As(new(user.WebhookDispatcher)),
+		fx.As(new(tweet.WebhookDispatcher)),
+	),
+	webhookhdl.NewHandler,
+	webhookhdl.NewRouter,
+	webhookjob.NewDispatchJob,
+)
+
+func registerWebhookEndpoints(router *gin.RouterGroup, handler *webhookhdl.WebhookHandlerRouter) {
+	handler.AddRoutes(router)
+}
+
+func scheduleWebhookJobs(lc fx.Lifecycle, cfg config.Configuration, dispatch *webhookjob.DispatchJob) {
+	scheduler.Schedule(lc, "dispatch_webhooks", cfg.Webhooks.DispatchInterval, dispatch.Run)
+}
+
+var webhookModule = fx.Options(
+	fx.Invoke(
+		registerWebhookEndpoints,
+		scheduleWebhookJobs,
+	),
+	webhookFactories,
+)
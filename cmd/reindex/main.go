@@ -0,0 +1,44 @@
+// Command reindex rebuilds the search index from the tweets table. With the
+// embedded backend the API must be stopped, since only one process can
+// open the index.
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/cmd/api/modules"
+	tweetrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/tweet"
+	userrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+)
+
+func main() {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	conns, err := db.NewDBConnections(cfg.Database)
+	if err != nil {
+		log.Fatalf("failed to connect to the database: %v", err)
+	}
+
+	index, closeIndex, err := modules.OpenSearchIndex(cfg.Search, conns)
+	if err != nil {
+		log.Fatalf("failed to open the search index: %v", err)
+	}
+
+	uc := search.NewSearchUseCase(index, tweetrepo.NewTweetRepository(conns), userrepo.NewUserRepository(conns))
+	indexed, err := uc.Reindex(context.Background())
+	if closeErr := closeIndex(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Fatalf("failed to reindex after %d tweets: %v", indexed, err)
+	}
+
+	log.Printf("indexed %d tweets with the %s backend", indexed, cfg.Search.Backend)
+}
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
@@ -43,3 +45,14 @@ func ValidateUserID(c *gin.Context) (string, error) {
 
 	return userID.String(), nil
 }
+
+// ParseLimitParam returns 0 when limit is missing or malformed so the use
+// case applies its default.
+func ParseLimitParam(c *gin.Context) int {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil {
+		return 0
+	}
+
+	return limit
+}
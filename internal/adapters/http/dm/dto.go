@@ -0,0 +1,89 @@
+package dm
+
+import (
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+)
+
+type (
+	createConversationRequest struct {
+		ParticipantIDs []string `json:"participant_ids" validate:"required,min=1,dive,validUUIDFormat"`
+	}
+
+	sendMessageRequest struct {
+		Content string `json:"content" validate:"required"`
+	}
+
+	conversationIDRequest struct {
+		ID string `validate:"required,validUUIDFormat"`
+	}
+
+	participantResponse struct {
+		UserID     string     `json:"user_id"`
+		Username   string     `json:"username"`
+		LastReadAt *time.Time `json:"last_read_at,omitempty"`
+	}
+
+	conversationResponse struct {
+		ID            string                `json:"id"`
+		IsGroup       bool                  `json:"is_group"`
+		CreatedBy     string                `json:"created_by,omitempty"`
+		Participants  []participantResponse `json:"participants"`
+		UnreadCount   int                   `json:"unread_count"`
+		CreatedAt     time.Time             `json:"created_at"`
+		LastMessageAt *time.Time            `json:"last_message_at,omitempty"`
+	}
+
+	conversationsResponse struct {
+		Conversations []conversationResponse `json:"conversations"`
+	}
+
+	messageResponse struct {
+		ID             string    `json:"id"`
+		ConversationID string    `json:"conversation_id"`
+		SenderID       string    `json:"sender_id"`
+		Content        string    `json:"content"`
+		CreatedAt      time.Time `json:"created_at"`
+	}
+
+	messagesResponse struct {
+		Messages   []messageResponse `json:"messages"`
+		NextCursor string            `json:"next_cursor,omitempty"`
+	}
+
+	markAsReadResponse struct {
+		Message string `json:"message"`
+	}
+)
+
+func toConversationResponse(c dm.Conversation) conversationResponse {
+	participants := make([]participantResponse, len(c.Participants))
+	for i, p := range c.Participants {
+		participants[i] = participantResponse{
+			UserID:     p.UserID,
+			Username:   p.Username,
+			LastReadAt: p.LastReadAt,
+		}
+	}
+
+	return conversationResponse{
+		ID:            c.ID,
+		IsGroup:       c.IsGroup,
+		CreatedBy:     c.CreatedBy,
+		Participants:  participants,
+		UnreadCount:   c.UnreadCount,
+		CreatedAt:     c.CreatedAt,
+		LastMessageAt: c.LastMessageAt,
+	}
+}
+
+func toMessageResponse(m dm.Message) messageResponse {
+	return messageResponse{
+		ID:             m.ID,
+		ConversationID: m.ConversationID,
+		SenderID:       m.SenderID,
+		Content:        m.Content,
+		CreatedAt:      m.CreatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package dm
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
+)
+
+func handleError(c *gin.Context, err error) {
+	var apiError *httperrors.APIError
+
+	switch {
+	case errors.Is(err, user.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid input"))
+	case errors.Is(err, user.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "User not found"))
+	case errors.Is(err, dm.ErrConversationNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Conversation not found"))
+	case errors.Is(err, dm.ErrInvalidParticipants):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid conversation participants"))
+	case errors.Is(err, dm.ErrTooManyParticipants):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Too many conversation participants"))
+	case errors.Is(err, dm.ErrNotAllowed):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "Not allowed to message user"))
+	case errors.Is(err, dm.ErrEmptyMessage):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Message is empty"))
+	case errors.Is(err, dm.ErrMessageTooLong):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Message is too long"))
+	case errors.Is(err, dm.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid cursor"))
+	case errors.As(err, &apiError):
+		c.JSON(apiError.Code, apiError)
+	default:
+		c.JSON(http.StatusInternalServerError, httperrors.NewSimple(httperrors.ErrInternal, "Internal server error"))
+	}
+}
@@ -0,0 +1,202 @@
+package dm
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	DMUseCase interface {
+		CreateConversation(ctx context.Context, userID string, participantIDs []string) (*dm.Conversation, bool, error)
+		GetConversation(ctx context.Context, userID, id string) (*dm.Conversation, error)
+		GetConversations(ctx context.Context, userID string, limit int) ([]dm.Conversation, error)
+		SendMessage(ctx context.Context, userID, conversationID, content string) (*dm.Message, error)
+		GetMessages(ctx context.Context, userID, conversationID, cursor string, limit int) (*dm.MessagePage, error)
+		MarkAsRead(ctx context.Context, userID, conversationID string) error
+	}
+
+	handler struct {
+		usecase DMUseCase
+	}
+)
+
+func NewHandler(usecase DMUseCase) *handler {
+	return &handler{usecase: usecase}
+}
+
+// CreateConversation answers 201 for a new conversation and 200 when an
+// existing one-to-one conversation is returned.
+func (h *handler) CreateConversation(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[createConversationRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	conversation, created, err := h.usecase.CreateConversation(ctx, userID, req.ParticipantIDs)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create conversation")
+		handleError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, toConversationResponse(*conversation))
+}
+
+func (h *handler) GetConversations(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	conversations, err := h.usecase.GetConversations(ctx, userID, common.ParseLimitParam(c))
+	if err != nil {
+		logger.WithError(err).Error("Failed to get conversations")
+		handleError(c, err)
+		return
+	}
+
+	resp := conversationsResponse{Conversations: make([]conversationResponse, len(conversations))}
+	for i, conversation := range conversations {
+		resp.Conversations[i] = toConversationResponse(conversation)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *handler) GetConversation(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, conversationID, err := validateConversationRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	conversation, err := h.usecase.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get conversation")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toConversationResponse(*conversation))
+}
+
+func (h *handler) SendMessage(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, conversationID, err := validateConversationRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[sendMessageRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	message, err := h.usecase.SendMessage(ctx, userID, conversationID, req.Content)
+	if err != nil {
+		logger.WithError(err).Error("Failed to send message")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toMessageResponse(*message))
+}
+
+func (h *handler) GetMessages(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, conversationID, err := validateConversationRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	page, err := h.usecase.GetMessages(ctx, userID, conversationID, c.Query("cursor"), common.ParseLimitParam(c))
+	if err != nil {
+		logger.WithError(err).Error("Failed to get messages")
+		handleError(c, err)
+		return
+	}
+
+	resp := messagesResponse{
+		Messages:   make([]messageResponse, len(page.Messages)),
+		NextCursor: page.NextCursor,
+	}
+	for i, m := range page.Messages {
+		resp.Messages[i] = toMessageResponse(m)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *handler) MarkAsRead(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, conversationID, err := validateConversationRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.MarkAsRead(ctx, userID, conversationID); err != nil {
+		logger.WithError(err).Error("Failed to mark conversation as read")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, markAsReadResponse{Message: "Conversation marked as read"})
+}
+
+func validateConversationRequest(c *gin.Context) (string, string, error) {
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		return "", "", err
+	}
+
+	conversationID := c.Param("id")
+	if err := common.Validate(conversationIDRequest{ID: conversationID}); err != nil {
+		return "", "", err
+	}
+
+	return userID, conversationID, nil
+}
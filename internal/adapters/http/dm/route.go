@@ -0,0 +1,24 @@
+package dm
+
+import "github.com/gin-gonic/gin"
+
+const conversationsPath = "/conversations"
+
+type DMHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *DMHandlerRouter {
+	return &DMHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *DMHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	router.POST(conversationsPath, r.hdl.CreateConversation)
+	router.GET(conversationsPath, r.hdl.GetConversations)
+	router.GET(conversationsPath+"/:id", r.hdl.GetConversation)
+	router.POST(conversationsPath+"/:id/messages", r.hdl.SendMessage)
+	router.GET(conversationsPath+"/:id/messages", r.hdl.GetMessages)
+	router.POST(conversationsPath+"/:id/read", r.hdl.MarkAsRead)
+}
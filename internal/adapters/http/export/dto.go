@@ -0,0 +1,36 @@
+package export
+
+import (
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+)
+
+type exportResponse struct {
+	JobID       string     `json:"job_id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// toExportResponse only exposes the download link while the export is
+// downloadable.
+func toExportResponse(job *export.Job, downloadBasePath string) exportResponse {
+	resp := exportResponse{
+		JobID:       job.ID,
+		Status:      string(job.Status),
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+		Error:       job.Error,
+	}
+
+	if job.Status == export.StatusCompleted {
+		resp.DownloadURL = downloadBasePath + "/" + job.Token
+		resp.ExpiresAt = job.ExpiresAt
+	}
+
+	return resp
+}
@@ -0,0 +1,34 @@
+package export
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
+)
+
+func handleError(c *gin.Context, err error) {
+	var apiError *httperrors.APIError
+
+	switch {
+	case errors.Is(err, user.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid input"))
+	case errors.Is(err, user.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "User not found"))
+	case errors.Is(err, export.ErrExportNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Export not found"))
+	case errors.Is(err, export.ErrExportInProgress):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "An export is already in progress"))
+	case errors.Is(err, export.ErrExportNotReady):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Export is not ready"))
+	case errors.Is(err, export.ErrExportLinkExpired):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Export link has expired"))
+	case errors.As(err, &apiError):
+		c.JSON(apiError.Code, apiError)
+	default:
+		c.JSON(http.StatusInternalServerError, httperrors.NewSimple(httperrors.ErrInternal, "Internal server error"))
+	}
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	ExportUseCase interface {
+		RequestExport(ctx context.Context, userID string) (*export.Job, error)
+		GetExport(ctx context.Context, userID, jobID string) (*export.Job, error)
+		OpenExport(ctx context.Context, token string) (*export.Job, io.ReadCloser, error)
+	}
+
+	handler struct {
+		usecase          ExportUseCase
+		downloadBasePath string
+	}
+)
+
+func NewHandler(usecase ExportUseCase) *handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) RequestExport(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	job, err := h.usecase.RequestExport(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to request export")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toExportResponse(job, h.downloadBasePath))
+}
+
+func (h *handler) GetExport(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	job, err := h.usecase.GetExport(ctx, userID, c.Param("jobID"))
+	if err != nil {
+		logger.WithError(err).Error("Failed to get export")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toExportResponse(job, h.downloadBasePath))
+}
+
+// DownloadExport streams the archive. The token in the URL is the only
+// credential, so the link can be opened directly from a browser.
+func (h *handler) DownloadExport(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	job, archive, err := h.usecase.OpenExport(ctx, c.Param("token"))
+	if err != nil {
+		logger.WithError(err).Error("Failed to open export")
+		handleError(c, err)
+		return
+	}
+	defer archive.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%s.zip"`, job.ID))
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, -1, "application/zip", archive, nil)
+}
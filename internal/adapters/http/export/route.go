@@ -0,0 +1,26 @@
+package export
+
+import "github.com/gin-gonic/gin"
+
+const (
+	exportPath   = "/users/me/export"
+	downloadPath = "/exports"
+)
+
+type ExportHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *ExportHandlerRouter {
+	return &ExportHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *ExportHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	r.hdl.downloadBasePath = router.BasePath() + downloadPath
+
+	router.POST(exportPath, r.hdl.RequestExport)
+	router.GET(exportPath+"/:jobID", r.hdl.GetExport)
+	router.GET(downloadPath+"/:token", r.hdl.DownloadExport)
+}
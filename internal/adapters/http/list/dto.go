@@ -0,0 +1,115 @@
+package list
+
+import (
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+)
+
+type (
+	createListRequest struct {
+		Name        string `json:"name" validate:"required"`
+		Description string `json:"description"`
+		Private     bool   `json:"private"`
+	}
+
+	updateListRequest struct {
+		Name        *string `json:"name,omitempty"`
+		Description *string `json:"description,omitempty"`
+		Private     *bool   `json:"private,omitempty"`
+	}
+
+	addMemberRequest struct {
+		UserID string `json:"user_id" validate:"required,validUUIDFormat"`
+	}
+
+	idParam struct {
+		ID string `validate:"required,validUUIDFormat"`
+	}
+
+	listResponse struct {
+		ID              string    `json:"id"`
+		OwnerID         string    `json:"owner_id"`
+		Name            string    `json:"name"`
+		Description     string    `json:"description"`
+		Private         bool      `json:"private"`
+		MemberCount     int       `json:"member_count"`
+		SubscriberCount int       `json:"subscriber_count"`
+		CreatedAt       time.Time `json:"created_at"`
+		UpdatedAt       time.Time `json:"updated_at"`
+	}
+
+	listsResponse struct {
+		Lists []listResponse `json:"lists"`
+	}
+
+	memberResponse struct {
+		ID          string `json:"id"`
+		Username    string `json:"username"`
+		DisplayName string `json:"display_name,omitempty"`
+		Protected   bool   `json:"protected"`
+	}
+
+	membersResponse struct {
+		Members []memberResponse `json:"members"`
+	}
+
+	messageResponse struct {
+		Message string `json:"message"`
+	}
+)
+
+func (r *createListRequest) ToDomain(ownerID string) *list.List {
+	return &list.List{
+		OwnerID:     ownerID,
+		Name:        r.Name,
+		Description: r.Description,
+		Private:     r.Private,
+	}
+}
+
+func (r *updateListRequest) ToDomain() list.ListUpdate {
+	return list.ListUpdate{
+		Name:        r.Name,
+		Description: r.Description,
+		Private:     r.Private,
+	}
+}
+
+func toListResponse(l list.List) listResponse {
+	return listResponse{
+		ID:              l.ID,
+		OwnerID:         l.OwnerID,
+		Name:            l.Name,
+		Description:     l.Description,
+		Private:         l.Private,
+		MemberCount:     l.MemberCount,
+		SubscriberCount: l.SubscriberCount,
+		CreatedAt:       l.CreatedAt,
+		UpdatedAt:       l.UpdatedAt,
+	}
+}
+
+func toListsResponse(lists []list.List) listsResponse {
+	resp := listsResponse{Lists: make([]listResponse, len(lists))}
+	for i, l := range lists {
+		resp.Lists[i] = toListResponse(l)
+	}
+
+	return resp
+}
+
+func toMembersResponse(members []user.User) membersResponse {
+	resp := membersResponse{Members: make([]memberResponse, len(members))}
+	for i, m := range members {
+		resp.Members[i] = memberResponse{
+			ID:          m.ID,
+			Username:    m.Username,
+			DisplayName: m.DisplayName,
+			Protected:   m.Protected,
+		}
+	}
+
+	return resp
+}
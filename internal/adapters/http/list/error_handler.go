@@ -0,0 +1,52 @@
+package list
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
+)
+
+func handleError(c *gin.Context, err error) {
+	var apiError *httperrors.APIError
+
+	switch {
+	case errors.Is(err, user.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid input"))
+	case errors.Is(err, user.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "User not found"))
+	case errors.Is(err, user.ErrUserBlocked):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "User is blocked"))
+	case errors.Is(err, user.ErrProtectedAccount):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "Account is protected"))
+	case errors.Is(err, list.ErrListNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "List not found"))
+	case errors.Is(err, list.ErrNotListOwner):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "Not the owner of the list"))
+	case errors.Is(err, list.ErrInvalidName):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "List name must have between 1 and 25 characters"))
+	case errors.Is(err, list.ErrDescriptionTooLong):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "List description is too long"))
+	case errors.Is(err, list.ErrTooManyLists):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Too many lists"))
+	case errors.Is(err, list.ErrTooManyMembers):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Too many list members"))
+	case errors.Is(err, list.ErrAlreadyMember):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Already a list member"))
+	case errors.Is(err, list.ErrNotMember):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Not a list member"))
+	case errors.Is(err, list.ErrCannotSubscribeOwnList):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Cannot subscribe to own list"))
+	case errors.Is(err, list.ErrAlreadySubscribed):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Already subscribed"))
+	case errors.Is(err, list.ErrNotSubscribed):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Not subscribed"))
+	case errors.As(err, &apiError):
+		c.JSON(apiError.Code, apiError)
+	default:
+		c.JSON(http.StatusInternalServerError, httperrors.NewSimple(httperrors.ErrInternal, "Internal server error"))
+	}
+}
@@ -0,0 +1,306 @@
+package list
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	ListUseCase interface {
+		CreateList(ctx context.Context, list *list.List) error
+		GetList(ctx context.Context, viewerID, id string) (*list.List, error)
+		GetUserLists(ctx context.Context, viewerID, ownerID string) ([]list.List, error)
+		GetSubscribedLists(ctx context.Context, userID string) ([]list.List, error)
+		UpdateList(ctx context.Context, userID, id string, update list.ListUpdate) (*list.List, error)
+		DeleteList(ctx context.Context, userID, id string) error
+		AddMember(ctx context.Context, userID, listID, memberID string) error
+		RemoveMember(ctx context.Context, userID, listID, memberID string) error
+		GetMembers(ctx context.Context, viewerID, listID string) ([]user.User, error)
+		Subscribe(ctx context.Context, userID, listID string) error
+		Unsubscribe(ctx context.Context, userID, listID string) error
+	}
+
+	handler struct {
+		usecase ListUseCase
+	}
+)
+
+func NewHandler(usecase ListUseCase) *handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) CreateList(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[createListRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	l := req.ToDomain(userID)
+	if err := h.usecase.CreateList(ctx, l); err != nil {
+		logger.WithError(err).Error("Failed to create list")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toListResponse(*l))
+}
+
+func (h *handler) GetList(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	l, err := h.usecase.GetList(ctx, userID, listID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get list")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toListResponse(*l))
+}
+
+func (h *handler) GetUserLists(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, ownerID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	lists, err := h.usecase.GetUserLists(ctx, userID, ownerID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get user lists")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toListsResponse(lists))
+}
+
+func (h *handler) GetSubscribedLists(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	lists, err := h.usecase.GetSubscribedLists(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get subscribed lists")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toListsResponse(lists))
+}
+
+func (h *handler) UpdateList(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[updateListRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	l, err := h.usecase.UpdateList(ctx, userID, listID, req.ToDomain())
+	if err != nil {
+		logger.WithError(err).Error("Failed to update list")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toListResponse(*l))
+}
+
+func (h *handler) DeleteList(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.DeleteList(ctx, userID, listID); err != nil {
+		logger.WithError(err).Error("Failed to delete list")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messageResponse{Message: "List deleted successfully"})
+}
+
+func (h *handler) GetMembers(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	members, err := h.usecase.GetMembers(ctx, userID, listID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get list members")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMembersResponse(members))
+}
+
+func (h *handler) AddMember(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[addMemberRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.AddMember(ctx, userID, listID, req.UserID); err != nil {
+		logger.WithError(err).Error("Failed to add list member")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, messageResponse{Message: "Member added successfully"})
+}
+
+func (h *handler) RemoveMember(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	memberID := c.Param("userID")
+	if err := common.Validate(idParam{ID: memberID}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.RemoveMember(ctx, userID, listID, memberID); err != nil {
+		logger.WithError(err).Error("Failed to remove list member")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messageResponse{Message: "Member removed successfully"})
+}
+
+func (h *handler) Subscribe(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.Subscribe(ctx, userID, listID); err != nil {
+		logger.WithError(err).Error("Failed to subscribe to list")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, messageResponse{Message: "Subscribed successfully"})
+}
+
+func (h *handler) Unsubscribe(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, listID, err := validateListRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.Unsubscribe(ctx, userID, listID); err != nil {
+		logger.WithError(err).Error("Failed to unsubscribe from list")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messageResponse{Message: "Unsubscribed successfully"})
+}
+
+// validateListRequest returns the caller and the :id path parameter, a list
+// or, for GetUserLists, a user.
+func validateListRequest(c *gin.Context) (string, string, error) {
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		return "", "", err
+	}
+
+	id := c.Param("id")
+	if err := common.Validate(idParam{ID: id}); err != nil {
+		return "", "", err
+	}
+
+	return userID, id, nil
+}
@@ -0,0 +1,32 @@
+package list
+
+import "github.com/gin-gonic/gin"
+
+const (
+	listsPath     = "/lists"
+	userListsPath = "/users/:id/lists"
+)
+
+type ListHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *ListHandlerRouter {
+	return &ListHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *ListHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	router.POST(listsPath, r.hdl.CreateList)
+	router.GET(listsPath+"/subscribed", r.hdl.GetSubscribedLists)
+	router.GET(listsPath+"/:id", r.hdl.GetList)
+	router.PATCH(listsPath+"/:id", r.hdl.UpdateList)
+	router.DELETE(listsPath+"/:id", r.hdl.DeleteList)
+	router.GET(listsPath+"/:id/members", r.hdl.GetMembers)
+	router.POST(listsPath+"/:id/members", r.hdl.AddMember)
+	router.DELETE(listsPath+"/:id/members/:userID", r.hdl.RemoveMember)
+	router.POST(listsPath+"/:id/subscription", r.hdl.Subscribe)
+	router.DELETE(listsPath+"/:id/subscription", r.hdl.Unsubscribe)
+	router.GET(userListsPath, r.hdl.GetUserLists)
+}
@@ -0,0 +1,52 @@
+package media
+
+import (
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+)
+
+type mediaResponse struct {
+	ID           string    `json:"id"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	AltText      string    `json:"alt_text"`
+	Sensitive    bool      `json:"sensitive"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// uploadRequest holds the optional form fields sent along with the file.
+type uploadRequest struct {
+	AltText   string `form:"alt_text" validate:"max=1000"`
+	Sensitive bool   `form:"sensitive"`
+}
+
+type metadataRequest struct {
+	AltText   string `json:"alt_text" validate:"max=1000"`
+	Sensitive bool   `json:"sensitive"`
+}
+
+func toMediaResponse(m *media.Media, basePath string) mediaResponse {
+	url := basePath + "/" + m.ID
+
+	return mediaResponse{
+		ID:           m.ID,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		URL:          url,
+		ThumbnailURL: url + "/thumbnail",
+		AltText:      m.AltText,
+		Sensitive:    m.Sensitive,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+type idParam struct {
+	ID string `validate:"required,validUUIDFormat"`
+}
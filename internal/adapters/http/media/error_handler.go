@@ -0,0 +1,37 @@
+package media
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
+)
+
+func handleError(c *gin.Context, err error) {
+	var (
+		apiError      *httperrors.APIError
+		maxBytesError *http.MaxBytesError
+	)
+
+	switch {
+	case errors.Is(err, user.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid input"))
+	case errors.Is(err, media.ErrMediaNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Media not found"))
+	case errors.Is(err, media.ErrMediaTooLarge), errors.As(err, &maxBytesError):
+		c.JSON(http.StatusRequestEntityTooLarge, httperrors.NewSimple(httperrors.ErrTooLarge, "Media is too large"))
+	case errors.Is(err, media.ErrUnsupportedMediaType):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Only JPEG, PNG and GIF images are supported"))
+	case errors.Is(err, media.ErrAltTextTooLong):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Alt text must be at most 1000 characters"))
+	case errors.Is(err, media.ErrInvalidMedia):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid image"))
+	case errors.As(err, &apiError):
+		c.JSON(apiError.Code, apiError)
+	default:
+		c.JSON(http.StatusInternalServerError, httperrors.NewSimple(httperrors.ErrInternal, "Internal server error"))
+	}
+}
@@ -0,0 +1,177 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
+)
+
+// multipartOverhead is the room left for the multipart headers on top of
+// the largest accepted file.
+const multipartOverhead = 64 << 10
+
+type (
+	MediaUseCase interface {
+		Upload(ctx context.Context, userID string, r io.Reader, metadata media.Metadata) (*media.Media, error)
+		UpdateMetadata(ctx context.Context, userID, id string, metadata media.Metadata) (*media.Media, error)
+		OpenMedia(ctx context.Context, id string, thumbnail bool) (*media.Media, io.ReadCloser, error)
+	}
+
+	handler struct {
+		usecase   MediaUseCase
+		mediaPath string
+	}
+)
+
+func NewHandler(usecase MediaUseCase) *handler {
+	return &handler{usecase: usecase}
+}
+
+// Upload takes the image in the "file" field of a multipart form, along
+// with the optional "alt_text" and "sensitive" fields.
+func (h *handler) Upload(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, media.MaxSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		logger.WithError(err).Error("Failed to read uploaded file")
+		handleError(c, wrapFormError(err))
+		return
+	}
+
+	var req uploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind form")
+		handleError(c, httperrors.New(httperrors.ErrBadRequest, "Failed to bind form", err.Error(), nil))
+		return
+	}
+	if err := common.Validate(req); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		logger.WithError(err).Error("Failed to open uploaded file")
+		handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	m, err := h.usecase.Upload(ctx, userID, file, media.Metadata{
+		AltText:   req.AltText,
+		Sensitive: req.Sensitive,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to upload media")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toMediaResponse(m, h.mediaPath))
+}
+
+func (h *handler) UpdateMetadata(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	id := c.Param("id")
+	if err := common.Validate(idParam{ID: id}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[metadataRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	m, err := h.usecase.UpdateMetadata(ctx, userID, id, media.Metadata{
+		AltText:   req.AltText,
+		Sensitive: req.Sensitive,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to update media metadata")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMediaResponse(m, h.mediaPath))
+}
+
+func (h *handler) GetMedia(c *gin.Context) {
+	h.serveMedia(c, false)
+}
+
+func (h *handler) GetThumbnail(c *gin.Context) {
+	h.serveMedia(c, true)
+}
+
+// serveMedia streams an image. Media IDs are random, so the URLs can be
+// used directly in an img tag, without the user header.
+func (h *handler) serveMedia(c *gin.Context, thumbnail bool) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	id := c.Param("id")
+	if err := common.Validate(idParam{ID: id}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	m, blob, err := h.usecase.OpenMedia(ctx, id, thumbnail)
+	if err != nil {
+		logger.WithError(err).Error("Failed to open media")
+		handleError(c, err)
+		return
+	}
+	defer blob.Close()
+
+	contentType := m.ContentType
+	if thumbnail {
+		contentType = "image/jpeg"
+	}
+
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, -1, contentType, blob, nil)
+}
+
+// wrapFormError keeps the too large error of the body reader and reports
+// any other problem with the form as a bad request.
+func wrapFormError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return err
+	}
+
+	return httperrors.New(httperrors.ErrBadRequest, "Invalid upload", "expected an image in the file field of a multipart form", nil)
+}
@@ -0,0 +1,24 @@
+package media
+
+import "github.com/gin-gonic/gin"
+
+const mediaPath = "/media"
+
+type MediaHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *MediaHandlerRouter {
+	return &MediaHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *MediaHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	r.hdl.mediaPath = router.BasePath() + mediaPath
+
+	router.POST(mediaPath, r.hdl.Upload)
+	router.GET(mediaPath+"/:id", r.hdl.GetMedia)
+	router.GET(mediaPath+"/:id/thumbnail", r.hdl.GetThumbnail)
+	router.PUT(mediaPath+"/:id/metadata", r.hdl.UpdateMetadata)
+}
@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+)
+
+type (
+	actorResponse struct {
+		UserID   string `json:"user_id"`
+		Username string `json:"username"`
+	}
+
+	groupResponse struct {
+		Type            string          `json:"type"`
+		TweetID         string          `json:"tweet_id,omitempty"`
+		Actors          []actorResponse `json:"actors"`
+		ActorCount      int             `json:"actor_count"`
+		Summary         string          `json:"summary"`
+		NotificationIDs []string        `json:"notification_ids"`
+		LatestAt        time.Time       `json:"latest_at"`
+		Read            bool            `json:"read"`
+	}
+
+	pageResponse struct {
+		Notifications []groupResponse `json:"notifications"`
+		NextCursor    string          `json:"next_cursor,omitempty"`
+		UnreadCount   int             `json:"unread_count"`
+	}
+
+	unreadCountResponse struct {
+		UnreadCount int `json:"unread_count"`
+	}
+
+	// markAsReadRequest marks the listed notifications as read. An empty body
+	// or an empty list marks every notification as read.
+	markAsReadRequest struct {
+		NotificationIDs []string `json:"notification_ids" validate:"max=100,dive,validUUIDFormat"`
+	}
+)
+
+func toPageResponse(page *notification.Page) pageResponse {
+	groups := make([]groupResponse, len(page.Groups))
+	for i, g := range page.Groups {
+		actors := make([]actorResponse, len(g.Actors))
+		for j, a := range g.Actors {
+			actors[j] = actorResponse{UserID: a.ID, Username: a.Username}
+		}
+
+		groups[i] = groupResponse{
+			Type:            string(g.Type),
+			TweetID:         g.TweetID,
+			Actors:          actors,
+			ActorCount:      g.ActorCount,
+			Summary:         g.Summary,
+			NotificationIDs: g.NotificationIDs,
+			LatestAt:        g.LatestAt,
+			Read:            g.Read,
+		}
+	}
+
+	return pageResponse{
+		Notifications: groups,
+		NextCursor:    page.NextCursor,
+		UnreadCount:   page.UnreadCount,
+	}
+}
@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
+)
+
+func handleError(c *gin.Context, err error) {
+	var apiError *httperrors.APIError
+
+	switch {
+	case errors.Is(err, user.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid input"))
+	case errors.Is(err, notification.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid cursor"))
+	case errors.Is(err, user.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "User not found"))
+	case errors.As(err, &apiError):
+		c.JSON(apiError.Code, apiError)
+	default:
+		c.JSON(http.StatusInternalServerError, httperrors.NewSimple(httperrors.ErrInternal, "Internal server error"))
+	}
+}
@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	NotificationUseCase interface {
+		GetNotifications(ctx context.Context, userID, cursor string, limit int) (*notification.Page, error)
+		GetUnreadCount(ctx context.Context, userID string) (int, error)
+		MarkAsRead(ctx context.Context, userID string, ids []string) (int, error)
+	}
+
+	handler struct {
+		usecase NotificationUseCase
+	}
+)
+
+func NewHandler(usecase NotificationUseCase) *handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) GetNotifications(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	page, err := h.usecase.GetNotifications(ctx, userID, c.Query("cursor"), common.ParseLimitParam(c))
+	if err != nil {
+		logger.WithError(err).Error("Failed to get notifications")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPageResponse(page))
+}
+
+func (h *handler) GetUnreadCount(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	unread, err := h.usecase.GetUnreadCount(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get unread count")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, unreadCountResponse{UnreadCount: unread})
+}
+
+func (h *handler) MarkAsRead(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	var req markAsReadRequest
+	if c.Request.ContentLength != 0 {
+		if req, err = common.BindAndValidate[markAsReadRequest](c); err != nil {
+			logger.WithError(err).Error("Failed to bind and validate request")
+			handleError(c, err)
+			return
+		}
+	}
+
+	unread, err := h.usecase.MarkAsRead(ctx, userID, req.NotificationIDs)
+	if err != nil {
+		logger.WithError(err).Error("Failed to mark notifications as read")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, unreadCountResponse{UnreadCount: unread})
+}
@@ -0,0 +1,21 @@
+package notification
+
+import "github.com/gin-gonic/gin"
+
+const notificationsPath = "/notifications"
+
+type NotificationHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *NotificationHandlerRouter {
+	return &NotificationHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *NotificationHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	router.GET(notificationsPath, r.hdl.GetNotifications)
+	router.GET(notificationsPath+"/unread_count", r.hdl.GetUnreadCount)
+	router.POST(notificationsPath+"/read", r.hdl.MarkAsRead)
+}
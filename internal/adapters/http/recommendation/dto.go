@@ -0,0 +1,26 @@
+package recommendation
+
+import "github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation"
+
+type suggestionResponse struct {
+	UserID        string `json:"user_id"`
+	Username      string `json:"username"`
+	MutualCount   int    `json:"mutual_count"`
+	FollowerCount int    `json:"follower_count"`
+	Explanation   string `json:"explanation"`
+}
+
+func toSuggestionsResponse(suggestions []recommendation.Suggestion) []suggestionResponse {
+	response := make([]suggestionResponse, len(suggestions))
+	for i, s := range suggestions {
+		response[i] = suggestionResponse{
+			UserID:        s.UserID,
+			Username:      s.Username,
+			MutualCount:   s.MutualCount,
+			FollowerCount: s.FollowerCount,
+			Explanation:   s.Reason,
+		}
+	}
+
+	return response
+}
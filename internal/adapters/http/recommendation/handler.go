@@ -0,0 +1,46 @@
+package recommendation
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	RecommendationUseCase interface {
+		GetSuggestions(ctx context.Context, userID string, limit int) ([]recommendation.Suggestion, error)
+	}
+
+	handler struct {
+		usecase RecommendationUseCase
+	}
+)
+
+func NewHandler(useCase RecommendationUseCase) *handler {
+	return &handler{usecase: useCase}
+}
+
+func (h *handler) GetSuggestions(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	suggestions, err := h.usecase.GetSuggestions(ctx, userID, common.ParseLimitParam(c))
+	if err != nil {
+		logger.WithError(err).Error("Failed to get suggestions")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSuggestionsResponse(suggestions))
+}
@@ -0,0 +1,21 @@
+package recommendation
+
+import "github.com/gin-gonic/gin"
+
+const (
+	suggestionsPath = "/users/me/suggestions"
+)
+
+type RecommendationHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *RecommendationHandlerRouter {
+	return &RecommendationHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *RecommendationHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	router.GET(suggestionsPath, r.hdl.GetSuggestions)
+}
@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+const (
+	headerLastEventID = "Last-Event-ID"
+
+	// retryMillis is the reconnection delay suggested to EventSource clients.
+	retryMillis = 3000
+)
+
+type (
+	StreamUseCase interface {
+		Connect(ctx context.Context, userID, lastEventID string) (*stream.Connection, error)
+		OpenSession(ctx context.Context, userID string) (*stream.Session, error)
+	}
+
+	handler struct {
+		usecase   StreamUseCase
+		heartbeat time.Duration
+	}
+)
+
+func NewHandler(usecase StreamUseCase, cfg config.Stream) *handler {
+	return &handler{usecase: usecase, heartbeat: cfg.HeartbeatInterval}
+}
+
+// Stream pushes the user's home events as Server-Sent Events until the client
+// disconnects. Browsers resend the last received ID in the Last-Event-ID
+// header when reconnecting; the last_event_id query parameter serves clients
+// that cannot set headers.
+func (h *handler) Stream(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	lastEventID := c.GetHeader(headerLastEventID)
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	conn, err := h.usecase.Connect(ctx, userID, lastEventID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to connect to stream")
+		handleError(c, err)
+		return
+	}
+	defer conn.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", retryMillis); err != nil {
+		return
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-conn.Events():
+			if !ok {
+				if err := conn.Err(); err != nil {
+					logger.WithError(err).Warn("Closing stream")
+					_, _ = fmt.Fprintf(c.Writer, "event: error\ndata: %q\n\n", err.Error())
+					c.Writer.Flush()
+				}
+				return
+			}
+			if err := writeEvent(c.Writer, event); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, event stream.Event) error {
+	_, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	return err
+}
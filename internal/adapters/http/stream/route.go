@@ -0,0 +1,23 @@
+package stream
+
+import "github.com/gin-gonic/gin"
+
+const (
+	streamPath    = "/stream"
+	websocketPath = "/ws"
+)
+
+type StreamHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *StreamHandlerRouter {
+	return &StreamHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *StreamHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	router.GET(streamPath, r.hdl.Stream)
+	router.GET(websocketPath, r.hdl.WebSocket)
+}
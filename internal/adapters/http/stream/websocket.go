@@ -0,0 +1,175 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"golang.org/x/net/websocket"
+)
+
+// maxClientMessageBytes bounds the commands a client can send.
+const maxClientMessageBytes = 4096
+
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+	actionTyping      = "typing"
+	actionPresence    = "presence"
+)
+
+// WebSocket upgrades the request to a WebSocket session where the client
+// subscribes to topics and sends typing and presence signals. The caller is
+// identified by the X-User-ID header, as in every other endpoint.
+func (h *handler) WebSocket(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	session, err := h.usecase.OpenSession(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to open stream session")
+		handleError(c, err)
+		return
+	}
+	defer session.Close(twcontext.NewDetachedWithRequestID(ctx))
+
+	server := websocket.Server{
+		// Identity comes from X-User-ID, which browsers cannot set on
+		// cross-site requests, so the Origin check is not needed.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = maxClientMessageBytes
+			h.serveSession(ctx, ws, session)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// serveSession writes events, command replies and heartbeats from a single
+// goroutine while another one reads commands, until either side ends.
+func (h *handler) serveSession(ctx context.Context, ws *websocket.Conn, session *stream.Session) {
+	logger := twcontext.Logger(ctx)
+
+	replies := make(chan serverMessage)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var msg clientMessage
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				return
+			}
+			reply := h.handleCommand(ctx, session, msg)
+			select {
+			case replies <- reply:
+			case <-session.Done():
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var msg serverMessage
+		select {
+		case <-closed:
+			return
+		case <-session.Done():
+			if err := session.Err(); err != nil {
+				logger.WithError(err).Warn("Closing stream session")
+				_ = websocket.JSON.Send(ws, serverMessage{Type: "error", Error: err.Error()})
+			}
+			return
+		case <-heartbeat.C:
+			msg = serverMessage{Type: "heartbeat"}
+		case reply := <-replies:
+			msg = reply
+		case event := <-session.Events():
+			msg = serverMessage{Type: string(event.Type), Topic: event.Topic, ID: event.ID, Data: event.Data}
+		}
+
+		if err := websocket.JSON.Send(ws, msg); err != nil {
+			return
+		}
+	}
+}
+
+func (h *handler) handleCommand(ctx context.Context, session *stream.Session, msg clientMessage) serverMessage {
+	switch msg.Action {
+	case actionSubscribe:
+		topics, err := session.Subscribe(ctx, msg.Topics)
+		if err != nil {
+			return errorMessage(ctx, msg.Action, err)
+		}
+		return serverMessage{Type: "subscribed", Topics: topics}
+	case actionUnsubscribe:
+		return serverMessage{Type: "unsubscribed", Topics: session.Unsubscribe(msg.Topics)}
+	case actionTyping:
+		if err := session.Typing(ctx, msg.Topic); err != nil {
+			return errorMessage(ctx, msg.Action, err)
+		}
+		return serverMessage{Type: "ack", Action: msg.Action}
+	case actionPresence:
+		if err := session.SetPresence(ctx, msg.Status); err != nil {
+			return errorMessage(ctx, msg.Action, err)
+		}
+		return serverMessage{Type: "ack", Action: msg.Action}
+	}
+
+	return serverMessage{Type: "error", Action: msg.Action, Error: "unknown action"}
+}
+
+// errorMessage reports client errors as they are and hides internal ones.
+func errorMessage(ctx context.Context, action string, err error) serverMessage {
+	for _, clientErr := range []error{
+		stream.ErrInvalidTopic,
+		stream.ErrTopicForbidden,
+		stream.ErrTooManyTopics,
+		stream.ErrNotSubscribed,
+		stream.ErrInvalidStatus,
+		stream.ErrSessionClosed,
+	} {
+		if errors.Is(err, clientErr) {
+			return serverMessage{Type: "error", Action: action, Error: err.Error()}
+		}
+	}
+
+	twcontext.Logger(ctx).WithError(err).WithField("action", action).Error("Failed to handle stream command")
+	return serverMessage{Type: "error", Action: action, Error: "internal error"}
+}
+
+type (
+	// clientMessage is a command sent by the client, e.g.
+	// {"action":"subscribe","topics":["home","hashtag:golang"]}.
+	clientMessage struct {
+		Action string   `json:"action"`
+		Topics []string `json:"topics,omitempty"`
+		Topic  string   `json:"topic,omitempty"`
+		Status string   `json:"status,omitempty"`
+	}
+
+	serverMessage struct {
+		Type   string          `json:"type"`
+		Action string          `json:"action,omitempty"`
+		Topic  string          `json:"topic,omitempty"`
+		ID     string          `json:"id,omitempty"`
+		Data   json.RawMessage `json:"data,omitempty"`
+		Topics []string        `json:"topics,omitempty"`
+		Error  string          `json:"error,omitempty"`
+	}
+)
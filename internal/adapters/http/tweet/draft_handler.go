@@ -0,0 +1,180 @@
+package tweet
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) CreateDraft(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[draftRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	draft := tweet.Draft{
+		UserID: userID,
+		Parts:  req.Parts,
+	}
+	if err := h.usecase.CreateDraft(ctx, &draft); err != nil {
+		logger.WithError(err).Error("Failed to create draft")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toDraftResponse(draft))
+}
+
+func (h *handler) GetDrafts(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	drafts, err := h.usecase.GetDrafts(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get drafts")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDraftsResponse(drafts))
+}
+
+func (h *handler) GetDraft(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, id, err := validateDraftRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	draft, err := h.usecase.GetDraft(ctx, userID, id)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get draft")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDraftResponse(*draft))
+}
+
+func (h *handler) UpdateDraft(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, id, err := validateDraftRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[draftRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.UpdateDraft(ctx, &tweet.Draft{ID: id, UserID: userID, Parts: req.Parts}); err != nil {
+		logger.WithError(err).Error("Failed to update draft")
+		handleError(c, err)
+		return
+	}
+
+	draft, err := h.usecase.GetDraft(ctx, userID, id)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get draft")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDraftResponse(*draft))
+}
+
+func (h *handler) DeleteDraft(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, id, err := validateDraftRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.DeleteDraft(ctx, userID, id); err != nil {
+		logger.WithError(err).Error("Failed to delete draft")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deleteDraftResponse{Message: "Draft deleted successfully"})
+}
+
+func (h *handler) PublishDraft(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, id, err := validateDraftRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	tweets, err := h.usecase.PublishDraft(ctx, userID, id)
+	if err != nil {
+		logger.WithError(err).Error("Failed to publish draft")
+		handleError(c, err)
+		return
+	}
+
+	ids := make([]string, len(tweets))
+	for i, t := range tweets {
+		ids[i] = t.ID
+	}
+
+	c.JSON(http.StatusCreated, publishDraftResponse{
+		Message:  "Draft published successfully",
+		TweetIDs: ids,
+	})
+}
+
+func validateDraftRequest(c *gin.Context) (string, string, error) {
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		return "", "", err
+	}
+
+	id := c.Param("id")
+	if err := common.Validate(idParam{ID: id}); err != nil {
+		return "", "", err
+	}
+
+	return userID, id, nil
+}
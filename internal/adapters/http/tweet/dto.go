@@ -1,9 +1,106 @@
 package tweet
 
-import "time"
+import (
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+)
 
 type createTweetRequest struct {
-	Content string `json:"content" validate:"required,max=280"`
+	// Content is checked against the weighted tweet length, see
+	// tweettext.Length.
+	Content string `json:"content" validate:"required,tweetLength"`
+	// PublishAt schedules the tweet instead of publishing it right away.
+	PublishAt *time.Time   `json:"publish_at"`
+	Poll      *pollRequest `json:"poll" validate:"omitempty"`
+	// MediaIDs references images previously uploaded to /media.
+	MediaIDs []string `json:"media_ids" validate:"omitempty,max=4,unique,dive,validUUIDFormat"`
+	// Sensitive marks the tweet as not suitable for every audience.
+	Sensitive bool `json:"sensitive"`
+	// Lang is the ISO 639-1 code of the content, used to stem it for
+	// search.
+	Lang string `json:"lang"`
+}
+
+func toAttachments(mediaIDs []string) []tweet.Attachment {
+	attachments := make([]tweet.Attachment, len(mediaIDs))
+	for i, id := range mediaIDs {
+		attachments[i] = tweet.Attachment{MediaID: id}
+	}
+
+	return attachments
+}
+
+type pollRequest struct {
+	Options           []string `json:"options" validate:"required,min=2,max=4,dive,required,max=25"`
+	DurationMinutes   int      `json:"duration_minutes" validate:"required,min=5,max=10080"`
+	ResultsVisibility string   `json:"results_visibility" validate:"omitempty,oneof=after_vote after_close"`
+}
+
+func (r *pollRequest) toDomain() *poll.Poll {
+	options := make([]poll.Option, len(r.Options))
+	for i, text := range r.Options {
+		options[i] = poll.Option{Position: i, Text: text}
+	}
+
+	visibility := poll.ResultsAfterVote
+	if r.ResultsVisibility != "" {
+		visibility = poll.ResultsVisibility(r.ResultsVisibility)
+	}
+
+	return &poll.Poll{
+		Options:           options,
+		ResultsVisibility: visibility,
+		Duration:          time.Duration(r.DurationMinutes) * time.Minute,
+	}
+}
+
+type voteRequest struct {
+	// Option is the zero-based position of the voted option.
+	Option *int `json:"option" validate:"required,min=0"`
+}
+
+type pollOptionResponse struct {
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+	// Votes is omitted while the results are hidden from the viewer.
+	Votes *int `json:"votes,omitempty"`
+}
+
+type pollResponse struct {
+	Options           []pollOptionResponse `json:"options"`
+	ResultsVisibility string               `json:"results_visibility"`
+	EndsAt            time.Time            `json:"ends_at"`
+	Closed            bool                 `json:"closed"`
+	TotalVotes        int                  `json:"total_votes"`
+	VotedOption       *int                 `json:"voted_option"`
+	ResultsVisible    bool                 `json:"results_visible"`
+}
+
+func toPollResponse(p *poll.Poll) *pollResponse {
+	if p == nil {
+		return nil
+	}
+
+	options := make([]pollOptionResponse, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = pollOptionResponse{Position: o.Position, Text: o.Text}
+		if p.ResultsVisible {
+			votes := o.Votes
+			options[i].Votes = &votes
+		}
+	}
+
+	return &pollResponse{
+		Options:           options,
+		ResultsVisibility: string(p.ResultsVisibility),
+		EndsAt:            p.EndsAt,
+		Closed:            p.Closed(time.Now()),
+		TotalVotes:        p.TotalVotes,
+		VotedOption:       p.VotedOption,
+		ResultsVisible:    p.ResultsVisible,
+	}
 }
 
 type createTweetResponse struct {
@@ -11,10 +108,185 @@ type createTweetResponse struct {
 	TweetID string `json:"tweet_id"`
 }
 
+type scheduleTweetResponse struct {
+	Message          string    `json:"message"`
+	ScheduledTweetID string    `json:"scheduled_tweet_id"`
+	PublishAt        time.Time `json:"publish_at"`
+}
+
+type scheduledTweetResponse struct {
+	ID        string     `json:"id"`
+	Content   string     `json:"content"`
+	PublishAt time.Time  `json:"publish_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	FailedAt  *time.Time `json:"failed_at,omitempty"`
+}
+
+type cancelScheduledTweetResponse struct {
+	Message string `json:"message"`
+}
+
 type tweetsResponse struct {
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	Content   string          `json:"content"`
+	Media     []mediaResponse `json:"media,omitempty"`
+	Links     []linkResponse  `json:"links,omitempty"`
+	Sensitive bool            `json:"sensitive,omitempty"`
+	Blurred   bool            `json:"blurred,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Pinned    bool            `json:"pinned,omitempty"`
+	Poll      *pollResponse   `json:"poll,omitempty"`
+}
+
+type searchTweetsResponse struct {
+	Tweets     []tweetsResponse `json:"tweets"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+type mediaResponse struct {
+	ID        string `json:"id"`
+	AltText   string `json:"alt_text,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+}
+
+func toMediaResponse(attachments []tweet.Attachment) []mediaResponse {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	response := make([]mediaResponse, len(attachments))
+	for i, a := range attachments {
+		response[i] = mediaResponse{
+			ID:        a.MediaID,
+			AltText:   a.AltText,
+			Sensitive: a.Sensitive,
+		}
+	}
+
+	return response
+}
+
+type linkResponse struct {
+	URL     string           `json:"url"`
+	Start   int              `json:"start"`
+	End     int              `json:"end"`
+	Preview *previewResponse `json:"preview,omitempty"`
+}
+
+type previewResponse struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+}
+
+func toLinksResponse(links []tweet.Link) []linkResponse {
+	if len(links) == 0 {
+		return nil
+	}
+
+	response := make([]linkResponse, len(links))
+	for i, l := range links {
+		response[i] = linkResponse{URL: l.URL, Start: l.Start, End: l.End}
+		if l.Preview != nil {
+			response[i].Preview = &previewResponse{
+				Title:       l.Preview.Title,
+				Description: l.Preview.Description,
+				ImageURL:    l.Preview.ImageURL,
+			}
+		}
+	}
+
+	return response
+}
+
+type pinTweetResponse struct {
+	Message string `json:"message"`
+}
+
+func toTweetsResponse(tweets []tweet.Tweet) []tweetsResponse {
+	response := make([]tweetsResponse, len(tweets))
+	for i, tweet := range tweets {
+		response[i] = tweetsResponse{
+			ID:        tweet.ID,
+			UserID:    tweet.UserID,
+			Content:   tweet.Content,
+			Media:     toMediaResponse(tweet.Media),
+			Links:     toLinksResponse(tweet.Links),
+			Sensitive: tweet.IsSensitive(),
+			Blurred:   tweet.Blurred,
+			CreatedAt: tweet.CreatedAt,
+			UpdatedAt: tweet.UpdatedAt,
+			Pinned:    tweet.Pinned,
+			Poll:      toPollResponse(tweet.Poll),
+		}
+	}
+
+	return response
+}
+
+func toScheduledTweetsResponse(scheduled []tweet.ScheduledTweet) []scheduledTweetResponse {
+	response := make([]scheduledTweetResponse, len(scheduled))
+	for i, s := range scheduled {
+		response[i] = scheduledTweetResponse{
+			ID:        s.ID,
+			Content:   s.Content,
+			PublishAt: s.PublishAt,
+			CreatedAt: s.CreatedAt,
+			FailedAt:  s.FailedAt,
+		}
+	}
+
+	return response
+}
+
+type draftRequest struct {
+	// Parts holds the tweets of a thread. They are only checked against the
+	// tweet length when the draft is published.
+	Parts []string `json:"parts" validate:"required,min=1,max=25,dive,max=1000"`
+}
+
+type draftResponse struct {
 	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Content   string    `json:"content"`
+	Parts     []string  `json:"parts"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type deleteDraftResponse struct {
+	Message string `json:"message"`
+}
+
+type publishDraftResponse struct {
+	Message  string   `json:"message"`
+	TweetIDs []string `json:"tweet_ids"`
+}
+
+func toDraftResponse(d tweet.Draft) draftResponse {
+	return draftResponse{
+		ID:        d.ID,
+		Parts:     d.Parts,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+		ExpiresAt: d.UpdatedAt.Add(tweet.DraftTTL),
+	}
+}
+
+func toDraftsResponse(drafts []tweet.Draft) []draftResponse {
+	response := make([]draftResponse, len(drafts))
+	for i, d := range drafts {
+		response[i] = toDraftResponse(d)
+	}
+
+	return response
+}
+
+type idParam struct {
+	ID string `validate:"required,validUUIDFormat"`
+}
+
+type userIDParam struct {
+	UserID string `validate:"required,validUUIDFormat"`
 }
@@ -5,12 +5,16 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
 )
 
 func handleError(c *gin.Context, err error) {
 	var apiError *httperrors.APIError
+	var lengthErr *tweet.LengthError
 
 	switch {
 	case errors.Is(err, user.ErrInvalidInput):
@@ -23,6 +27,50 @@ func handleError(c *gin.Context, err error) {
 		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Followee not found"))
 	case errors.Is(err, user.ErrAlreadyFollowing):
 		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Already following"))
+	case errors.Is(err, user.ErrProtectedAccount):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "Account is protected"))
+	case errors.Is(err, tweet.ErrInvalidPublishAt):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "publish_at must be in the future and within a year"))
+	case errors.Is(err, tweet.ErrScheduledTweetNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Scheduled tweet not found"))
+	case errors.Is(err, tweet.ErrDraftNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Draft not found"))
+	case errors.Is(err, tweet.ErrTooManyDrafts):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Too many drafts"))
+	case errors.As(err, &lengthErr):
+		c.JSON(http.StatusBadRequest, httperrors.New(httperrors.ErrBadRequest, "Tweet is too long", err.Error(), map[string]any{
+			"length":     lengthErr.Length,
+			"max_length": tweet.MaxLength,
+			"remaining":  lengthErr.Remaining(),
+		}))
+	case errors.Is(err, tweet.ErrInvalidContent):
+		c.JSON(http.StatusBadRequest, httperrors.New(httperrors.ErrBadRequest, "Invalid tweet content", err.Error(), nil))
+	case errors.Is(err, tweet.ErrTweetNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Tweet not found"))
+	case errors.Is(err, tweet.ErrCannotPinTweet):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "Cannot pin another user's tweet"))
+	case errors.Is(err, tweet.ErrInvalidMedia):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Media not found or not uploaded by you"))
+	case errors.Is(err, tweet.ErrUnsupportedLanguage):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Unsupported language"))
+	case errors.Is(err, tweet.ErrInvalidQuery):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid search query"))
+	case errors.Is(err, tweet.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid cursor"))
+	case errors.Is(err, user.ErrUserBlocked):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "User is blocked"))
+	case errors.Is(err, list.ErrListNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "List not found"))
+	case errors.Is(err, poll.ErrPollNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Poll not found"))
+	case errors.Is(err, poll.ErrInvalidPoll):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid poll"))
+	case errors.Is(err, poll.ErrInvalidOption):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid poll option"))
+	case errors.Is(err, poll.ErrPollClosed):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Poll is closed"))
+	case errors.Is(err, poll.ErrAlreadyVoted):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Already voted"))
 	case errors.As(err, &apiError):
 		c.JSON(apiError.Code, apiError)
 	default:
@@ -2,28 +2,51 @@ package tweet
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
 	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/httperrors"
 )
 
 type (
 	TweetUseCase interface {
 		CreateTweet(ctx context.Context, tweet *tweet.Tweet) error
-		GetTimeline(ctx context.Context, userID string, limit, offset int) ([]tweet.Tweet, error)
+		GetTimeline(ctx context.Context, userID string, includeOwn *bool, limit, offset int) ([]tweet.Tweet, error)
+		GetListTimeline(ctx context.Context, viewerID, listID string, limit, offset int) ([]tweet.Tweet, error)
+		GetUserTweets(ctx context.Context, viewerID, authorID string, limit, offset int) ([]tweet.Tweet, error)
+		ScheduleTweet(ctx context.Context, scheduled *tweet.ScheduledTweet) error
+		GetScheduledTweets(ctx context.Context, userID string) ([]tweet.ScheduledTweet, error)
+		CancelScheduledTweet(ctx context.Context, userID, id string) error
+		CreateDraft(ctx context.Context, draft *tweet.Draft) error
+		GetDrafts(ctx context.Context, userID string) ([]tweet.Draft, error)
+		GetDraft(ctx context.Context, userID, id string) (*tweet.Draft, error)
+		UpdateDraft(ctx context.Context, draft *tweet.Draft) error
+		DeleteDraft(ctx context.Context, userID, id string) error
+		PublishDraft(ctx context.Context, userID, id string) ([]tweet.Tweet, error)
+		PinTweet(ctx context.Context, userID, tweetID string) error
+		UnpinTweet(ctx context.Context, userID, tweetID string) error
+		SearchTweets(ctx context.Context, viewerID, q, cursor string, limit int) (*tweet.SearchPage, error)
+	}
+
+	PollUseCase interface {
+		Vote(ctx context.Context, userID, tweetID string, option int) (*poll.Poll, error)
+		GetPoll(ctx context.Context, viewerID, tweetID string) (*poll.Poll, error)
 	}
 
 	handler struct {
 		usecase TweetUseCase
+		polls   PollUseCase
 	}
 )
 
-func NewHandler(useCase TweetUseCase) *handler {
-	return &handler{usecase: useCase}
+func NewHandler(useCase TweetUseCase, polls PollUseCase) *handler {
+	return &handler{usecase: useCase, polls: polls}
 }
 
 func (h *handler) CreateTweet(c *gin.Context) {
@@ -40,13 +63,34 @@ func (h *handler) CreateTweet(c *gin.Context) {
 	req, err := common.BindAndValidate[createTweetRequest](c)
 	if err != nil {
 		logger.WithError(err).Error("Failed to bind JSON")
+		// Report the length of content that is too long instead of the
+		// bare validation error.
+		var lengthErr *tweet.LengthError
+		if errors.As(tweet.ValidateContent(req.Content), &lengthErr) {
+			err = lengthErr
+		}
 		handleError(c, err)
 		return
 	}
 
+	if req.PublishAt != nil {
+		if req.Poll != nil || len(req.MediaIDs) > 0 || req.Sensitive || req.Lang != "" {
+			handleError(c, httperrors.NewSimple(httperrors.ErrBadRequest, "Scheduled tweets cannot have a poll, media, the sensitive flag or a language"))
+			return
+		}
+		h.scheduleTweet(ctx, c, userID, req)
+		return
+	}
+
 	tweetDomain := tweet.Tweet{
-		UserID:  userID,
-		Content: req.Content,
+		UserID:    userID,
+		Content:   req.Content,
+		Media:     toAttachments(req.MediaIDs),
+		Sensitive: req.Sensitive,
+		Language:  req.Lang,
+	}
+	if req.Poll != nil {
+		tweetDomain.Poll = req.Poll.toDomain()
 	}
 	if err := h.usecase.CreateTweet(ctx, &tweetDomain); err != nil {
 		logger.WithError(err).Error("Failed to create tweet")
@@ -60,6 +104,129 @@ func (h *handler) CreateTweet(c *gin.Context) {
 	})
 }
 
+func (h *handler) scheduleTweet(ctx context.Context, c *gin.Context, userID string, req createTweetRequest) {
+	logger := twcontext.Logger(ctx)
+
+	scheduled := tweet.ScheduledTweet{
+		UserID:    userID,
+		Content:   req.Content,
+		PublishAt: *req.PublishAt,
+	}
+	if err := h.usecase.ScheduleTweet(ctx, &scheduled); err != nil {
+		logger.WithError(err).Error("Failed to schedule tweet")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, scheduleTweetResponse{
+		Message:          "Tweet scheduled successfully",
+		ScheduledTweetID: scheduled.ID,
+		PublishAt:        scheduled.PublishAt,
+	})
+}
+
+func (h *handler) GetScheduledTweets(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	scheduled, err := h.usecase.GetScheduledTweets(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get scheduled tweets")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toScheduledTweetsResponse(scheduled))
+}
+
+func (h *handler) CancelScheduledTweet(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	id := c.Param("id")
+	if err := common.Validate(idParam{ID: id}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.CancelScheduledTweet(ctx, userID, id); err != nil {
+		logger.WithError(err).Error("Failed to cancel scheduled tweet")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cancelScheduledTweetResponse{Message: "Scheduled tweet cancelled successfully"})
+}
+
+func (h *handler) PinTweet(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, tweetID, err := validateTweetRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.PinTweet(ctx, userID, tweetID); err != nil {
+		logger.WithError(err).Error("Failed to pin tweet")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pinTweetResponse{Message: "Tweet pinned successfully"})
+}
+
+func (h *handler) UnpinTweet(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, tweetID, err := validateTweetRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.UnpinTweet(ctx, userID, tweetID); err != nil {
+		logger.WithError(err).Error("Failed to unpin tweet")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pinTweetResponse{Message: "Tweet unpinned successfully"})
+}
+
+func validateTweetRequest(c *gin.Context) (string, string, error) {
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		return "", "", err
+	}
+
+	tweetID := c.Param("id")
+	if err := common.Validate(idParam{ID: tweetID}); err != nil {
+		return "", "", err
+	}
+
+	return userID, tweetID, nil
+}
+
 func (h *handler) GetTimeline(c *gin.Context) {
 	ctx := twcontext.New(c.Request)
 	logger := twcontext.Logger(ctx)
@@ -71,27 +238,88 @@ func (h *handler) GetTimeline(c *gin.Context) {
 		return
 	}
 
+	// include_own overrides the timeline_own_tweets setting of the user.
+	var includeOwn *bool
+	if includeOwnParam := c.Query("include_own"); includeOwnParam != "" {
+		value, err := strconv.ParseBool(includeOwnParam)
+		if err != nil {
+			logger.WithError(err).Error("Failed to parse include_own")
+			handleError(c, httperrors.NewSimple(httperrors.ErrBadRequest, "include_own must be true or false"))
+			return
+		}
+		includeOwn = &value
+	}
+
 	limit, offset := parsePaginationParams(c)
 
-	tweets, err := h.usecase.GetTimeline(ctx, userID, limit, offset)
+	tweets, err := h.usecase.GetTimeline(ctx, userID, includeOwn, limit, offset)
 	if err != nil {
 		logger.WithError(err).Error("Failed to get timeline")
 		handleError(c, err)
 		return
 	}
 
-	response := make([]tweetsResponse, len(tweets))
-	for i, tweet := range tweets {
-		response[i] = tweetsResponse{
-			ID:        tweet.ID,
-			UserID:    tweet.UserID,
-			Content:   tweet.Content,
-			CreatedAt: tweet.CreatedAt,
-			UpdatedAt: tweet.UpdatedAt,
-		}
+	c.JSON(http.StatusOK, toTweetsResponse(tweets))
+}
+
+func (h *handler) GetListTimeline(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	viewerID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	listID := c.Param("id")
+	if err := common.Validate(idParam{ID: listID}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	limit, offset := parsePaginationParams(c)
+
+	tweets, err := h.usecase.GetListTimeline(ctx, viewerID, listID, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get list timeline")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTweetsResponse(tweets))
+}
+
+func (h *handler) GetUserTweets(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	viewerID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	authorID := c.Param("id")
+	if err := common.Validate(userIDParam{UserID: authorID}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	limit, offset := parsePaginationParams(c)
+
+	tweets, err := h.usecase.GetUserTweets(ctx, viewerID, authorID, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get user tweets")
+		handleError(c, err)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, toTweetsResponse(tweets))
 }
 
 const defaultLimit = 100
@@ -0,0 +1,58 @@
+package tweet
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) GetPoll(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, tweetID, err := validateTweetRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	p, err := h.polls.GetPoll(ctx, userID, tweetID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get poll")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPollResponse(p))
+}
+
+func (h *handler) VotePoll(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, tweetID, err := validateTweetRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[voteRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	p, err := h.polls.Vote(ctx, userID, tweetID, *req.Option)
+	if err != nil {
+		logger.WithError(err).Error("Failed to vote")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toPollResponse(p))
+}
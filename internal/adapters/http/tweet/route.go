@@ -3,7 +3,11 @@ package tweet
 import "github.com/gin-gonic/gin"
 
 const (
-	tweetPath = "/tweets"
+	tweetPath        = "/tweets"
+	userTweetsPath   = "/users/:id/tweets"
+	draftsPath       = "/drafts"
+	searchPath       = "/search/tweets"
+	listTimelinePath = "/lists/:id/timeline"
 )
 
 type TweetHandlerRouter struct {
@@ -19,4 +23,19 @@ func NewRouter(hdl *handler) *TweetHandlerRouter {
 func (r *TweetHandlerRouter) AddRoutes(router *gin.RouterGroup) {
 	router.POST(tweetPath, r.hdl.CreateTweet)
 	router.GET(tweetPath+"/timeline", r.hdl.GetTimeline)
+	router.GET(tweetPath+"/scheduled", r.hdl.GetScheduledTweets)
+	router.DELETE(tweetPath+"/scheduled/:id", r.hdl.CancelScheduledTweet)
+	router.POST(tweetPath+"/:id/pin", r.hdl.PinTweet)
+	router.DELETE(tweetPath+"/:id/pin", r.hdl.UnpinTweet)
+	router.GET(tweetPath+"/:id/poll", r.hdl.GetPoll)
+	router.POST(tweetPath+"/:id/poll/votes", r.hdl.VotePoll)
+	router.GET(userTweetsPath, r.hdl.GetUserTweets)
+	router.GET(listTimelinePath, r.hdl.GetListTimeline)
+	router.POST(draftsPath, r.hdl.CreateDraft)
+	router.GET(draftsPath, r.hdl.GetDrafts)
+	router.GET(draftsPath+"/:id", r.hdl.GetDraft)
+	router.PUT(draftsPath+"/:id", r.hdl.UpdateDraft)
+	router.DELETE(draftsPath+"/:id", r.hdl.DeleteDraft)
+	router.POST(draftsPath+"/:id/publish", r.hdl.PublishDraft)
+	router.GET(searchPath, r.hdl.SearchTweets)
 }
@@ -0,0 +1,36 @@
+package tweet
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) SearchTweets(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	// An invalid limit falls back to the default of the use case.
+	limit := common.ParseLimitParam(c)
+
+	page, err := h.usecase.SearchTweets(ctx, userID, c.Query("q"), c.Query("cursor"), limit)
+	if err != nil {
+		logger.WithError(err).Error("Failed to search tweets")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, searchTweetsResponse{
+		Tweets:     toTweetsResponse(page.Tweets),
+		NextCursor: page.NextCursor,
+	})
+}
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) DeactivateUser(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.DeactivateUser(ctx, userID); err != nil {
+		logger.WithError(err).Error("Failed to deactivate user")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accountResponse{
+		Message: "User deactivated successfully",
+	})
+}
+
+func (h *handler) ReactivateUser(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.ReactivateUser(ctx, userID); err != nil {
+		logger.WithError(err).Error("Failed to reactivate user")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accountResponse{
+		Message: "User reactivated successfully",
+	})
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) BlockUser(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[blockUserRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.BlockUser(ctx, userID, req.BlockedID); err != nil {
+		logger.WithError(err).Error("Failed to block user")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, blockUserResponse{
+		Message: "User blocked successfully",
+	})
+}
+
+func (h *handler) UnblockUser(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	blockedID := c.Param("blockedID")
+	if err := common.Validate(blockUserRequest{BlockedID: blockedID}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.UnblockUser(ctx, userID, blockedID); err != nil {
+		logger.WithError(err).Error("Failed to unblock user")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, blockUserResponse{
+		Message: "User unblocked successfully",
+	})
+}
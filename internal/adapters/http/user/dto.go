@@ -2,20 +2,99 @@ package user
 
 import (
 	"strings"
+	"time"
 
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
 )
 
 type createUserRequest struct {
-	Username string `json:"username,omitempty" validate:"required"`
+	Username          string `json:"username,omitempty" validate:"required"`
+	DisplayName       string `json:"display_name,omitempty"`
+	Protected         bool   `json:"protected,omitempty"`
+	OpenDMs           bool   `json:"open_dms,omitempty"`
+	SensitiveMedia    string `json:"sensitive_media,omitempty" validate:"omitempty,oneof=blur hide show"`
+	TimelineOwnTweets bool   `json:"timeline_own_tweets,omitempty"`
 }
 
 func (c *createUserRequest) ToDomain() *user.User {
 	return &user.User{
-		Username: strings.TrimSpace(c.Username),
+		Username:          strings.TrimSpace(c.Username),
+		DisplayName:       c.DisplayName,
+		Protected:         c.Protected,
+		OpenDMs:           c.OpenDMs,
+		SensitiveMedia:    user.SensitiveMedia(c.SensitiveMedia),
+		TimelineOwnTweets: c.TimelineOwnTweets,
 	}
 }
 
+type updateUserRequest struct {
+	DisplayName       *string `json:"display_name,omitempty"`
+	Protected         *bool   `json:"protected,omitempty"`
+	OpenDMs           *bool   `json:"open_dms,omitempty"`
+	SensitiveMedia    *string `json:"sensitive_media,omitempty" validate:"omitempty,oneof=blur hide show"`
+	TimelineOwnTweets *bool   `json:"timeline_own_tweets,omitempty"`
+}
+
+func (u *updateUserRequest) ToDomain() user.UserUpdate {
+	update := user.UserUpdate{
+		DisplayName:       u.DisplayName,
+		Protected:         u.Protected,
+		OpenDMs:           u.OpenDMs,
+		TimelineOwnTweets: u.TimelineOwnTweets,
+	}
+	if u.SensitiveMedia != nil {
+		sensitiveMedia := user.SensitiveMedia(*u.SensitiveMedia)
+		update.SensitiveMedia = &sensitiveMedia
+	}
+
+	return update
+}
+
+type updateUserResponse struct {
+	Message string `json:"message"`
+}
+
+type changeUsernameRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+type changeUsernameResponse struct {
+	Message  string `json:"message"`
+	Username string `json:"username"`
+}
+
+type userResponse struct {
+	ID            string    `json:"id"`
+	Username      string    `json:"username"`
+	DisplayName   string    `json:"display_name"`
+	Protected     bool      `json:"protected"`
+	OpenDMs       bool      `json:"open_dms"`
+	PinnedTweetID string    `json:"pinned_tweet_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	RedirectTo    string    `json:"redirect_to,omitempty"`
+}
+
+func toUserResponse(lookup *user.UsernameLookup) userResponse {
+	resp := userResponse{
+		ID:            lookup.User.ID,
+		Username:      lookup.User.Username,
+		DisplayName:   lookup.User.DisplayName,
+		Protected:     lookup.User.Protected,
+		OpenDMs:       lookup.User.OpenDMs,
+		PinnedTweetID: lookup.User.PinnedTweetID,
+		CreatedAt:     lookup.User.CreatedAt,
+	}
+	if lookup.PreviousUsername != "" {
+		resp.RedirectTo = lookup.User.Username
+	}
+
+	return resp
+}
+
+type accountResponse struct {
+	Message string `json:"message"`
+}
+
 type createUserResponse struct {
 	Message string `json:"message"`
 	UserID  string `json:"user_id"`
@@ -27,4 +106,74 @@ type followUserRequest struct {
 
 type followUserResponse struct {
 	Message string `json:"message"`
+	Status  string `json:"status,omitempty"`
+}
+
+type followUsersRequest struct {
+	FolloweeIDs []string `json:"followee_ids" validate:"required,min=1,max=100,dive,validUUIDFormat"`
+}
+
+type followResultResponse struct {
+	FolloweeID string `json:"followee_id"`
+	Status     string `json:"status"`
+}
+
+type followUsersResponse struct {
+	Results []followResultResponse `json:"results"`
+}
+
+func toFollowUsersResponse(results []user.FollowResult) followUsersResponse {
+	response := followUsersResponse{Results: make([]followResultResponse, len(results))}
+	for i, result := range results {
+		response.Results[i] = followResultResponse{
+			FolloweeID: result.FolloweeID,
+			Status:     string(result.Status),
+		}
+	}
+
+	return response
+}
+
+type blockUserRequest struct {
+	BlockedID string `json:"blocked_id" validate:"required,validUUIDFormat"`
+}
+
+type blockUserResponse struct {
+	Message string `json:"message"`
+}
+
+type followRequestResponse struct {
+	RequesterID string    `json:"requester_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type requesterIDParam struct {
+	RequesterID string `validate:"required,validUUIDFormat"`
+}
+
+type searchUserResponse struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	DisplayName   string `json:"display_name"`
+	Following     bool   `json:"following"`
+	FollowerCount int    `json:"follower_count,omitempty"`
+}
+
+type searchUsersResponse struct {
+	Users []searchUserResponse `json:"users"`
+}
+
+func toSearchUsersResponse(results []user.SearchResult) searchUsersResponse {
+	response := searchUsersResponse{Users: make([]searchUserResponse, len(results))}
+	for i, result := range results {
+		response.Users[i] = searchUserResponse{
+			ID:            result.ID,
+			Username:      result.Username,
+			DisplayName:   result.DisplayName,
+			Following:     result.Following,
+			FollowerCount: result.FollowerCount,
+		}
+	}
+
+	return response
 }
@@ -15,10 +15,48 @@ func handleError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, user.ErrInvalidInput):
 		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Invalid input"))
+	case errors.Is(err, user.ErrDisplayNameTooLong):
+		c.JSON(http.StatusBadRequest, httperrors.New(httperrors.ErrValidation, "Invalid display name", err.Error(), map[string]any{
+			"field":      "display_name",
+			"rule":       "max_length",
+			"max_length": user.MaxDisplayNameLength,
+		}))
+	case errors.Is(err, user.ErrFollowBatchTooLarge):
+		c.JSON(http.StatusBadRequest, httperrors.New(httperrors.ErrValidation, "Too many users in batch", err.Error(), map[string]any{
+			"field":    "followee_ids",
+			"rule":     "max_items",
+			"max_size": user.MaxFollowBatchSize,
+		}))
 	case errors.Is(err, user.ErrCannotFollowSelf):
 		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Cannot follow self"))
 	case errors.Is(err, user.ErrUsernameExists):
 		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrConflict, "User already exists"))
+	case errors.Is(err, user.ErrUsernameConfusable):
+		c.JSON(http.StatusConflict, httperrors.New(httperrors.ErrConflict, "Username not available", err.Error(), map[string]any{
+			"field": "username",
+			"rule":  "confusable",
+		}))
+	case errors.Is(err, user.ErrUsernameTooShort),
+		errors.Is(err, user.ErrUsernameTooLong),
+		errors.Is(err, user.ErrUsernameInvalidCharacters),
+		errors.Is(err, user.ErrUsernameReserved):
+		c.JSON(http.StatusBadRequest, httperrors.New(httperrors.ErrValidation, "Invalid username", err.Error(), map[string]any{
+			"field":      "username",
+			"rule":       usernameRule(err),
+			"min_length": user.MinUsernameLength,
+			"max_length": user.MaxUsernameLength,
+		}))
+	case errors.Is(err, user.ErrUsernameUnavailable):
+		c.JSON(http.StatusConflict, httperrors.New(httperrors.ErrConflict, "Username not available", err.Error(), map[string]any{
+			"field": "username",
+			"rule":  "recently_released",
+		}))
+	case errors.Is(err, user.ErrUsernameUnchanged):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Username is unchanged"))
+	case errors.Is(err, user.ErrUsernameChangeTooSoon):
+		c.JSON(http.StatusTooManyRequests, httperrors.New(httperrors.ErrTooManyRequests, "Username was changed too recently", err.Error(), map[string]any{
+			"cooldown_hours": int(user.UsernameChangeCooldown.Hours()),
+		}))
 	case errors.Is(err, user.ErrUserNotFound):
 		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "User not found"))
 	case errors.Is(err, user.ErrFolloweeNotFound):
@@ -29,9 +67,38 @@ func handleError(c *gin.Context, err error) {
 		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Not following"))
 	case errors.Is(err, user.ErrCannotUnfollowSelf):
 		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Cannot unfollow self"))
+	case errors.Is(err, user.ErrFollowRequestPending):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Follow request already pending"))
+	case errors.Is(err, user.ErrFollowRequestNotFound):
+		c.JSON(http.StatusNotFound, httperrors.NewSimple(httperrors.ErrNotFound, "Follow request not found"))
+	case errors.Is(err, user.ErrUserBlocked):
+		c.JSON(http.StatusForbidden, httperrors.NewSimple(httperrors.ErrForbidden, "User is blocked"))
+	case errors.Is(err, user.ErrCannotBlockSelf):
+		c.JSON(http.StatusBadRequest, httperrors.NewSimple(httperrors.ErrBadRequest, "Cannot block self"))
+	case errors.Is(err, user.ErrAlreadyBlocked):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Already blocked"))
+	case errors.Is(err, user.ErrNotBlocked):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Not blocked"))
+	case errors.Is(err, user.ErrUserNotDeactivated):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "User is not deactivated"))
+	case errors.Is(err, user.ErrReactivationExpired):
+		c.JSON(http.StatusConflict, httperrors.NewSimple(httperrors.ErrConflict, "Reactivation period has expired"))
 	case errors.As(err, &apiError):
 		c.JSON(apiError.Code, apiError)
 	default:
 		c.JSON(http.StatusInternalServerError, httperrors.NewSimple(httperrors.ErrInternal, "Internal server error"))
 	}
 }
+
+func usernameRule(err error) string {
+	switch {
+	case errors.Is(err, user.ErrUsernameTooShort):
+		return "min_length"
+	case errors.Is(err, user.ErrUsernameTooLong):
+		return "max_length"
+	case errors.Is(err, user.ErrUsernameInvalidCharacters):
+		return "charset"
+	default:
+		return "reserved"
+	}
+}
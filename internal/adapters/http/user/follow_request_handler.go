@@ -0,0 +1,96 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) GetFollowRequests(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	requests, err := h.usecase.GetFollowRequests(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get follow requests")
+		handleError(c, err)
+		return
+	}
+
+	response := make([]followRequestResponse, len(requests))
+	for i, request := range requests {
+		response[i] = followRequestResponse{
+			RequesterID: request.RequesterID,
+			CreatedAt:   request.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *handler) ApproveFollowRequest(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	requesterID := c.Param("requesterID")
+	if err := common.Validate(requesterIDParam{RequesterID: requesterID}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.ApproveFollowRequest(ctx, userID, requesterID); err != nil {
+		logger.WithError(err).Error("Failed to approve follow request")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, followUserResponse{
+		Message: "Follow request approved",
+	})
+}
+
+func (h *handler) RejectFollowRequest(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	requesterID := c.Param("requesterID")
+	if err := common.Validate(requesterIDParam{RequesterID: requesterID}); err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.RejectFollowRequest(ctx, userID, requesterID); err != nil {
+		logger.WithError(err).Error("Failed to reject follow request")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, followUserResponse{
+		Message: "Follow request rejected",
+	})
+}
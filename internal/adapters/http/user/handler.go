@@ -14,8 +14,22 @@ import (
 type (
 	UserUseCase interface {
 		CreateUser(ctx context.Context, user *user.User) error
-		FollowUser(ctx context.Context, followerID, followeeID string) error
+		UpdateUser(ctx context.Context, id string, update user.UserUpdate) error
+		ChangeUsername(ctx context.Context, id, username string) error
+		GetUserByUsername(ctx context.Context, username string) (*user.UsernameLookup, error)
+		DeactivateUser(ctx context.Context, id string) error
+		ReactivateUser(ctx context.Context, id string) error
+		FollowUser(ctx context.Context, followerID, followeeID string) (user.FollowStatus, error)
 		UnfollowUser(ctx context.Context, followerID, followeeID string) error
+		FollowUsers(ctx context.Context, followerID string, followeeIDs []string) ([]user.FollowResult, error)
+		UnfollowUsers(ctx context.Context, followerID string, followeeIDs []string) ([]user.FollowResult, error)
+		BlockUser(ctx context.Context, blockerID, blockedID string) error
+		UnblockUser(ctx context.Context, blockerID, blockedID string) error
+		GetFollowRequests(ctx context.Context, targetID string) ([]user.FollowRequest, error)
+		ApproveFollowRequest(ctx context.Context, targetID, requesterID string) error
+		RejectFollowRequest(ctx context.Context, targetID, requesterID string) error
+		SearchUsers(ctx context.Context, viewerID, query string, limit int) ([]user.SearchResult, error)
+		Typeahead(ctx context.Context, viewerID, prefix string, limit int) ([]user.SearchResult, error)
 	}
 
 	handler struct {
@@ -51,6 +65,35 @@ func (h *handler) CreateUser(c *gin.Context) {
 	})
 }
 
+func (h *handler) UpdateUser(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[updateUserRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.UpdateUser(ctx, userID, req.ToDomain()); err != nil {
+		logger.WithError(err).Error("Failed to update user")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updateUserResponse{
+		Message: "User updated successfully",
+	})
+}
+
 func (h *handler) FollowUser(c *gin.Context) {
 	ctx := twcontext.New(c.Request)
 	logger := twcontext.Logger(ctx)
@@ -69,14 +112,24 @@ func (h *handler) FollowUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.FollowUser(ctx, userID, req.FolloweeID); err != nil {
+	status, err := h.usecase.FollowUser(ctx, userID, req.FolloweeID)
+	if err != nil {
 		logger.WithError(err).Error("Failed to follow user")
 		handleError(c, err)
 		return
 	}
 
+	if status == user.FollowStatusPending {
+		c.JSON(http.StatusAccepted, followUserResponse{
+			Message: "Follow request sent",
+			Status:  string(status),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, followUserResponse{
 		Message: "User followed successfully",
+		Status:  string(status),
 	})
 }
 
@@ -114,3 +167,59 @@ func (h *handler) UnfollowUser(c *gin.Context) {
 		Message: "User unfollowed successfully",
 	})
 }
+
+func (h *handler) FollowUsers(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[followUsersRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		handleError(c, err)
+		return
+	}
+
+	results, err := h.usecase.FollowUsers(ctx, userID, req.FolloweeIDs)
+	if err != nil {
+		logger.WithError(err).Error("Failed to follow users")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toFollowUsersResponse(results))
+}
+
+func (h *handler) UnfollowUsers(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[followUsersRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		handleError(c, err)
+		return
+	}
+
+	results, err := h.usecase.UnfollowUsers(ctx, userID, req.FolloweeIDs)
+	if err != nil {
+		logger.WithError(err).Error("Failed to unfollow users")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toFollowUsersResponse(results))
+}
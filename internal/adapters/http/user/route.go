@@ -3,7 +3,9 @@ package user
 import "github.com/gin-gonic/gin"
 
 const (
-	userPath = "/users"
+	userPath           = "/users"
+	followRequestsPath = userPath + "/me/follow-requests"
+	searchPath         = "/search/users"
 )
 
 type UserHandlerRouter struct {
@@ -18,6 +20,20 @@ func NewRouter(hdl *handler) *UserHandlerRouter {
 
 func (r *UserHandlerRouter) AddRoutesV1(v1 *gin.RouterGroup) {
 	v1.POST(userPath, r.hdl.CreateUser)
+	v1.PATCH(userPath+"/me", r.hdl.UpdateUser)
+	v1.PATCH(userPath+"/me/username", r.hdl.ChangeUsername)
+	v1.POST(userPath+"/me/deactivate", r.hdl.DeactivateUser)
+	v1.POST(userPath+"/me/reactivate", r.hdl.ReactivateUser)
+	v1.GET(userPath+"/by-username/:username", r.hdl.GetUserByUsername)
 	v1.POST(userPath+"/follow", r.hdl.FollowUser)
+	v1.POST(userPath+"/follow/batch", r.hdl.FollowUsers)
 	v1.DELETE(userPath+"/unfollow/:followeeID", r.hdl.UnfollowUser)
+	v1.POST(userPath+"/unfollow/batch", r.hdl.UnfollowUsers)
+	v1.POST(userPath+"/block", r.hdl.BlockUser)
+	v1.DELETE(userPath+"/unblock/:blockedID", r.hdl.UnblockUser)
+	v1.GET(followRequestsPath, r.hdl.GetFollowRequests)
+	v1.POST(followRequestsPath+"/:requesterID/approve", r.hdl.ApproveFollowRequest)
+	v1.POST(followRequestsPath+"/:requesterID/reject", r.hdl.RejectFollowRequest)
+	v1.GET(searchPath, r.hdl.SearchUsers)
+	v1.GET(searchPath+"/typeahead", r.hdl.Typeahead)
 }
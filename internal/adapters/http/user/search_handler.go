@@ -0,0 +1,56 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) SearchUsers(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	// An invalid limit falls back to the default of the use case.
+	limit := common.ParseLimitParam(c)
+
+	results, err := h.usecase.SearchUsers(ctx, userID, c.Query("q"), limit)
+	if err != nil {
+		logger.WithError(err).Error("Failed to search users")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSearchUsersResponse(results))
+}
+
+func (h *handler) Typeahead(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	limit := common.ParseLimitParam(c)
+
+	results, err := h.usecase.Typeahead(ctx, userID, c.Query("q"), limit)
+	if err != nil {
+		logger.WithError(err).Error("Failed to suggest users")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSearchUsersResponse(results))
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (h *handler) ChangeUsername(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[changeUsernameRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		handleError(c, err)
+		return
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if err := h.usecase.ChangeUsername(ctx, userID, username); err != nil {
+		logger.WithError(err).Error("Failed to change username")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changeUsernameResponse{
+		Message:  "Username changed successfully",
+		Username: username,
+	})
+}
+
+// GetUserByUsername resolves current and recently released usernames. When
+// the lookup went through the rename history the response carries the
+// current username in redirect_to and a Location header pointing to it.
+func (h *handler) GetUserByUsername(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	lookup, err := h.usecase.GetUserByUsername(ctx, c.Param("username"))
+	if err != nil {
+		logger.WithError(err).Error("Failed to get user by username")
+		handleError(c, err)
+		return
+	}
+
+	resp := toUserResponse(lookup)
+	if resp.RedirectTo != "" {
+		c.Header("Location", strings.TrimSuffix(c.FullPath(), ":username")+resp.RedirectTo)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+)
+
+type (
+	createSubscriptionRequest struct {
+		URL    string   `json:"url" validate:"required,http_url,max=2048"`
+		Events []string `json:"events" validate:"required,min=1,max=10"`
+	}
+
+	subscriptionIDRequest struct {
+		ID string `validate:"required,validUUIDFormat"`
+	}
+
+	// subscriptionResponse only carries the secret right after creation.
+	subscriptionResponse struct {
+		ID                  string     `json:"id"`
+		URL                 string     `json:"url"`
+		Events              []string   `json:"events"`
+		Secret              string     `json:"secret,omitempty"`
+		Active              bool       `json:"active"`
+		ConsecutiveFailures int        `json:"consecutive_failures"`
+		DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+		CreatedAt           time.Time  `json:"created_at"`
+	}
+
+	subscriptionsResponse struct {
+		Subscriptions []subscriptionResponse `json:"subscriptions"`
+	}
+
+	deliveryResponse struct {
+		ID             string          `json:"id"`
+		Event          string          `json:"event"`
+		Status         string          `json:"status"`
+		Attempts       int             `json:"attempts"`
+		ResponseStatus int             `json:"response_status,omitempty"`
+		Error          string          `json:"error,omitempty"`
+		Payload        json.RawMessage `json:"payload"`
+		NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+		LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+		CreatedAt      time.Time       `json:"created_at"`
+	}
+
+	deliveriesResponse struct {
+		Deliveries []deliveryResponse `json:"deliveries"`
+	}
+
+	messageResponse struct {
+		Message string `json:"message"`
+	}
+)
+
+func (r createSubscriptionRequest) eventTypes() []webhook.EventType {
+	events := make([]webhook.EventType, len(r.Events))
+	for i, e := range r.Events {
+		events[i] = webhook.EventType(e)
+	}
+
+	return events
+}
+
+func toSubscriptionResponse(s webhook.Subscription, withSecret bool) subscriptionResponse {
+	events := make([]string, len(s.Events))
+	for i, e := range s.Events {
+		events[i] = string(e)
+	}
+
+	resp := subscriptionResponse{
+		ID:                  s.ID,
+		URL:                 s.URL,
+		Events:              events,
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		CreatedAt:           s.CreatedAt,
+	}
+	if withSecret {
+		resp.Secret = s.Secret
+	}
+
+	return resp
+}
+
+func toDeliveryResponse(d webhook.Delivery) deliveryResponse {
+	resp := deliveryResponse{
+		ID:             d.ID,
+		Event:          string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		Payload:        d.Payload,
+		LastAttemptAt:  d.LastAttemptAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == webhook.DeliveryPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+
+	return resp
+}
@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/http/common"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	WebhookUseCase interface {
+		CreateSubscription(ctx context.Context, userID, rawURL string, events []webhook.EventType) (*webhook.Subscription, error)
+		GetSubscriptions(ctx context.Context, userID string) ([]webhook.Subscription, error)
+		DeleteSubscription(ctx context.Context, userID, id string) error
+		EnableSubscription(ctx context.Context, userID, id string) (*webhook.Subscription, error)
+		GetDeliveries(ctx context.Context, userID, subscriptionID string, limit int) ([]webhook.Delivery, error)
+	}
+
+	handler struct {
+		usecase WebhookUseCase
+	}
+)
+
+func NewHandler(usecase WebhookUseCase) *handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) CreateSubscription(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	req, err := common.BindAndValidate[createSubscriptionRequest](c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to bind and validate request")
+		handleError(c, err)
+		return
+	}
+
+	subscription, err := h.usecase.CreateSubscription(ctx, userID, req.URL, req.eventTypes())
+	if err != nil {
+		logger.WithError(err).Error("Failed to create webhook subscription")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toSubscriptionResponse(*subscription, true))
+}
+
+func (h *handler) GetSubscriptions(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate user ID")
+		handleError(c, err)
+		return
+	}
+
+	subscriptions, err := h.usecase.GetSubscriptions(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get webhook subscriptions")
+		handleError(c, err)
+		return
+	}
+
+	resp := subscriptionsResponse{Subscriptions: make([]subscriptionResponse, len(subscriptions))}
+	for i, s := range subscriptions {
+		resp.Subscriptions[i] = toSubscriptionResponse(s, false)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *handler) DeleteSubscription(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, id, err := validateSubscriptionRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	if err := h.usecase.DeleteSubscription(ctx, userID, id); err != nil {
+		logger.WithError(err).Error("Failed to delete webhook subscription")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messageResponse{Message: "Webhook subscription deleted successfully"})
+}
+
+func (h *handler) EnableSubscription(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, id, err := validateSubscriptionRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	subscription, err := h.usecase.EnableSubscription(ctx, userID, id)
+	if err != nil {
+		logger.WithError(err).Error("Failed to enable webhook subscription")
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSubscriptionResponse(*subscription, false))
+}
+
+func (h *handler) GetDeliveries(c *gin.Context) {
+	ctx := twcontext.New(c.Request)
+	logger := twcontext.Logger(ctx)
+
+	userID, id, err := validateSubscriptionRequest(c)
+	if err != nil {
+		logger.WithError(err).Error("Failed to validate request")
+		handleError(c, err)
+		return
+	}
+
+	deliveries, err := h.usecase.GetDeliveries(ctx, userID, id, common.ParseLimitParam(c))
+	if err != nil {
+		logger.WithError(err).Error("Failed to get webhook deliveries")
+		handleError(c, err)
+		return
+	}
+
+	resp := deliveriesResponse{Deliveries: make([]deliveryResponse, len(deliveries))}
+	for i, d := range deliveries {
+		resp.Deliveries[i] = toDeliveryResponse(d)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func validateSubscriptionRequest(c *gin.Context) (string, string, error) {
+	userID, err := common.ValidateUserID(c)
+	if err != nil {
+		return "", "", err
+	}
+
+	id := c.Param("id")
+	if err := common.Validate(subscriptionIDRequest{ID: id}); err != nil {
+		return "", "", err
+	}
+
+	return userID, id, nil
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import "github.com/gin-gonic/gin"
+
+const webhooksPath = "/webhooks"
+
+type WebhookHandlerRouter struct {
+	hdl *handler
+}
+
+func NewRouter(hdl *handler) *WebhookHandlerRouter {
+	return &WebhookHandlerRouter{
+		hdl: hdl,
+	}
+}
+
+func (r *WebhookHandlerRouter) AddRoutes(router *gin.RouterGroup) {
+	router.POST(webhooksPath, r.hdl.CreateSubscription)
+	router.GET(webhooksPath, r.hdl.GetSubscriptions)
+	router.DELETE(webhooksPath+"/:id", r.hdl.DeleteSubscription)
+	router.POST(webhooksPath+"/:id/enable", r.hdl.EnableSubscription)
+	router.GET(webhooksPath+"/:id/deliveries", r.hdl.GetDeliveries)
+}
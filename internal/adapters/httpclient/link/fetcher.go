@@ -0,0 +1,210 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/safehttp"
+	"golang.org/x/net/html"
+)
+
+const (
+	userAgent = "microblogging-previews/1.0"
+
+	maxRedirects = 3
+	// maxBody bounds how much of the page is read looking for the metadata,
+	// which is expected in the head.
+	maxBody = 512 << 10
+)
+
+type fetcher struct {
+	client *http.Client
+}
+
+func NewFetcher(cfg config.Links) *fetcher {
+	return &fetcher{client: safehttp.NewClient(cfg.PreviewTimeout, cfg.AllowPrivateNetworks)}
+}
+
+// Fetch reads the Open Graph metadata of the page at rawURL, falling back
+// to the title and description tags. Up to maxRedirects redirects are
+// followed; every hop goes through the address check of the client.
+func (f *fetcher) Fetch(ctx context.Context, rawURL string) (*link.Preview, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	for redirects := 0; ; redirects++ {
+		resp, err := f.get(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+			http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+			location, err := resp.Location()
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("invalid redirect: %w", err)
+			}
+			if redirects == maxRedirects {
+				return nil, errors.New("too many redirects")
+			}
+			if location.Scheme != "http" && location.Scheme != "https" {
+				return nil, fmt.Errorf("redirect to unsupported scheme %q", location.Scheme)
+			}
+			target = location
+			continue
+		}
+
+		return read(resp, target)
+	}
+}
+
+func (f *fetcher) get(ctx context.Context, target *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build preview request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+
+	return resp, nil
+}
+
+func read(resp *http.Response, target *url.URL) (*link.Preview, error) {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, link.ErrNoPreview
+	}
+
+	preview := parse(io.LimitReader(resp.Body, maxBody), target)
+	if preview.Title == "" && preview.Description == "" && preview.ImageURL == "" {
+		return nil, link.ErrNoPreview
+	}
+
+	return preview, nil
+}
+
+// parse reads the head of the page. The first value of every meta tag
+// wins.
+func parse(r io.Reader, base *url.URL) *link.Preview {
+	meta := make(map[string]string)
+	var title strings.Builder
+	inTitle := false
+
+	z := html.NewTokenizer(r)
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = title.Len() == 0
+			case "meta":
+				if hasAttr {
+					readMeta(z, meta)
+				}
+			case "body":
+				break loop
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(z.Text())
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				break loop
+			}
+		}
+	}
+
+	return &link.Preview{
+		Title:       clean(first(meta["og:title"], meta["twitter:title"], title.String())),
+		Description: clean(first(meta["og:description"], meta["twitter:description"], meta["description"])),
+		ImageURL:    resolveImage(base, first(meta["og:image"], meta["og:image:url"], meta["twitter:image"])),
+	}
+}
+
+// readMeta stores the content of a meta tag under its property or name.
+func readMeta(z *html.Tokenizer, meta map[string]string) {
+	var key, content string
+	for {
+		name, value, more := z.TagAttr()
+		switch string(name) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(string(value)))
+			}
+		case "content":
+			content = string(value)
+		}
+		if !more {
+			break
+		}
+	}
+
+	if _, ok := meta[key]; key != "" && !ok {
+		meta[key] = content
+	}
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// clean collapses whitespace and drops what Postgres cannot store in a
+// text column.
+func clean(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	s = strings.ReplaceAll(s, "\x00", "")
+
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// resolveImage makes a relative image URL absolute. Only http and https
+// images are kept.
+func resolveImage(base *url.URL, raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	image, err := base.Parse(strings.TrimSpace(raw))
+	if err != nil || (image.Scheme != "http" && image.Scheme != "https") {
+		return ""
+	}
+
+	return clean(image.String())
+}
@@ -0,0 +1,137 @@
+package link_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	adapter "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/httpclient/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/safehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const page = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Fallback title</title>
+  <meta property="og:title" content="Go &amp; you">
+  <meta property="og:description" content="  A   language
+    for  gophers ">
+  <meta property="og:image" content="/img/gopher.png">
+  <meta property="og:title" content="Second title">
+</head>
+<body><meta property="og:image" content="https://example.com/ignored.png"></body>
+</html>`
+
+func newPageServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, page)
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title> Just a
+ title </title><meta name="description" content="Described"></head></html>`)
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body>nothing</body></html>`)
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		fmt.Fprint(w, "\x89PNG")
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.Handle("/redirect", http.RedirectHandler("/page", http.StatusFound))
+	mux.Handle("/loop", http.RedirectHandler("/loop", http.StatusFound))
+	mux.Handle("/ftp", http.RedirectHandler("ftp://example.com/", http.StatusFound))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_fetcher_Fetch(t *testing.T) {
+	server := newPageServer(t)
+	f := adapter.NewFetcher(config.Links{PreviewTimeout: time.Second, AllowPrivateNetworks: true})
+
+	tests := []struct {
+		name    string
+		path    string
+		preview *link.Preview
+		err     error
+	}{
+		{
+			name: "should read the Open Graph metadata of the head",
+			path: "/page",
+			preview: &link.Preview{
+				Title:       "Go & you",
+				Description: "A language for gophers",
+				ImageURL:    server.URL + "/img/gopher.png",
+			},
+		},
+		{
+			name:    "should fall back to the title and description tags",
+			path:    "/plain",
+			preview: &link.Preview{Title: "Just a title", Description: "Described"},
+		},
+		{
+			name: "should follow redirects",
+			path: "/redirect",
+			preview: &link.Preview{
+				Title:       "Go & you",
+				Description: "A language for gophers",
+				ImageURL:    server.URL + "/img/gopher.png",
+			},
+		},
+		{name: "should report pages without metadata", path: "/empty", err: link.ErrNoPreview},
+		{name: "should report content that is not HTML", path: "/image.png", err: link.ErrNoPreview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := f.Fetch(context.Background(), server.URL+tt.path)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.preview, preview)
+		})
+	}
+
+	for _, path := range []string{"/missing", "/loop", "/ftp"} {
+		t.Run("should fail on "+path, func(t *testing.T) {
+			_, err := f.Fetch(context.Background(), server.URL+path)
+
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, link.ErrNoPreview)
+		})
+	}
+
+	t.Run("should fail if the page does not answer in time", func(t *testing.T) {
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(slow.Close)
+		t.Cleanup(func() { close(release) })
+		f := adapter.NewFetcher(config.Links{PreviewTimeout: 50 * time.Millisecond, AllowPrivateNetworks: true})
+
+		_, err := f.Fetch(context.Background(), slow.URL)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should refuse private addresses unless allowed", func(t *testing.T) {
+		f := adapter.NewFetcher(config.Links{PreviewTimeout: time.Second})
+
+		_, err := f.Fetch(context.Background(), server.URL+"/page")
+
+		assert.ErrorIs(t, err, safehttp.ErrForbiddenAddress)
+	})
+}
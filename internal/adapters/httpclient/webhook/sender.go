@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/safehttp"
+)
+
+const (
+	userAgent = "microblogging-webhooks/1.0"
+
+	// maxResponseBody bounds how much of the response is read before the
+	// connection is reused.
+	maxResponseBody = 64 << 10
+)
+
+type sender struct {
+	client *http.Client
+}
+
+func NewSender(cfg config.Webhooks) *sender {
+	return &sender{client: safehttp.NewClient(cfg.Timeout, cfg.AllowPrivateNetworks)}
+}
+
+// Send posts the body to url and returns the response status. Redirects are
+// not followed, so a 3xx is reported as is.
+func (s *sender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	return resp.StatusCode, nil
+}
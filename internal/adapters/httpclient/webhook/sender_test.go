@@ -0,0 +1,106 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	adapter "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/httpclient/webhook"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/safehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, status int) (*httptest.Server, <-chan received) {
+	requests := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func Test_sender_Send(t *testing.T) {
+	body := []byte(`{"event":"tweet.created","data":{"id":"t1"}}`)
+	sentAt := time.Now()
+	headers := map[string]string{
+		"Content-Type":          "application/json",
+		webhook.SignatureHeader: webhook.Sign("secret", sentAt, body),
+		webhook.EventHeader:     string(webhook.EventTweetCreated),
+		webhook.DeliveryHeader:  "d1",
+	}
+
+	t.Run("should deliver a payload the receiver can verify", func(t *testing.T) {
+		server, requests := newReceiver(t, http.StatusNoContent)
+		s := adapter.NewSender(config.Webhooks{Timeout: time.Second, AllowPrivateNetworks: true})
+
+		status, err := s.Send(context.Background(), server.URL, headers, body)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		req := <-requests
+		assert.JSONEq(t, string(body), string(req.body))
+		assert.Equal(t, string(webhook.EventTweetCreated), req.header.Get(webhook.EventHeader))
+		assert.Equal(t, "d1", req.header.Get(webhook.DeliveryHeader))
+		assert.Equal(t, webhook.Sign("secret", sentAt, req.body), req.header.Get(webhook.SignatureHeader))
+	})
+
+	t.Run("should report the status of a failing receiver", func(t *testing.T) {
+		server, _ := newReceiver(t, http.StatusInternalServerError)
+		s := adapter.NewSender(config.Webhooks{Timeout: time.Second, AllowPrivateNetworks: true})
+
+		status, err := s.Send(context.Background(), server.URL, headers, body)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, status)
+	})
+
+	t.Run("should not follow redirects", func(t *testing.T) {
+		server := httptest.NewServer(http.RedirectHandler("http://example.com", http.StatusFound))
+		t.Cleanup(server.Close)
+		s := adapter.NewSender(config.Webhooks{Timeout: time.Second, AllowPrivateNetworks: true})
+
+		status, err := s.Send(context.Background(), server.URL, headers, body)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusFound, status)
+	})
+
+	t.Run("should fail if the receiver does not answer in time", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(release) })
+		s := adapter.NewSender(config.Webhooks{Timeout: 50 * time.Millisecond, AllowPrivateNetworks: true})
+
+		_, err := s.Send(context.Background(), server.URL, headers, body)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should refuse private addresses unless allowed", func(t *testing.T) {
+		server, requests := newReceiver(t, http.StatusOK)
+		s := adapter.NewSender(config.Webhooks{Timeout: time.Second})
+
+		_, err := s.Send(context.Background(), server.URL, headers, body)
+
+		assert.ErrorIs(t, err, safehttp.ErrForbiddenAddress)
+		assert.Empty(t, requests)
+	})
+}
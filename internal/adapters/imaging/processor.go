@@ -0,0 +1,238 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+)
+
+const (
+	jpegQuality      = 90
+	thumbnailQuality = 80
+)
+
+// processor re-encodes uploaded images with the standard library codecs,
+// which do not write EXIF or other metadata back.
+type processor struct{}
+
+func NewProcessor() *processor {
+	return &processor{}
+}
+
+// Process trusts the sniffed content type only, never the file name or the
+// type declared by the client.
+func (p *processor) Process(data []byte) (*media.Processed, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, fmt.Errorf("%w: %s", media.ErrUnsupportedMediaType, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", media.ErrInvalidMedia, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > media.MaxDimension || cfg.Height > media.MaxDimension {
+		return nil, fmt.Errorf("%w: %dx%d image", media.ErrInvalidMedia, cfg.Width, cfg.Height)
+	}
+
+	var (
+		clean bytes.Buffer
+		first image.Image
+	)
+	switch contentType {
+	case "image/jpeg":
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", media.ErrInvalidMedia, err)
+		}
+		if err := jpeg.Encode(&clean, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		first = img
+	case "image/png":
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", media.ErrInvalidMedia, err)
+		}
+		if err := png.Encode(&clean, img); err != nil {
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		first = img
+	case "image/gif":
+		// Every frame is kept so that animations survive; comments and
+		// application extensions are dropped. The frames are counted first
+		// because DecodeAll keeps all of them in memory.
+		if err := checkGIFFrames(data); err != nil {
+			return nil, err
+		}
+		img, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", media.ErrInvalidMedia, err)
+		}
+		if len(img.Image) == 0 {
+			return nil, fmt.Errorf("%w: gif without frames", media.ErrInvalidMedia)
+		}
+		if err := gif.EncodeAll(&clean, img); err != nil {
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		first = img.Image[0]
+	}
+
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(first, media.ThumbnailSize), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	return &media.Processed{
+		ContentType: contentType,
+		Data:        clean.Bytes(),
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Thumbnail:   thumb.Bytes(),
+	}, nil
+}
+
+// checkGIFFrames walks the blocks of a GIF without decoding any pixel and
+// reports ErrInvalidMedia if it has more than MaxGIFFrames frames or their
+// areas add up to more than MaxGIFPixels.
+func checkGIFFrames(data []byte) error {
+	const (
+		headerLen     = 6 + 7 // signature, version and logical screen descriptor
+		descriptorLen = 9
+		colorTableBit = 0x80
+	)
+
+	truncated := fmt.Errorf("%w: truncated gif", media.ErrInvalidMedia)
+	if len(data) < headerLen {
+		return truncated
+	}
+	pos := headerLen
+	if flags := data[10]; flags&colorTableBit != 0 {
+		pos += 3 << (flags&0x07 + 1)
+	}
+
+	// skipSubBlocks returns the position after a sequence of data
+	// sub-blocks, each prefixed with its length and ended by an empty one.
+	skipSubBlocks := func(pos int) (int, bool) {
+		for pos < len(data) {
+			n := int(data[pos])
+			pos++
+			if n == 0 {
+				return pos, true
+			}
+			pos += n
+		}
+		return pos, false
+	}
+
+	var (
+		frames int
+		pixels int64
+		ok     bool
+	)
+	for {
+		if pos >= len(data) {
+			return truncated
+		}
+
+		switch data[pos] {
+		case 0x21: // extension: label and sub-blocks
+			if pos+2 > len(data) {
+				return truncated
+			}
+			if pos, ok = skipSubBlocks(pos + 2); !ok {
+				return truncated
+			}
+		case 0x2c: // image descriptor, optional local color table, LZW code size and sub-blocks
+			if pos+1+descriptorLen > len(data) {
+				return truncated
+			}
+			d := data[pos+1 : pos+1+descriptorLen]
+			width := int64(d[4]) | int64(d[5])<<8
+			height := int64(d[6]) | int64(d[7])<<8
+
+			frames++
+			pixels += width * height
+			if frames > media.MaxGIFFrames {
+				return fmt.Errorf("%w: gif with more than %d frames", media.ErrInvalidMedia, media.MaxGIFFrames)
+			}
+			if pixels > media.MaxGIFPixels {
+				return fmt.Errorf("%w: gif frames exceed %d pixels", media.ErrInvalidMedia, media.MaxGIFPixels)
+			}
+
+			pos += 1 + descriptorLen
+			if flags := d[8]; flags&colorTableBit != 0 {
+				pos += 3 << (flags&0x07 + 1)
+			}
+			if pos, ok = skipSubBlocks(pos + 1); !ok {
+				return truncated
+			}
+		case 0x3b: // trailer
+			return nil
+		default:
+			return fmt.Errorf("%w: unknown gif block 0x%02x", media.ErrInvalidMedia, data[pos])
+		}
+	}
+}
+
+// thumbnail scales src down to fit in a size x size box, averaging the
+// source pixels behind each thumbnail pixel. Transparent areas are drawn
+// over white, since the thumbnail is a JPEG.
+func thumbnail(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	flat := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	tw, th := w, h
+	if w > size || h > size {
+		if w >= h {
+			tw, th = size, max(1, h*size/w)
+		} else {
+			tw, th = max(1, w*size/h), size
+		}
+	}
+	if tw == w && th == h {
+		return flat
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0 := y * h / th
+		y1 := max((y+1)*h/th, y0+1)
+		for x := 0; x < tw; x++ {
+			x0 := x * w / tw
+			x1 := max((x+1)*w/tw, x0+1)
+
+			var r, g, b, n int
+			for sy := y0; sy < y1; sy++ {
+				row := flat.Pix[sy*flat.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,132 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"testing"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	return buf.Bytes()
+}
+
+func newGIF(t *testing.T, frames, size int) []byte {
+	t.Helper()
+
+	anim := &gif.GIF{}
+	for i := 0; i < frames; i++ {
+		anim.Image = append(anim.Image, image.NewPaletted(image.Rect(0, 0, size, size), palette.Plan9))
+		anim.Delay = append(anim.Delay, 10)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, anim))
+
+	return buf.Bytes()
+}
+
+// gifWithFrameSizes builds a GIF whose image descriptors declare the given
+// square sizes, without any pixel data behind them.
+func gifWithFrameSizes(screen int, sizes ...int) []byte {
+	le := func(v int) []byte { return []byte{byte(v), byte(v >> 8)} }
+
+	out := []byte("GIF89a")
+	out = append(out, le(screen)...)
+	out = append(out, le(screen)...)
+	out = append(out, 0, 0, 0)
+	for _, size := range sizes {
+		out = append(out, 0x2c, 0, 0, 0, 0)
+		out = append(out, le(size)...)
+		out = append(out, le(size)...)
+		out = append(out, 0, 2, 0)
+	}
+
+	return append(out, 0x3b)
+}
+
+// withEXIF inserts an APP1 Exif segment right after the SOI marker.
+func withEXIF(data []byte, payload string) []byte {
+	segment := append([]byte("Exif\x00\x00"), payload...)
+	length := len(segment) + 2
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, 0xff, 0xe1, byte(length>>8), byte(length))
+	out = append(out, segment...)
+
+	return append(out, data[2:]...)
+}
+
+func Test_processor_Process(t *testing.T) {
+	p := NewProcessor()
+
+	t.Run("should strip EXIF and build a thumbnail", func(t *testing.T) {
+		data := withEXIF(newJPEG(t, 1200, 600), "GPS 40.4168N 3.7038W")
+		require.True(t, bytes.Contains(data, []byte("GPS 40.4168N")))
+
+		processed, err := p.Process(data)
+		require.NoError(t, err)
+
+		assert.Equal(t, "image/jpeg", processed.ContentType)
+		assert.Equal(t, 1200, processed.Width)
+		assert.Equal(t, 600, processed.Height)
+		assert.False(t, bytes.Contains(processed.Data, []byte("Exif")))
+		assert.False(t, bytes.Contains(processed.Data, []byte("GPS 40.4168N")))
+
+		thumb, err := jpeg.DecodeConfig(bytes.NewReader(processed.Thumbnail))
+		require.NoError(t, err)
+		assert.Equal(t, media.ThumbnailSize, thumb.Width)
+		assert.Equal(t, media.ThumbnailSize/2, thumb.Height)
+	})
+
+	t.Run("should reject files that are not images", func(t *testing.T) {
+		_, err := p.Process([]byte("%PDF-1.4 not an image"))
+		assert.ErrorIs(t, err, media.ErrUnsupportedMediaType)
+	})
+
+	t.Run("should keep every frame of an animated GIF", func(t *testing.T) {
+		processed, err := p.Process(newGIF(t, 3, 16))
+		require.NoError(t, err)
+
+		anim, err := gif.DecodeAll(bytes.NewReader(processed.Data))
+		require.NoError(t, err)
+		assert.Len(t, anim.Image, 3)
+	})
+
+	t.Run("should reject GIFs with too many frames", func(t *testing.T) {
+		_, err := p.Process(newGIF(t, media.MaxGIFFrames+1, 1))
+		assert.ErrorContains(t, err, "frames")
+		assert.ErrorIs(t, err, media.ErrInvalidMedia)
+	})
+
+	t.Run("should reject GIFs whose frames add up to too many pixels", func(t *testing.T) {
+		_, err := p.Process(gifWithFrameSizes(media.MaxDimension, media.MaxDimension, media.MaxDimension))
+		assert.ErrorContains(t, err, "gif frames exceed")
+		assert.ErrorIs(t, err, media.ErrInvalidMedia)
+	})
+
+	t.Run("should reject truncated images", func(t *testing.T) {
+		data := newJPEG(t, 10, 10)
+		_, err := p.Process(data[:20])
+		assert.ErrorIs(t, err, media.ErrInvalidMedia)
+	})
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	ExportPurger interface {
+		PurgeExpiredExports(ctx context.Context) (int, error)
+	}
+
+	PurgeJob struct {
+		purger ExportPurger
+	}
+)
+
+func NewPurgeJob(purger ExportPurger) *PurgeJob {
+	return &PurgeJob{purger: purger}
+}
+
+// Run deletes the archives of exports whose download link has expired.
+func (j *PurgeJob) Run(ctx context.Context) error {
+	purged, err := j.purger.PurgeExpiredExports(ctx)
+	if purged > 0 {
+		twcontext.Logger(ctx).WithField("purged", purged).Info("purged expired exports")
+	}
+
+	return err
+}
@@ -0,0 +1,31 @@
+package link
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	PreviewFetcher interface {
+		FetchQueuedPreviews(ctx context.Context) (int, error)
+	}
+
+	FetchJob struct {
+		fetcher PreviewFetcher
+	}
+)
+
+func NewFetchJob(fetcher PreviewFetcher) *FetchJob {
+	return &FetchJob{fetcher: fetcher}
+}
+
+// Run fetches the link previews that are queued.
+func (j *FetchJob) Run(ctx context.Context) error {
+	fetched, err := j.fetcher.FetchQueuedPreviews(ctx)
+	if fetched > 0 {
+		twcontext.Logger(ctx).WithField("fetched", fetched).Info("fetched link previews")
+	}
+
+	return err
+}
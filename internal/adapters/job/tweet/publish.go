@@ -0,0 +1,31 @@
+package tweet
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	ScheduledPublisher interface {
+		PublishDueTweets(ctx context.Context) (int, error)
+	}
+
+	PublishJob struct {
+		publisher ScheduledPublisher
+	}
+)
+
+func NewPublishJob(publisher ScheduledPublisher) *PublishJob {
+	return &PublishJob{publisher: publisher}
+}
+
+// Run publishes the scheduled tweets that are due.
+func (j *PublishJob) Run(ctx context.Context) error {
+	published, err := j.publisher.PublishDueTweets(ctx)
+	if published > 0 {
+		twcontext.Logger(ctx).WithField("published", published).Info("published scheduled tweets")
+	}
+
+	return err
+}
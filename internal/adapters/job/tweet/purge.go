@@ -0,0 +1,31 @@
+package tweet
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	DraftPurger interface {
+		PurgeExpiredDrafts(ctx context.Context) (int, error)
+	}
+
+	PurgeDraftsJob struct {
+		purger DraftPurger
+	}
+)
+
+func NewPurgeDraftsJob(purger DraftPurger) *PurgeDraftsJob {
+	return &PurgeDraftsJob{purger: purger}
+}
+
+// Run deletes the drafts that have expired.
+func (j *PurgeDraftsJob) Run(ctx context.Context) error {
+	purged, err := j.purger.PurgeExpiredDrafts(ctx)
+	if purged > 0 {
+		twcontext.Logger(ctx).WithField("purged", purged).Info("purged expired drafts")
+	}
+
+	return err
+}
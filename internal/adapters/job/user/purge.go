@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	AccountPurger interface {
+		PurgeDeactivatedUsers(ctx context.Context) (int, error)
+	}
+
+	PurgeJob struct {
+		purger AccountPurger
+	}
+)
+
+func NewPurgeJob(purger AccountPurger) *PurgeJob {
+	return &PurgeJob{purger: purger}
+}
+
+// Run permanently deletes accounts whose reactivation period has expired.
+func (j *PurgeJob) Run(ctx context.Context) error {
+	deleted, err := j.purger.PurgeDeactivatedUsers(ctx)
+	if deleted > 0 {
+		twcontext.Logger(ctx).WithField("deleted", deleted).Info("purged deactivated users")
+	}
+
+	return err
+}
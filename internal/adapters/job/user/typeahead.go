@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	TypeaheadIndexer interface {
+		IndexTypeahead(ctx context.Context) (int, error)
+	}
+
+	TypeaheadJob struct {
+		indexer TypeaheadIndexer
+	}
+)
+
+func NewTypeaheadJob(indexer TypeaheadIndexer) *TypeaheadJob {
+	return &TypeaheadJob{indexer: indexer}
+}
+
+// Run copies the users updated since the last run to the typeahead index.
+func (j *TypeaheadJob) Run(ctx context.Context) error {
+	indexed, err := j.indexer.IndexTypeahead(ctx)
+	if indexed > 0 {
+		twcontext.Logger(ctx).WithField("indexed", indexed).Info("indexed users for typeahead")
+	}
+
+	return err
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type (
+	Deliverer interface {
+		DeliverPending(ctx context.Context) (int, error)
+	}
+
+	DispatchJob struct {
+		deliverer Deliverer
+	}
+)
+
+func NewDispatchJob(deliverer Deliverer) *DispatchJob {
+	return &DispatchJob{deliverer: deliverer}
+}
+
+// Run sends the webhook deliveries that are due.
+func (j *DispatchJob) Run(ctx context.Context) error {
+	delivered, err := j.deliverer.DeliverPending(ctx)
+	if delivered > 0 {
+		twcontext.Logger(ctx).WithField("delivered", delivered).Info("dispatched webhook deliveries")
+	}
+
+	return err
+}
@@ -0,0 +1,110 @@
+package dm
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+)
+
+type Conversation struct {
+	ID            uuid.UUID  `gorm:"primaryKey;column:id"`
+	IsGroup       bool       `gorm:"column:is_group;not null"`
+	CreatedBy     *uuid.UUID `gorm:"type:uuid;column:created_by"`
+	DirectKey     *string    `gorm:"column:direct_key;unique"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
+	LastMessageAt *time.Time `gorm:"column:last_message_at"`
+}
+
+func (Conversation) TableName() string {
+	return "conversations"
+}
+
+func (c *Conversation) toDomain() dm.Conversation {
+	conversation := dm.Conversation{
+		ID:            c.ID.String(),
+		IsGroup:       c.IsGroup,
+		CreatedAt:     c.CreatedAt,
+		LastMessageAt: c.LastMessageAt,
+	}
+	if c.CreatedBy != nil {
+		conversation.CreatedBy = c.CreatedBy.String()
+	}
+
+	return conversation
+}
+
+type Participant struct {
+	ConversationID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	JoinedAt       time.Time  `gorm:"column:joined_at;autoCreateTime"`
+	LastReadAt     *time.Time `gorm:"column:last_read_at"`
+}
+
+func (Participant) TableName() string {
+	return "conversation_participants"
+}
+
+type Message struct {
+	ID             uuid.UUID `gorm:"primaryKey;column:id"`
+	ConversationID uuid.UUID `gorm:"type:uuid;not null"`
+	SenderID       uuid.UUID `gorm:"type:uuid;not null"`
+	Content        string    `gorm:"column:content;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Message) TableName() string {
+	return "direct_messages"
+}
+
+func (m *Message) toDomain() dm.Message {
+	return dm.Message{
+		ID:             m.ID.String(),
+		ConversationID: m.ConversationID.String(),
+		SenderID:       m.SenderID.String(),
+		Content:        m.Content,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+func messageFromDomain(m *dm.Message) (*Message, error) {
+	conversationID, err := uuid.Parse(m.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	senderID, err := uuid.Parse(m.SenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Content:        m.Content,
+	}, nil
+}
+
+// participantRow is a participant joined with its username.
+type participantRow struct {
+	ConversationID string
+	UserID         string
+	Username       string
+	LastReadAt     *time.Time
+}
+
+type unreadRow struct {
+	ConversationID string
+	Unread         int
+}
+
+// directKey identifies the one-to-one conversation between two users
+// regardless of who started it.
+func directKey(userID, otherID string) string {
+	if otherID < userID {
+		userID, otherID = otherID, userID
+	}
+
+	return userID + ":" + otherID
+}
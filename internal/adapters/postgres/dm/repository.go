@@ -0,0 +1,266 @@
+package dm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+const (
+	// memberConversations restricts conversations to those userID takes
+	// part in.
+	memberConversations = `
+SELECT c.* FROM conversations c
+JOIN conversation_participants p ON p.conversation_id = c.id AND p.user_id = ?`
+
+	participantsQuery = `
+SELECT p.conversation_id, p.user_id, u.username, p.last_read_at
+FROM conversation_participants p
+JOIN users u ON u.id = p.user_id
+WHERE p.conversation_id IN ?
+ORDER BY p.joined_at, u.username`
+
+	// unreadQuery counts, per conversation, the messages from others sent
+	// after the read marker of userID.
+	unreadQuery = `
+SELECT m.conversation_id, COUNT(*) AS unread
+FROM direct_messages m
+JOIN conversation_participants p ON p.conversation_id = m.conversation_id AND p.user_id = ?
+WHERE m.conversation_id IN ? AND m.sender_id <> p.user_id
+  AND (p.last_read_at IS NULL OR m.created_at > p.last_read_at)
+GROUP BY m.conversation_id`
+)
+
+type conversationRepository struct {
+	db db.Connections
+}
+
+func NewConversationRepository(db db.Connections) *conversationRepository {
+	return &conversationRepository{db: db}
+}
+
+// CreateConversation stores the conversation and its participants in a
+// single transaction. If a concurrent request created the same one-to-one
+// conversation first, that one is returned instead.
+func (r *conversationRepository) CreateConversation(ctx context.Context, conversation *dm.Conversation) error {
+	createdBy, err := uuid.Parse(conversation.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("invalid createdBy: %w", err)
+	}
+
+	participants := make([]Participant, 0, len(conversation.Participants))
+	for _, p := range conversation.Participants {
+		userID, err := uuid.Parse(p.UserID)
+		if err != nil {
+			return fmt.Errorf("invalid participant: %w", err)
+		}
+		participants = append(participants, Participant{UserID: userID})
+	}
+
+	model := &Conversation{
+		ID:        uuid.New(),
+		IsGroup:   conversation.IsGroup,
+		CreatedBy: &createdBy,
+	}
+	if !conversation.IsGroup && len(participants) == 2 {
+		key := directKey(participants[0].UserID.String(), participants[1].UserID.String())
+		model.DirectKey = &key
+	}
+
+	err = r.db.MasterConn.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(model).Error; err != nil {
+				return err
+			}
+
+			for i := range participants {
+				participants[i].ConversationID = model.ID
+			}
+			if err := tx.Create(&participants).Error; err != nil {
+				return fmt.Errorf("error creating participants: %w", err)
+			}
+
+			return nil
+		})
+	if errors.Is(err, gorm.ErrDuplicatedKey) && model.DirectKey != nil {
+		existing, err := r.findConversation(ctx, conversation.CreatedBy, "c.direct_key = ?", *model.DirectKey)
+		if err != nil {
+			return err
+		}
+		*conversation = *existing
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	conversation.ID = model.ID.String()
+	conversation.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *conversationRepository) FindDirectConversation(ctx context.Context, userID, otherID string) (*dm.Conversation, error) {
+	return r.findConversation(ctx, userID, "c.direct_key = ?", directKey(userID, otherID))
+}
+
+func (r *conversationRepository) GetConversation(ctx context.Context, userID, id string) (*dm.Conversation, error) {
+	return r.findConversation(ctx, userID, "c.id = ?", id)
+}
+
+func (r *conversationRepository) GetConversations(ctx context.Context, userID string, limit int) ([]dm.Conversation, error) {
+	var models []Conversation
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(memberConversations+" ORDER BY COALESCE(c.last_message_at, c.created_at) DESC, c.id DESC LIMIT ?", userID, limit).
+		Scan(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find conversations: %w", err)
+	}
+
+	return r.withDetails(ctx, userID, models)
+}
+
+// CreateMessage stores the message and bumps the last activity of its
+// conversation.
+func (r *conversationRepository) CreateMessage(ctx context.Context, message *dm.Message) error {
+	model, err := messageFromDomain(message)
+	if err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(model).Error; err != nil {
+				return fmt.Errorf("error creating message: %w", err)
+			}
+
+			if err := tx.
+				Model(&Conversation{}).
+				Where("id = ?", model.ConversationID).
+				Update("last_message_at", model.CreatedAt).Error; err != nil {
+				return fmt.Errorf("error updating conversation: %w", err)
+			}
+
+			return nil
+		}); err != nil {
+		return fmt.Errorf("failed to create message: %w", err)
+	}
+
+	message.ID = model.ID.String()
+	message.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *conversationRepository) GetMessages(ctx context.Context, conversationID string, after *dm.Cursor, limit int) ([]dm.Message, error) {
+	query := r.db.MasterConn.
+		WithContext(ctx).
+		Where("conversation_id = ?", conversationID)
+	if after != nil {
+		query = query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var models []Message
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find messages: %w", err)
+	}
+
+	messages := make([]dm.Message, 0, len(models))
+	for _, m := range models {
+		messages = append(messages, m.toDomain())
+	}
+
+	return messages, nil
+}
+
+func (r *conversationRepository) MarkAsRead(ctx context.Context, conversationID, userID string, at time.Time) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Participant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Update("last_read_at", at).Error; err != nil {
+		return fmt.Errorf("failed to mark conversation as read: %w", err)
+	}
+
+	return nil
+}
+
+func (r *conversationRepository) findConversation(ctx context.Context, userID, condition string, args ...any) (*dm.Conversation, error) {
+	var models []Conversation
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(memberConversations+" WHERE "+condition, append([]any{userID}, args...)...).
+		Scan(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find conversation: %w", err)
+	}
+	if len(models) == 0 {
+		return nil, dm.ErrConversationNotFound
+	}
+
+	conversations, err := r.withDetails(ctx, userID, models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversations[0], nil
+}
+
+// withDetails loads the participants of the conversations and the unread
+// count of userID in each of them.
+func (r *conversationRepository) withDetails(ctx context.Context, userID string, models []Conversation) ([]dm.Conversation, error) {
+	if len(models) == 0 {
+		return []dm.Conversation{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(models))
+	for i, m := range models {
+		ids[i] = m.ID
+	}
+
+	conn := r.db.MasterConn.WithContext(ctx)
+
+	var participants []participantRow
+	if err := conn.Raw(participantsQuery, ids).Scan(&participants).Error; err != nil {
+		return nil, fmt.Errorf("failed to find participants: %w", err)
+	}
+
+	var unread []unreadRow
+	if err := conn.Raw(unreadQuery, userID, ids).Scan(&unread).Error; err != nil {
+		return nil, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	byConversation := make(map[string][]dm.Participant, len(models))
+	for _, p := range participants {
+		byConversation[p.ConversationID] = append(byConversation[p.ConversationID], dm.Participant{
+			UserID:     p.UserID,
+			Username:   p.Username,
+			LastReadAt: p.LastReadAt,
+		})
+	}
+
+	unreadByConversation := make(map[string]int, len(unread))
+	for _, u := range unread {
+		unreadByConversation[u.ConversationID] = u.Unread
+	}
+
+	conversations := make([]dm.Conversation, 0, len(models))
+	for _, m := range models {
+		c := m.toDomain()
+		c.Participants = byConversation[c.ID]
+		c.UnreadCount = unreadByConversation[c.ID]
+		conversations = append(conversations, c)
+	}
+
+	return conversations, nil
+}
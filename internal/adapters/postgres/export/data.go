@@ -0,0 +1,204 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+)
+
+const (
+	// profileQuery resolves the pinned tweet like the profile does, so a
+	// deleted tweet is not exported as pinned.
+	profileQuery = `
+SELECT u.id, u.username, u.display_name, u.protected, u.open_dms, u.sensitive_media, u.timeline_own_tweets,
+	COALESCE(t.id::text, '') AS pinned_tweet_id, u.created_at, u.updated_at
+FROM users u LEFT JOIN tweets t ON t.id = u.pinned_tweet_id AND t.deleted_at IS NULL
+WHERE u.id = ?`
+
+	followingQuery = `
+SELECT u.id AS user_id, u.username, f.created_at
+FROM follows f JOIN users u ON u.id = f.followee_id
+WHERE f.follower_id = ?
+ORDER BY f.created_at DESC`
+
+	followersQuery = `
+SELECT u.id AS user_id, u.username, f.created_at
+FROM follows f JOIN users u ON u.id = f.follower_id
+WHERE f.followee_id = ?
+ORDER BY f.created_at DESC`
+
+	blockedQuery = `
+SELECT u.id AS user_id, u.username, b.created_at
+FROM blocks b JOIN users u ON u.id = b.blocked_id
+WHERE b.blocker_id = ?
+ORDER BY b.created_at DESC`
+
+	conversationsQuery = `
+SELECT c.id, c.is_group, c.created_at, string_agg(p.user_id::text, ',' ORDER BY p.user_id) AS participant_ids
+FROM conversations c
+JOIN conversation_participants me ON me.conversation_id = c.id AND me.user_id = ?
+JOIN conversation_participants p ON p.conversation_id = c.id
+GROUP BY c.id
+ORDER BY c.created_at DESC`
+
+	messagesQuery = `
+SELECT m.id, m.conversation_id, m.sender_id, m.content, m.created_at
+FROM direct_messages m
+JOIN conversation_participants p ON p.conversation_id = m.conversation_id AND p.user_id = ?
+ORDER BY m.created_at DESC`
+
+	listMembersQuery = `
+SELECT lm.list_id, u.id AS user_id, u.username, lm.created_at
+FROM list_members lm JOIN lists l ON l.id = lm.list_id JOIN users u ON u.id = lm.user_id
+WHERE l.owner_id = ?
+ORDER BY lm.created_at DESC`
+
+	// Private lists of other users are left out: their owners did not share
+	// them, not even with their members.
+	listMembershipsQuery = `
+SELECT l.id AS list_id, l.name, l.owner_id, lm.created_at
+FROM list_members lm JOIN lists l ON l.id = lm.list_id
+WHERE lm.user_id = ? AND l.owner_id <> lm.user_id AND NOT l.private
+ORDER BY lm.created_at DESC`
+
+	listSubscriptionsQuery = `
+SELECT l.id AS list_id, l.name, l.owner_id, ls.created_at
+FROM list_subscriptions ls JOIN lists l ON l.id = ls.list_id
+WHERE ls.user_id = ? AND l.owner_id <> ls.user_id AND NOT l.private
+ORDER BY ls.created_at DESC`
+
+	notificationsQuery = `
+SELECT id, type, actor_id, COALESCE(tweet_id::text, '') AS tweet_id, created_at, read_at
+FROM notifications
+WHERE recipient_id = ?
+ORDER BY created_at DESC`
+)
+
+// GetUserData collects everything stored about the user.
+func (r *exportRepository) GetUserData(ctx context.Context, userID string) (*export.Data, error) {
+	conn := r.db.MasterConn.WithContext(ctx)
+
+	var p profile
+	if err := conn.Raw(profileQuery, userID).Scan(&p).Error; err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	data := &export.Data{
+		Profile: export.Profile{
+			ID:                p.ID,
+			Username:          p.Username,
+			DisplayName:       p.DisplayName,
+			Protected:         p.Protected,
+			OpenDMs:           p.OpenDMs,
+			SensitiveMedia:    p.SensitiveMedia,
+			TimelineOwnTweets: p.TimelineOwnTweets,
+			PinnedTweetID:     p.PinnedTweetID,
+			CreatedAt:         p.CreatedAt,
+			UpdatedAt:         p.UpdatedAt,
+		},
+	}
+
+	if err := conn.
+		Raw("SELECT username, changed_at FROM username_history WHERE user_id = ? ORDER BY changed_at DESC", userID).
+		Scan(&data.UsernameHistory).Error; err != nil {
+		return nil, fmt.Errorf("failed to get username history: %w", err)
+	}
+
+	if err := conn.
+		Raw("SELECT id, content, created_at FROM tweets WHERE user_id = ? AND deleted_at IS NULL ORDER BY created_at DESC", userID).
+		Scan(&data.Tweets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tweets: %w", err)
+	}
+
+	if err := conn.
+		Raw("SELECT id, content, publish_at, failed_at, created_at FROM scheduled_tweets WHERE user_id = ? ORDER BY publish_at", userID).
+		Scan(&data.ScheduledTweets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get scheduled tweets: %w", err)
+	}
+
+	var drafts []draft
+	if err := conn.
+		Raw("SELECT id, parts, created_at, updated_at FROM drafts WHERE user_id = ? ORDER BY updated_at DESC", userID).
+		Scan(&drafts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get drafts: %w", err)
+	}
+	var err error
+	if data.Drafts, err = toDrafts(drafts); err != nil {
+		return nil, err
+	}
+
+	if err := conn.
+		Raw("SELECT id, content_type, size, width, height, alt_text, sensitive, blob_key, created_at FROM media WHERE user_id = ? ORDER BY created_at DESC", userID).
+		Scan(&data.Media).Error; err != nil {
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	relations := []struct {
+		query  string
+		target *[]export.Relation
+	}{
+		{followingQuery, &data.Following},
+		{followersQuery, &data.Followers},
+		{blockedQuery, &data.Blocked},
+	}
+	for _, rel := range relations {
+		var rows []relation
+		if err := conn.Raw(rel.query, userID).Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to get relations: %w", err)
+		}
+		*rel.target = toRelations(rows)
+	}
+
+	if err := conn.
+		Raw("SELECT requester_id, target_id, created_at FROM follow_requests WHERE requester_id = ? OR target_id = ? ORDER BY created_at DESC", userID, userID).
+		Scan(&data.FollowRequests).Error; err != nil {
+		return nil, fmt.Errorf("failed to get follow requests: %w", err)
+	}
+
+	var conversations []conversation
+	if err := conn.Raw(conversationsQuery, userID).Scan(&conversations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get conversations: %w", err)
+	}
+	data.Conversations = toConversations(conversations)
+
+	if err := conn.Raw(messagesQuery, userID).Scan(&data.Messages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	var members []listMember
+	if err := conn.Raw(listMembersQuery, userID).Scan(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to get list members: %w", err)
+	}
+	var lists []list
+	if err := conn.
+		Raw("SELECT id, name, description, private, created_at FROM lists WHERE owner_id = ? ORDER BY created_at DESC", userID).
+		Scan(&lists).Error; err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+	data.Lists = toLists(lists, members)
+
+	if err := conn.Raw(listMembershipsQuery, userID).Scan(&data.ListMemberships).Error; err != nil {
+		return nil, fmt.Errorf("failed to get list memberships: %w", err)
+	}
+
+	if err := conn.Raw(listSubscriptionsQuery, userID).Scan(&data.ListSubscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get list subscriptions: %w", err)
+	}
+
+	if err := conn.Raw(notificationsQuery, userID).Scan(&data.Notifications).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	var webhooks []webhookSubscription
+	if err := conn.
+		Raw("SELECT id, url, events, active, disabled_at, created_at FROM webhook_subscriptions WHERE user_id = ? ORDER BY created_at DESC", userID).
+		Scan(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	if data.WebhookSubscriptions, err = toWebhookSubscriptions(webhooks); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
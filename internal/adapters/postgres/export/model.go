@@ -0,0 +1,203 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+)
+
+type Job struct {
+	ID          uuid.UUID  `gorm:"primaryKey;column:id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null"`
+	Status      string     `gorm:"column:status;not null"`
+	Token       string     `gorm:"column:token;unique;not null"`
+	BlobKey     string     `gorm:"column:blob_key"`
+	Error       string     `gorm:"column:error"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+}
+
+func (Job) TableName() string {
+	return "export_jobs"
+}
+
+func (j *Job) toDomain() export.Job {
+	return export.Job{
+		ID:          j.ID.String(),
+		UserID:      j.UserID.String(),
+		Status:      export.Status(j.Status),
+		Token:       j.Token,
+		BlobKey:     j.BlobKey,
+		Error:       j.Error,
+		CreatedAt:   j.CreatedAt,
+		CompletedAt: j.CompletedAt,
+		ExpiresAt:   j.ExpiresAt,
+	}
+}
+
+func fromDomain(j *export.Job) (*Job, error) {
+	userID, err := uuid.Parse(j.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Job{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Status:      string(j.Status),
+		Token:       j.Token,
+		BlobKey:     j.BlobKey,
+		Error:       j.Error,
+		CompletedAt: j.CompletedAt,
+		ExpiresAt:   j.ExpiresAt,
+	}, nil
+}
+
+type profile struct {
+	ID                string
+	Username          string
+	DisplayName       string
+	Protected         bool
+	OpenDMs           bool `gorm:"column:open_dms"`
+	SensitiveMedia    string
+	TimelineOwnTweets bool
+	PinnedTweetID     string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type relation struct {
+	UserID    string
+	Username  string
+	CreatedAt time.Time
+}
+
+func toRelations(rows []relation) []export.Relation {
+	relations := make([]export.Relation, 0, len(rows))
+	for _, r := range rows {
+		relations = append(relations, export.Relation{
+			UserID:    r.UserID,
+			Username:  r.Username,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return relations
+}
+
+type draft struct {
+	ID        string
+	Parts     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func toDrafts(rows []draft) ([]export.Draft, error) {
+	drafts := make([]export.Draft, 0, len(rows))
+	for _, d := range rows {
+		var parts []string
+		if err := json.Unmarshal([]byte(d.Parts), &parts); err != nil {
+			return nil, fmt.Errorf("failed to decode parts of draft %s: %w", d.ID, err)
+		}
+		drafts = append(drafts, export.Draft{
+			ID:        d.ID,
+			Parts:     parts,
+			CreatedAt: d.CreatedAt,
+			UpdatedAt: d.UpdatedAt,
+		})
+	}
+
+	return drafts, nil
+}
+
+type conversation struct {
+	ID             string
+	IsGroup        bool
+	ParticipantIDs string
+	CreatedAt      time.Time
+}
+
+func toConversations(rows []conversation) []export.Conversation {
+	conversations := make([]export.Conversation, 0, len(rows))
+	for _, c := range rows {
+		conversations = append(conversations, export.Conversation{
+			ID:             c.ID,
+			IsGroup:        c.IsGroup,
+			ParticipantIDs: strings.Split(c.ParticipantIDs, ","),
+			CreatedAt:      c.CreatedAt,
+		})
+	}
+
+	return conversations
+}
+
+type list struct {
+	ID          string
+	Name        string
+	Description string
+	Private     bool
+	CreatedAt   time.Time
+}
+
+type listMember struct {
+	ListID    string
+	UserID    string
+	Username  string
+	CreatedAt time.Time
+}
+
+func toLists(rows []list, members []listMember) []export.List {
+	byList := make(map[string][]relation)
+	for _, m := range members {
+		byList[m.ListID] = append(byList[m.ListID], relation{UserID: m.UserID, Username: m.Username, CreatedAt: m.CreatedAt})
+	}
+
+	lists := make([]export.List, 0, len(rows))
+	for _, l := range rows {
+		lists = append(lists, export.List{
+			ID:          l.ID,
+			Name:        l.Name,
+			Description: l.Description,
+			Private:     l.Private,
+			Members:     toRelations(byList[l.ID]),
+			CreatedAt:   l.CreatedAt,
+		})
+	}
+
+	return lists
+}
+
+type webhookSubscription struct {
+	ID         string
+	URL        string
+	Events     string
+	Active     bool
+	DisabledAt *time.Time
+	CreatedAt  time.Time
+}
+
+func toWebhookSubscriptions(rows []webhookSubscription) ([]export.WebhookSubscription, error) {
+	subscriptions := make([]export.WebhookSubscription, 0, len(rows))
+	for _, w := range rows {
+		var events []string
+		if err := json.Unmarshal([]byte(w.Events), &events); err != nil {
+			return nil, fmt.Errorf("failed to decode events of webhook subscription %s: %w", w.ID, err)
+		}
+		subscriptions = append(subscriptions, export.WebhookSubscription{
+			ID:         w.ID,
+			URL:        w.URL,
+			Events:     events,
+			Active:     w.Active,
+			DisabledAt: w.DisabledAt,
+			CreatedAt:  w.CreatedAt,
+		})
+	}
+
+	return subscriptions, nil
+}
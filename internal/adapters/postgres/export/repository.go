@@ -0,0 +1,117 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+type exportRepository struct {
+	db db.Connections
+}
+
+func NewExportRepository(db db.Connections) *exportRepository {
+	return &exportRepository{db: db}
+}
+
+func (r *exportRepository) CreateJob(ctx context.Context, job *export.Job) error {
+	jobModel, err := fromDomain(job)
+	if err != nil {
+		return fmt.Errorf("invalid userID: %w", err)
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(jobModel).Error; err != nil {
+		return fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	job.ID = jobModel.ID.String()
+	job.CreatedAt = jobModel.CreatedAt
+
+	return nil
+}
+
+func (r *exportRepository) UpdateJob(ctx context.Context, job *export.Job) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Job{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]any{
+			"status":       string(job.Status),
+			"blob_key":     job.BlobKey,
+			"error":        job.Error,
+			"completed_at": job.CompletedAt,
+			"expires_at":   job.ExpiresAt,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update export job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exportRepository) GetJob(ctx context.Context, userID, jobID string) (*export.Job, error) {
+	return r.findJob(ctx, "id = ? AND user_id = ?", jobID, userID)
+}
+
+func (r *exportRepository) GetJobByToken(ctx context.Context, token string) (*export.Job, error) {
+	return r.findJob(ctx, "token = ?", token)
+}
+
+// HasPendingJob reports whether the user has a pending job created after
+// since.
+func (r *exportRepository) HasPendingJob(ctx context.Context, userID string, since time.Time) (bool, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Job{}).
+		Where("user_id = ? AND status = ? AND created_at > ?", userID, string(export.StatusPending), since).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to find export jobs: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// GetExpiredJobs returns completed jobs whose download link expired before
+// now.
+func (r *exportRepository) GetExpiredJobs(ctx context.Context, now time.Time, limit int) ([]export.Job, error) {
+	var jobs []Job
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("status = ? AND expires_at < ?", string(export.StatusCompleted), now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find expired export jobs: %w", err)
+	}
+
+	jobList := make([]export.Job, 0, len(jobs))
+	for _, j := range jobs {
+		jobList = append(jobList, j.toDomain())
+	}
+
+	return jobList, nil
+}
+
+func (r *exportRepository) findJob(ctx context.Context, query string, args ...any) (*export.Job, error) {
+	var jobModel Job
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Where(query, args...).
+		Take(&jobModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, export.ErrExportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find export job: %w", err)
+	}
+
+	job := jobModel.toDomain()
+	return &job, nil
+}
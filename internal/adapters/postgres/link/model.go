@@ -0,0 +1,35 @@
+package link
+
+import (
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+)
+
+// Preview is the cached preview of a URL. A non-null NextFetchAt means the
+// URL is queued for fetching.
+type Preview struct {
+	URL         string     `gorm:"primaryKey;column:url"`
+	Status      string     `gorm:"column:status;not null"`
+	Title       string     `gorm:"column:title;not null"`
+	Description string     `gorm:"column:description;not null"`
+	ImageURL    string     `gorm:"column:image_url;not null"`
+	FetchedAt   *time.Time `gorm:"column:fetched_at"`
+	NextFetchAt *time.Time `gorm:"column:next_fetch_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Preview) TableName() string {
+	return "link_previews"
+}
+
+func (p *Preview) toDomain() link.Preview {
+	return link.Preview{
+		URL:         p.URL,
+		Status:      link.PreviewStatus(p.Status),
+		Title:       p.Title,
+		Description: p.Description,
+		ImageURL:    p.ImageURL,
+		FetchedAt:   p.FetchedAt,
+	}
+}
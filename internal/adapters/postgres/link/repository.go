@@ -0,0 +1,109 @@
+package link
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm/clause"
+)
+
+// claimQueuedQuery postpones the queued URLs by the lease and returns them.
+// SKIP LOCKED lets several instances claim disjoint batches concurrently.
+const claimQueuedQuery = `
+UPDATE link_previews p
+SET next_fetch_at = ?
+FROM (
+    SELECT url FROM link_previews
+    WHERE next_fetch_at <= ?
+    ORDER BY next_fetch_at
+    LIMIT ?
+    FOR UPDATE SKIP LOCKED
+) queued
+WHERE p.url = queued.url
+RETURNING p.url`
+
+type previewRepository struct {
+	db db.Connections
+}
+
+func NewPreviewRepository(db db.Connections) *previewRepository {
+	return &previewRepository{db: db}
+}
+
+func (r *previewRepository) QueuePreviews(ctx context.Context, urls []string, readyBefore, failedBefore time.Time) error {
+	now := time.Now()
+	models := make([]Preview, len(urls))
+	for i, url := range urls {
+		models[i] = Preview{URL: url, Status: string(link.PreviewPending), NextFetchAt: &now}
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "url"}},
+			DoUpdates: clause.Assignments(map[string]any{"next_fetch_at": now}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "link_previews.next_fetch_at IS NULL"},
+				clause.Or(
+					clause.Expr{SQL: "link_previews.status = ? AND link_previews.fetched_at < ?", Vars: []any{string(link.PreviewReady), readyBefore}},
+					clause.Expr{SQL: "link_previews.status = ? AND link_previews.fetched_at < ?", Vars: []any{string(link.PreviewFailed), failedBefore}},
+				),
+			}},
+		}).
+		Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to queue link previews: %w", err)
+	}
+
+	return nil
+}
+
+func (r *previewRepository) ClaimQueuedPreviews(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	var urls []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(claimQueuedQuery, now.Add(lease), now, limit).
+		Scan(&urls).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim link previews: %w", err)
+	}
+
+	return urls, nil
+}
+
+func (r *previewRepository) SavePreview(ctx context.Context, preview *link.Preview) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Preview{}).
+		Where("url = ?", preview.URL).
+		Updates(map[string]any{
+			"status":        string(preview.Status),
+			"title":         preview.Title,
+			"description":   preview.Description,
+			"image_url":     preview.ImageURL,
+			"fetched_at":    preview.FetchedAt,
+			"next_fetch_at": nil,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to save link preview: %w", err)
+	}
+
+	return nil
+}
+
+func (r *previewRepository) GetPreviews(ctx context.Context, urls []string) ([]link.Preview, error) {
+	var models []Preview
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("url IN ? AND status = ?", urls, string(link.PreviewReady)).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to get link previews: %w", err)
+	}
+
+	previews := make([]link.Preview, len(models))
+	for i := range models {
+		previews[i] = models[i].toDomain()
+	}
+
+	return previews, nil
+}
@@ -0,0 +1,80 @@
+package list
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+)
+
+type List struct {
+	ID          uuid.UUID `gorm:"primaryKey;column:id"`
+	OwnerID     uuid.UUID `gorm:"type:uuid;not null"`
+	Name        string    `gorm:"column:name;not null"`
+	Description string    `gorm:"column:description;not null;default:''"`
+	Private     bool      `gorm:"column:private;not null;default:false"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+
+	// MemberCount and SubscriberCount are only read, see withCounts.
+	MemberCount     int `gorm:"->;column:member_count"`
+	SubscriberCount int `gorm:"->;column:subscriber_count"`
+}
+
+func (List) TableName() string {
+	return "lists"
+}
+
+func (l *List) toDomain() list.List {
+	return list.List{
+		ID:              l.ID.String(),
+		OwnerID:         l.OwnerID.String(),
+		Name:            l.Name,
+		Description:     l.Description,
+		Private:         l.Private,
+		MemberCount:     l.MemberCount,
+		SubscriberCount: l.SubscriberCount,
+		CreatedAt:       l.CreatedAt,
+		UpdatedAt:       l.UpdatedAt,
+	}
+}
+
+type Member struct {
+	ListID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Member) TableName() string {
+	return "list_members"
+}
+
+type Subscription struct {
+	ListID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Subscription) TableName() string {
+	return "list_subscriptions"
+}
+
+// memberUser is a list member as read from the users table.
+type memberUser struct {
+	ID          uuid.UUID
+	Username    string
+	DisplayName string
+	Protected   bool
+	CreatedAt   time.Time
+}
+
+func (m *memberUser) toDomain() user.User {
+	return user.User{
+		ID:          m.ID.String(),
+		Username:    m.Username,
+		DisplayName: m.DisplayName,
+		Protected:   m.Protected,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,301 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+const membersQuery = `
+SELECT u.id, u.username, u.display_name, u.protected, u.created_at
+FROM list_members m
+JOIN users u ON u.id = m.user_id AND u.deleted_at IS NULL
+WHERE m.list_id = ?
+ORDER BY m.created_at DESC, u.id`
+
+type listRepository struct {
+	db db.Connections
+}
+
+func NewListRepository(db db.Connections) *listRepository {
+	return &listRepository{db: db}
+}
+
+func (r *listRepository) CreateList(ctx context.Context, l *list.List) error {
+	ownerID, err := uuid.Parse(l.OwnerID)
+	if err != nil {
+		return fmt.Errorf("invalid ownerID: %w", err)
+	}
+
+	model := &List{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Name:        l.Name,
+		Description: l.Description,
+		Private:     l.Private,
+	}
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create list: %w", err)
+	}
+
+	l.ID = model.ID.String()
+	l.CreatedAt = model.CreatedAt
+	l.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *listRepository) CountLists(ctx context.Context, ownerID string) (int, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&List{}).
+		Where("owner_id = ?", ownerID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count lists: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (r *listRepository) GetList(ctx context.Context, id string) (*list.List, error) {
+	var model List
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Scopes(withCounts).
+		Where("lists.id = ?", id).
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, list.ErrListNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find list: %w", err)
+	}
+
+	l := model.toDomain()
+	return &l, nil
+}
+
+func (r *listRepository) GetLists(ctx context.Context, ownerID string, includePrivate bool) ([]list.List, error) {
+	query := r.db.MasterConn.
+		WithContext(ctx).
+		Scopes(withCounts).
+		Where("lists.owner_id = ?", ownerID)
+	if !includePrivate {
+		query = query.Where("NOT lists.private")
+	}
+
+	var models []List
+	if err := query.
+		Order("lists.created_at DESC, lists.id DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find lists: %w", err)
+	}
+
+	return toDomainLists(models), nil
+}
+
+func (r *listRepository) GetSubscribedLists(ctx context.Context, userID string) ([]list.List, error) {
+	var models []List
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Scopes(withCounts).
+		Joins("JOIN list_subscriptions sub ON sub.list_id = lists.id AND sub.user_id = ?", userID).
+		Where("NOT lists.private").
+		Order("sub.created_at DESC, lists.id DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find subscribed lists: %w", err)
+	}
+
+	return toDomainLists(models), nil
+}
+
+func (r *listRepository) UpdateList(ctx context.Context, id string, update list.ListUpdate) error {
+	updates := map[string]any{}
+	if update.Name != nil {
+		updates["name"] = *update.Name
+	}
+	if update.Description != nil {
+		updates["description"] = *update.Description
+	}
+	if update.Private != nil {
+		updates["private"] = *update.Private
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&List{}).
+		Where("id = ?", id).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update list: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteList also removes the members and subscriptions of the list, by
+// cascade.
+func (r *listRepository) DeleteList(ctx context.Context, id string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&List{}).Error; err != nil {
+		return fmt.Errorf("failed to delete list: %w", err)
+	}
+
+	return nil
+}
+
+func (r *listRepository) AddMember(ctx context.Context, listID, userID string) error {
+	model, err := newMember(listID, userID)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.MasterConn.
+		WithContext(ctx).
+		Create(model).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return list.ErrAlreadyMember
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create list member: %w", err)
+	}
+
+	return nil
+}
+
+func (r *listRepository) RemoveMember(ctx context.Context, listID, userID string) error {
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Where("list_id = ? AND user_id = ?", listID, userID).
+		Delete(&Member{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete list member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return list.ErrNotMember
+	}
+
+	return nil
+}
+
+// GetMembers skips deactivated users.
+func (r *listRepository) GetMembers(ctx context.Context, listID string) ([]user.User, error) {
+	var models []memberUser
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(membersQuery, listID).
+		Scan(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find list members: %w", err)
+	}
+
+	members := make([]user.User, len(models))
+	for i := range models {
+		members[i] = models[i].toDomain()
+	}
+
+	return members, nil
+}
+
+func (r *listRepository) GetMemberIDs(ctx context.Context, listID string) ([]string, error) {
+	var ids []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Member{}).
+		Where("list_id = ?", listID).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find list members: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *listRepository) GetListIDsByMember(ctx context.Context, userID string) ([]string, error) {
+	var ids []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Member{}).
+		Where("user_id = ?", userID).
+		Pluck("list_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find lists of member: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *listRepository) Subscribe(ctx context.Context, listID, userID string) error {
+	member, err := newMember(listID, userID)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.MasterConn.
+		WithContext(ctx).
+		Create(&Subscription{ListID: member.ListID, UserID: member.UserID}).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return list.ErrAlreadySubscribed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create list subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *listRepository) Unsubscribe(ctx context.Context, listID, userID string) error {
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Where("list_id = ? AND user_id = ?", listID, userID).
+		Delete(&Subscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete list subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return list.ErrNotSubscribed
+	}
+
+	return nil
+}
+
+// withCounts reads the number of members and subscribers of each list.
+func withCounts(db *gorm.DB) *gorm.DB {
+	return db.Select(`lists.*,
+		(SELECT COUNT(*) FROM list_members m WHERE m.list_id = lists.id) AS member_count,
+		(SELECT COUNT(*) FROM list_subscriptions s WHERE s.list_id = lists.id) AS subscriber_count`)
+}
+
+func newMember(listID, userID string) (*Member, error) {
+	listUUID, err := uuid.Parse(listID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listID: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userID: %w", err)
+	}
+
+	return &Member{ListID: listUUID, UserID: userUUID}, nil
+}
+
+func toDomainLists(models []List) []list.List {
+	lists := make([]list.List, len(models))
+	for i := range models {
+		lists[i] = models[i].toDomain()
+	}
+
+	return lists
+}
@@ -0,0 +1,67 @@
+package media
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+)
+
+type Media struct {
+	ID           uuid.UUID `gorm:"primaryKey;column:id"`
+	UserID       uuid.UUID `gorm:"type:uuid;column:user_id;not null"`
+	ContentType  string    `gorm:"column:content_type;not null"`
+	Size         int64     `gorm:"column:size;not null"`
+	Width        int       `gorm:"column:width;not null"`
+	Height       int       `gorm:"column:height;not null"`
+	BlobKey      string    `gorm:"column:blob_key;not null"`
+	ThumbnailKey string    `gorm:"column:thumbnail_key;not null"`
+	AltText      string    `gorm:"column:alt_text;not null"`
+	Sensitive    bool      `gorm:"column:sensitive;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Media) TableName() string {
+	return "media"
+}
+
+func (m *Media) toDomain() media.Media {
+	return media.Media{
+		ID:           m.ID.String(),
+		UserID:       m.UserID.String(),
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		BlobKey:      m.BlobKey,
+		ThumbnailKey: m.ThumbnailKey,
+		AltText:      m.AltText,
+		Sensitive:    m.Sensitive,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func fromDomain(m *media.Media) (*Media, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Media{
+		ID:           id,
+		UserID:       userID,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		BlobKey:      m.BlobKey,
+		ThumbnailKey: m.ThumbnailKey,
+		AltText:      m.AltText,
+		Sensitive:    m.Sensitive,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+type mediaRepository struct {
+	db db.Connections
+}
+
+func NewMediaRepository(db db.Connections) *mediaRepository {
+	return &mediaRepository{db: db}
+}
+
+func (r *mediaRepository) CreateMedia(ctx context.Context, m *media.Media) error {
+	model, err := fromDomain(m)
+	if err != nil {
+		return fmt.Errorf("invalid media: %w", err)
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create media: %w", err)
+	}
+
+	m.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *mediaRepository) GetMedia(ctx context.Context, id string) (*media.Media, error) {
+	var model Media
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, media.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find media: %w", err)
+	}
+
+	m := model.toDomain()
+	return &m, nil
+}
+
+func (r *mediaRepository) UpdateMetadata(ctx context.Context, userID, id string, metadata media.Metadata) error {
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Media{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]any{
+			"alt_text":  metadata.AltText,
+			"sensitive": metadata.Sensitive,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update media: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return media.ErrMediaNotFound
+	}
+
+	return nil
+}
+
+func (r *mediaRepository) GetMediaByIDs(ctx context.Context, ids []string) ([]media.Media, error) {
+	var models []Media
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id IN ?", ids).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find media: %w", err)
+	}
+
+	found := make([]media.Media, len(models))
+	for i := range models {
+		found[i] = models[i].toDomain()
+	}
+
+	return found, nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+)
+
+type Notification struct {
+	ID          uuid.UUID  `gorm:"primaryKey;column:id"`
+	RecipientID uuid.UUID  `gorm:"type:uuid;not null"`
+	ActorID     uuid.UUID  `gorm:"type:uuid;not null"`
+	Type        string     `gorm:"column:type;not null"`
+	TweetID     *uuid.UUID `gorm:"type:uuid;column:tweet_id"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	ReadAt      *time.Time `gorm:"column:read_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+func fromDomain(n notification.Notification) (*Notification, error) {
+	recipientID, err := uuid.Parse(n.RecipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := uuid.Parse(n.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tweetID *uuid.UUID
+	if n.TweetID != "" {
+		id, err := uuid.Parse(n.TweetID)
+		if err != nil {
+			return nil, err
+		}
+		tweetID = &id
+	}
+
+	return &Notification{
+		ID:          uuid.New(),
+		RecipientID: recipientID,
+		ActorID:     actorID,
+		Type:        string(n.Type),
+		TweetID:     tweetID,
+	}, nil
+}
+
+// row is a notification joined with its actor's username.
+type row struct {
+	ID            string
+	RecipientID   string
+	ActorID       string
+	ActorUsername string
+	Type          string
+	TweetID       *string
+	CreatedAt     time.Time
+	ReadAt        *time.Time
+}
+
+func (r *row) toDomain() notification.Notification {
+	n := notification.Notification{
+		ID:            r.ID,
+		RecipientID:   r.RecipientID,
+		ActorID:       r.ActorID,
+		ActorUsername: r.ActorUsername,
+		Type:          notification.Type(r.Type),
+		CreatedAt:     r.CreatedAt,
+		ReadAt:        r.ReadAt,
+	}
+	if r.TweetID != nil {
+		n.TweetID = *r.TweetID
+	}
+
+	return n
+}
@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+)
+
+// visibleNotifications hides notifications whose actor is deactivated or
+// whose tweet was deleted.
+const visibleNotifications = `
+FROM notifications n
+JOIN users u ON u.id = n.actor_id AND u.deleted_at IS NULL
+LEFT JOIN tweets t ON t.id = n.tweet_id
+WHERE n.recipient_id = ? AND (n.tweet_id IS NULL OR t.deleted_at IS NULL)`
+
+type notificationRepository struct {
+	db db.Connections
+}
+
+func NewNotificationRepository(db db.Connections) *notificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateNotifications(ctx context.Context, notifications []notification.Notification) error {
+	models := make([]*Notification, 0, len(notifications))
+	for _, n := range notifications {
+		model, err := fromDomain(n)
+		if err != nil {
+			return fmt.Errorf("invalid notification: %w", err)
+		}
+		models = append(models, model)
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+
+	for i, model := range models {
+		notifications[i].ID = model.ID.String()
+		notifications[i].CreatedAt = model.CreatedAt
+	}
+
+	return nil
+}
+
+// GetNotifications returns up to limit notifications of the recipient,
+// newest first, strictly after the given cursor.
+func (r *notificationRepository) GetNotifications(ctx context.Context, recipientID string, after *notification.Cursor, limit int) ([]notification.Notification, error) {
+	query := `
+SELECT n.id, n.recipient_id, n.actor_id, u.username AS actor_username, n.type, n.tweet_id, n.created_at, n.read_at` +
+		visibleNotifications
+	args := []any{recipientID}
+	if after != nil {
+		query += " AND (n.created_at, n.id) < (?, ?)"
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += " ORDER BY n.created_at DESC, n.id DESC LIMIT ?"
+	args = append(args, limit)
+
+	var rows []row
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(query, args...).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find notifications: %w", err)
+	}
+
+	notifications := make([]notification.Notification, 0, len(rows))
+	for _, row := range rows {
+		notifications = append(notifications, row.toDomain())
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, recipientID string) (int, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw("SELECT count(*)"+visibleNotifications+" AND n.read_at IS NULL", recipientID).
+		Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// MarkAsRead marks the given unread notifications of the recipient as read,
+// or all of them when ids is empty.
+func (r *notificationRepository) MarkAsRead(ctx context.Context, recipientID string, ids []string) error {
+	tx := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Notification{}).
+		Where("recipient_id = ? AND read_at IS NULL", recipientID)
+	if len(ids) > 0 {
+		tx = tx.Where("id IN ?", ids)
+	}
+
+	if err := tx.Update("read_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package poll
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+)
+
+type Poll struct {
+	TweetID           uuid.UUID `gorm:"type:uuid;primaryKey;column:tweet_id"`
+	UserID            uuid.UUID `gorm:"type:uuid;column:user_id;not null"`
+	Options           string    `gorm:"column:options;type:jsonb;not null"`
+	ResultsVisibility string    `gorm:"column:results_visibility;not null"`
+	EndsAt            time.Time `gorm:"column:ends_at;not null"`
+	CreatedAt         time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Poll) TableName() string {
+	return "polls"
+}
+
+func (p *Poll) toDomain() (poll.Poll, error) {
+	var texts []string
+	if err := json.Unmarshal([]byte(p.Options), &texts); err != nil {
+		return poll.Poll{}, err
+	}
+
+	options := make([]poll.Option, len(texts))
+	for i, text := range texts {
+		options[i] = poll.Option{Position: i, Text: text}
+	}
+
+	return poll.Poll{
+		TweetID:           p.TweetID.String(),
+		AuthorID:          p.UserID.String(),
+		Options:           options,
+		ResultsVisibility: poll.ResultsVisibility(p.ResultsVisibility),
+		EndsAt:            p.EndsAt,
+		CreatedAt:         p.CreatedAt,
+	}, nil
+}
+
+func fromDomain(p *poll.Poll) (*Poll, error) {
+	tweetID, err := uuid.Parse(p.TweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(p.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, len(p.Options))
+	for i, o := range p.Options {
+		texts[i] = o.Text
+	}
+
+	options, err := json.Marshal(texts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Poll{
+		TweetID:           tweetID,
+		UserID:            userID,
+		Options:           string(options),
+		ResultsVisibility: string(p.ResultsVisibility),
+		EndsAt:            p.EndsAt,
+	}, nil
+}
+
+type Vote struct {
+	TweetID   uuid.UUID `gorm:"type:uuid;primaryKey;column:tweet_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;column:user_id"`
+	Position  int       `gorm:"column:position;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Vote) TableName() string {
+	return "poll_votes"
+}
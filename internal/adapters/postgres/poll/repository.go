@@ -0,0 +1,127 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+type pollRepository struct {
+	db db.Connections
+}
+
+func NewPollRepository(db db.Connections) *pollRepository {
+	return &pollRepository{db: db}
+}
+
+// CreatePoll stores the poll of a tweet within tx, so that the tweet
+// repository can create both atomically.
+func CreatePoll(tx *gorm.DB, p *poll.Poll) error {
+	model, err := fromDomain(p)
+	if err != nil {
+		return fmt.Errorf("invalid poll: %w", err)
+	}
+
+	if err := tx.Create(model).Error; err != nil {
+		return err
+	}
+
+	p.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *pollRepository) GetPolls(ctx context.Context, tweetIDs []string) ([]poll.Poll, error) {
+	var models []Poll
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("tweet_id IN ?", tweetIDs).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find polls: %w", err)
+	}
+
+	polls := make([]poll.Poll, 0, len(models))
+	for i := range models {
+		p, err := models[i].toDomain()
+		if err != nil {
+			return nil, fmt.Errorf("invalid poll %s: %w", models[i].TweetID, err)
+		}
+		polls = append(polls, p)
+	}
+
+	return polls, nil
+}
+
+func (r *pollRepository) CreateVote(ctx context.Context, tweetID, userID string, option int) error {
+	tweetUUID, err := uuid.Parse(tweetID)
+	if err != nil {
+		return fmt.Errorf("invalid tweet ID: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	err = r.db.MasterConn.
+		WithContext(ctx).
+		Create(&Vote{TweetID: tweetUUID, UserID: userUUID, Position: option}).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return poll.ErrAlreadyVoted
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create vote: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pollRepository) GetVotedOptions(ctx context.Context, userID string, tweetIDs []string) (map[string]int, error) {
+	var votes []Vote
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("user_id = ? AND tweet_id IN ?", userID, tweetIDs).
+		Find(&votes).Error; err != nil {
+		return nil, fmt.Errorf("failed to find votes: %w", err)
+	}
+
+	voted := make(map[string]int, len(votes))
+	for _, v := range votes {
+		voted[v.TweetID.String()] = v.Position
+	}
+
+	return voted, nil
+}
+
+func (r *pollRepository) CountVotes(ctx context.Context, tweetIDs []string) (map[string]poll.Tallies, error) {
+	var rows []struct {
+		TweetID  uuid.UUID
+		Position int
+		Votes    int
+	}
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Vote{}).
+		Select("tweet_id, position, COUNT(*) AS votes").
+		Where("tweet_id IN ?", tweetIDs).
+		Group("tweet_id, position").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count votes: %w", err)
+	}
+
+	tallies := make(map[string]poll.Tallies)
+	for _, row := range rows {
+		id := row.TweetID.String()
+		if tallies[id] == nil {
+			tallies[id] = poll.Tallies{}
+		}
+		tallies[id][row.Position] = row.Votes
+	}
+
+	return tallies, nil
+}
@@ -0,0 +1,33 @@
+package recommendation
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation"
+)
+
+type Candidate struct {
+	UserID          string     `gorm:"column:user_id"`
+	Username        string     `gorm:"column:username"`
+	MutualCount     int        `gorm:"column:mutual_count"`
+	MutualUsernames string     `gorm:"column:mutual_usernames"`
+	FollowerCount   int        `gorm:"column:follower_count"`
+	LastTweetAt     *time.Time `gorm:"column:last_tweet_at"`
+}
+
+func (c *Candidate) toDomain() recommendation.Candidate {
+	var mutualUsernames []string
+	if c.MutualUsernames != "" {
+		_ = json.Unmarshal([]byte(c.MutualUsernames), &mutualUsernames)
+	}
+
+	return recommendation.Candidate{
+		UserID:          c.UserID,
+		Username:        c.Username,
+		MutualCount:     c.MutualCount,
+		MutualUsernames: mutualUsernames,
+		FollowerCount:   c.FollowerCount,
+		LastTweetAt:     c.LastTweetAt,
+	}
+}
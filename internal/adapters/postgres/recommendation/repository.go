@@ -0,0 +1,134 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+)
+
+// excludeCandidates filters out the user itself, users already followed or
+// requested, and users with a block in either direction.
+const excludeCandidates = `
+	u.id <> @user_id
+	AND u.deleted_at IS NULL
+	AND NOT EXISTS (SELECT 1 FROM follows x WHERE x.follower_id = @user_id AND x.followee_id = u.id)
+	AND NOT EXISTS (SELECT 1 FROM follow_requests r WHERE r.requester_id = @user_id AND r.target_id = u.id)
+	AND NOT EXISTS (
+		SELECT 1 FROM blocks b
+		WHERE (b.blocker_id = @user_id AND b.blocked_id = u.id)
+		   OR (b.blocker_id = u.id AND b.blocked_id = @user_id)
+	)`
+
+const friendsOfFriendsQuery = `
+SELECT
+	u.id AS user_id,
+	u.username,
+	c.mutual_count,
+	to_json(c.mutual_usernames)::text AS mutual_usernames,
+	(SELECT COUNT(*) FROM follows fc WHERE fc.followee_id = u.id) AS follower_count,
+	(SELECT MAX(t.created_at) FROM tweets t WHERE t.user_id = u.id AND t.deleted_at IS NULL) AS last_tweet_at
+FROM (
+	SELECT
+		f2.followee_id AS candidate_id,
+		COUNT(*) AS mutual_count,
+		(ARRAY_AGG(mu.username ORDER BY f2.created_at DESC))[1:3] AS mutual_usernames
+	FROM follows f1
+	JOIN follows f2 ON f2.follower_id = f1.followee_id
+	JOIN users mu ON mu.id = f1.followee_id AND mu.deleted_at IS NULL
+	WHERE f1.follower_id = @user_id
+	GROUP BY f2.followee_id
+) c
+JOIN users u ON u.id = c.candidate_id
+WHERE ` + excludeCandidates + `
+ORDER BY c.mutual_count DESC
+LIMIT @limit`
+
+const popularUsersQuery = `
+SELECT
+	u.id AS user_id,
+	u.username,
+	0 AS mutual_count,
+	'[]' AS mutual_usernames,
+	p.follower_count,
+	(SELECT MAX(t.created_at) FROM tweets t WHERE t.user_id = u.id AND t.deleted_at IS NULL) AS last_tweet_at
+FROM (
+	SELECT followee_id, COUNT(*) AS follower_count
+	FROM follows
+	GROUP BY followee_id
+	ORDER BY follower_count DESC
+	LIMIT @pool
+) p
+JOIN users u ON u.id = p.followee_id
+WHERE ` + excludeCandidates + `
+ORDER BY p.follower_count DESC
+LIMIT @limit`
+
+const candidatesAmongQuery = `
+SELECT u.id FROM users u
+WHERE u.id IN @ids AND ` + excludeCandidates
+
+type recommendationRepository struct {
+	db db.Connections
+}
+
+func NewRecommendationRepository(db db.Connections) *recommendationRepository {
+	return &recommendationRepository{db: db}
+}
+
+func (r *recommendationRepository) GetFriendsOfFriends(ctx context.Context, userID string, limit int) ([]recommendation.Candidate, error) {
+	var candidates []Candidate
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(friendsOfFriendsQuery, map[string]any{
+			"user_id": userID,
+			"limit":   limit,
+		}).
+		Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get friends of friends: %w", err)
+	}
+
+	return toDomain(candidates), nil
+}
+
+func (r *recommendationRepository) GetPopularUsers(ctx context.Context, userID string, limit int) ([]recommendation.Candidate, error) {
+	var candidates []Candidate
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(popularUsersQuery, map[string]any{
+			"user_id": userID,
+			"limit":   limit,
+			// Over-fetch so that excluded users do not empty the result.
+			"pool": limit * 4,
+		}).
+		Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get popular users: %w", err)
+	}
+
+	return toDomain(candidates), nil
+}
+
+func (r *recommendationRepository) GetCandidatesAmong(ctx context.Context, userID string, ids []string) ([]string, error) {
+	var candidates []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(candidatesAmongQuery, map[string]any{
+			"user_id": userID,
+			"ids":     ids,
+		}).
+		Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+func toDomain(candidates []Candidate) []recommendation.Candidate {
+	candidateList := make([]recommendation.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		candidateList = append(candidateList, c.toDomain())
+	}
+
+	return candidateList
+}
@@ -0,0 +1,213 @@
+package tweet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+const lockUserQuery = `SELECT 1 FROM users WHERE id = ? FOR UPDATE`
+
+type draftRepository struct {
+	db db.Connections
+}
+
+func NewDraftRepository(db db.Connections) *draftRepository {
+	return &draftRepository{db: db}
+}
+
+// CreateDraft locks the row of the user so that the count and the insert
+// of concurrent creations do not interleave.
+func (r *draftRepository) CreateDraft(ctx context.Context, draft *tweet.Draft, limit int) error {
+	model, err := draftFromDomain(draft)
+	if err != nil {
+		return fmt.Errorf("invalid draft: %w", err)
+	}
+
+	err = r.db.MasterConn.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(lockUserQuery, model.UserID).Error; err != nil {
+				return fmt.Errorf("error locking user: %w", err)
+			}
+
+			var count int64
+			if err := tx.
+				Model(&Draft{}).
+				Where("user_id = ?", model.UserID).
+				Count(&count).Error; err != nil {
+				return fmt.Errorf("error counting drafts: %w", err)
+			}
+			if count >= int64(limit) {
+				return tweet.ErrTooManyDrafts
+			}
+
+			return tx.Create(model).Error
+		})
+	if errors.Is(err, tweet.ErrTooManyDrafts) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create draft: %w", err)
+	}
+
+	draft.ID = model.ID.String()
+	draft.CreatedAt = model.CreatedAt
+	draft.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *draftRepository) GetDrafts(ctx context.Context, userID string) ([]tweet.Draft, error) {
+	var models []Draft
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("updated_at DESC, id").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find drafts: %w", err)
+	}
+
+	drafts := make([]tweet.Draft, 0, len(models))
+	for i := range models {
+		draft, err := models[i].toDomain()
+		if err != nil {
+			return nil, fmt.Errorf("invalid draft %s: %w", models[i].ID, err)
+		}
+		drafts = append(drafts, draft)
+	}
+
+	return drafts, nil
+}
+
+func (r *draftRepository) GetDraft(ctx context.Context, userID, id string) (*tweet.Draft, error) {
+	var model Draft
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, tweet.ErrDraftNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find draft: %w", err)
+	}
+
+	draft, err := model.toDomain()
+	if err != nil {
+		return nil, fmt.Errorf("invalid draft %s: %w", model.ID, err)
+	}
+
+	return &draft, nil
+}
+
+func (r *draftRepository) UpdateDraft(ctx context.Context, draft *tweet.Draft) error {
+	parts, err := json.Marshal(draft.Parts)
+	if err != nil {
+		return fmt.Errorf("invalid draft: %w", err)
+	}
+
+	now := time.Now()
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Draft{}).
+		Where("id = ? AND user_id = ?", draft.ID, draft.UserID).
+		Updates(map[string]any{
+			"parts":      string(parts),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update draft: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return tweet.ErrDraftNotFound
+	}
+
+	draft.UpdatedAt = now
+
+	return nil
+}
+
+func (r *draftRepository) DeleteDraft(ctx context.Context, userID, id string) error {
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&Draft{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete draft: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return tweet.ErrDraftNotFound
+	}
+
+	return nil
+}
+
+// PublishDraft deletes the draft first so that two concurrent publications
+// of the same draft cannot both create the tweets.
+func (r *draftRepository) PublishDraft(ctx context.Context, draft *tweet.Draft, tweets []*tweet.Tweet) error {
+	models := make([]*Tweet, len(tweets))
+	createdAt := time.Now()
+	for i, t := range tweets {
+		model, err := fromDomain(t)
+		if err != nil {
+			return fmt.Errorf("invalid tweet: %w", err)
+		}
+		// Spread the creation times so that the parts of a thread keep
+		// their order in the timeline.
+		model.CreatedAt = createdAt.Add(time.Duration(i) * time.Microsecond)
+		models[i] = model
+	}
+
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			result := tx.
+				Where("id = ? AND user_id = ?", draft.ID, draft.UserID).
+				Delete(&Draft{})
+			if result.Error != nil {
+				return fmt.Errorf("error deleting draft: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return tweet.ErrDraftNotFound
+			}
+
+			if err := tx.Create(&models).Error; err != nil {
+				return fmt.Errorf("error creating tweets: %w", err)
+			}
+
+			return nil
+		})
+	if errors.Is(err, tweet.ErrDraftNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to publish draft: %w", err)
+	}
+
+	for i, model := range models {
+		tweets[i].ID = model.ID.String()
+		tweets[i].CreatedAt = model.CreatedAt
+		tweets[i].UpdatedAt = model.UpdatedAt
+	}
+
+	return nil
+}
+
+func (r *draftRepository) PurgeDraftsUpdatedBefore(ctx context.Context, before time.Time) (int, error) {
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Where("updated_at < ?", before).
+		Delete(&Draft{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge drafts: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
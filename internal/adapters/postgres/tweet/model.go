@@ -1,9 +1,12 @@
 package tweet
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	mediarepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/media"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
 	"gorm.io/gorm"
 )
@@ -11,26 +14,196 @@ import (
 type Tweet struct {
 	ID        uuid.UUID      `gorm:"primaryKey;column:id"`
 	UserID    string         `gorm:"column:user_id;not null"`
-	Content   string         `gorm:"column:content;not null;type:text;size:280"`
+	Content   string         `gorm:"column:content;not null;type:text"`
+	Sensitive bool           `gorm:"column:sensitive;not null"`
+	Language  string         `gorm:"column:language;not null"`
 	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `gorm:"index;column:deleted_at"`
+	Media     []TweetMedia   `gorm:"foreignKey:TweetID"`
+	Links     []TweetLink    `gorm:"foreignKey:TweetID"`
 }
 
 func (t *Tweet) toDomain() tweet.Tweet {
+	var attachments []tweet.Attachment
+	for _, m := range t.Media {
+		attachment := tweet.Attachment{MediaID: m.MediaID.String()}
+		if m.Media != nil {
+			attachment.AltText = m.Media.AltText
+			attachment.Sensitive = m.Media.Sensitive
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	var links []tweet.Link
+	for _, l := range t.Links {
+		links = append(links, tweet.Link{Entity: link.Entity{URL: l.URL, Start: l.Start, End: l.End}})
+	}
+
 	return tweet.Tweet{
 		ID:        t.ID.String(),
 		UserID:    t.UserID,
 		Content:   t.Content,
+		Media:     attachments,
+		Links:     links,
+		Sensitive: t.Sensitive,
+		Language:  t.Language,
 		CreatedAt: t.CreatedAt,
 		UpdatedAt: t.UpdatedAt,
 	}
 }
 
-func fromDomain(t *tweet.Tweet) *Tweet {
+func fromDomain(t *tweet.Tweet) (*Tweet, error) {
+	id := uuid.New()
+	if t.ID != "" {
+		parsed, err := uuid.Parse(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		id = parsed
+	}
+
+	media := make([]TweetMedia, len(t.Media))
+	for i, a := range t.Media {
+		parsed, err := uuid.Parse(a.MediaID)
+		if err != nil {
+			return nil, err
+		}
+		media[i] = TweetMedia{TweetID: id, MediaID: parsed, Position: i}
+	}
+
+	links := make([]TweetLink, len(t.Links))
+	for i, l := range t.Links {
+		links[i] = TweetLink{TweetID: id, Position: i, URL: l.URL, Start: l.Start, End: l.End}
+	}
+
 	return &Tweet{
-		ID:      uuid.New(),
-		UserID:  t.UserID,
-		Content: t.Content,
+		ID:        id,
+		UserID:    t.UserID,
+		Content:   t.Content,
+		Sensitive: t.Sensitive,
+		Language:  t.Language,
+		Media:     media,
+		Links:     links,
+	}, nil
+}
+
+// TweetMedia keeps the media attached to a tweet, in the order they were
+// given. Media is only loaded on reads, for the alt text and sensitive flag.
+type TweetMedia struct {
+	TweetID  uuid.UUID        `gorm:"type:uuid;primaryKey;column:tweet_id"`
+	MediaID  uuid.UUID        `gorm:"type:uuid;primaryKey;column:media_id"`
+	Position int              `gorm:"column:position;not null"`
+	Media    *mediarepo.Media `gorm:"foreignKey:MediaID"`
+}
+
+func (TweetMedia) TableName() string {
+	return "tweet_media"
+}
+
+// preloadMedia loads the media of the tweets being queried.
+func preloadMedia(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Media", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Media.Media")
+}
+
+// TweetLink is a link found in the content of a tweet. Start and End are
+// positions in characters.
+type TweetLink struct {
+	TweetID  uuid.UUID `gorm:"type:uuid;primaryKey;column:tweet_id"`
+	Position int       `gorm:"primaryKey;column:position"`
+	URL      string    `gorm:"column:url;not null"`
+	Start    int       `gorm:"column:start_offset;not null"`
+	End      int       `gorm:"column:end_offset;not null"`
+}
+
+func (TweetLink) TableName() string {
+	return "tweet_links"
+}
+
+// preloadLinks loads the links of the tweets being queried.
+func preloadLinks(db *gorm.DB) *gorm.DB {
+	return db.Preload("Links", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
+}
+
+type ScheduledTweet struct {
+	ID          uuid.UUID  `gorm:"primaryKey;column:id"`
+	UserID      string     `gorm:"column:user_id;not null"`
+	Content     string     `gorm:"column:content;not null"`
+	PublishAt   time.Time  `gorm:"column:publish_at;not null"`
+	LockedUntil *time.Time `gorm:"column:locked_until"`
+	Attempts    int        `gorm:"column:attempts;not null"`
+	FailedAt    *time.Time `gorm:"column:failed_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (s *ScheduledTweet) toDomain() tweet.ScheduledTweet {
+	return tweet.ScheduledTweet{
+		ID:        s.ID.String(),
+		UserID:    s.UserID,
+		Content:   s.Content,
+		PublishAt: s.PublishAt,
+		CreatedAt: s.CreatedAt,
+		Attempts:  s.Attempts,
+		FailedAt:  s.FailedAt,
+	}
+}
+
+func scheduledFromDomain(s *tweet.ScheduledTweet) *ScheduledTweet {
+	return &ScheduledTweet{
+		ID:        uuid.New(),
+		UserID:    s.UserID,
+		Content:   s.Content,
+		PublishAt: s.PublishAt,
+	}
+}
+
+func toScheduledTweets(models []ScheduledTweet) []tweet.ScheduledTweet {
+	scheduled := make([]tweet.ScheduledTweet, len(models))
+	for i := range models {
+		scheduled[i] = models[i].toDomain()
 	}
+
+	return scheduled
+}
+
+type Draft struct {
+	ID        uuid.UUID `gorm:"primaryKey;column:id"`
+	UserID    string    `gorm:"column:user_id;not null"`
+	Parts     string    `gorm:"column:parts;type:jsonb;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (d *Draft) toDomain() (tweet.Draft, error) {
+	var parts []string
+	if err := json.Unmarshal([]byte(d.Parts), &parts); err != nil {
+		return tweet.Draft{}, err
+	}
+
+	return tweet.Draft{
+		ID:        d.ID.String(),
+		UserID:    d.UserID,
+		Parts:     parts,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}, nil
+}
+
+func draftFromDomain(d *tweet.Draft) (*Draft, error) {
+	parts, err := json.Marshal(d.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Draft{
+		ID:     uuid.New(),
+		UserID: d.UserID,
+		Parts:  string(parts),
+	}, nil
 }
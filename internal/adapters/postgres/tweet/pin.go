@@ -0,0 +1,63 @@
+package tweet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+)
+
+// pinRepository keeps the pinned tweet in users.pinned_tweet_id, whose
+// foreign key unpins the tweet when it is deleted.
+type pinRepository struct {
+	db db.Connections
+}
+
+func NewPinRepository(db db.Connections) *pinRepository {
+	return &pinRepository{db: db}
+}
+
+// GetPinnedTweet ignores pinned tweets that were soft deleted.
+func (r *pinRepository) GetPinnedTweet(ctx context.Context, userID string) (*tweet.Tweet, error) {
+	var models []Tweet
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Scopes(preloadMedia, preloadLinks).
+		Joins("JOIN users ON users.pinned_tweet_id = tweets.id").
+		Where("users.id = ?", userID).
+		Limit(1).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find pinned tweet: %w", err)
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	t := models[0].toDomain()
+	return &t, nil
+}
+
+func (r *pinRepository) SetPinnedTweet(ctx context.Context, userID, tweetID string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Table("users").
+		Where("id = ?", userID).
+		Update("pinned_tweet_id", tweetID).Error; err != nil {
+		return fmt.Errorf("failed to set pinned tweet: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pinRepository) UnsetPinnedTweet(ctx context.Context, userID, tweetID string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Table("users").
+		Where("id = ? AND pinned_tweet_id = ?", userID, tweetID).
+		Update("pinned_tweet_id", nil).Error; err != nil {
+		return fmt.Errorf("failed to unset pinned tweet: %w", err)
+	}
+
+	return nil
+}
@@ -2,10 +2,13 @@ package tweet
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	pollrepo "github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/postgres/poll"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
 	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
 )
 
 type tweetRepository struct {
@@ -16,29 +19,56 @@ func NewTweetRepository(db db.Connections) *tweetRepository {
 	return &tweetRepository{db: db}
 }
 
-func (r *tweetRepository) CreateTweet(ctx context.Context, tweet *tweet.Tweet) error {
-	tweetModel := fromDomain(tweet)
+// CreateTweet keeps tweet.ID if it is set, failing with ErrTweetExists if a
+// tweet with that ID was already created. The media references, the links
+// and the poll of the tweet, if any, are created in the same transaction.
+func (r *tweetRepository) CreateTweet(ctx context.Context, t *tweet.Tweet) error {
+	tweetModel, err := fromDomain(t)
+	if err != nil {
+		return fmt.Errorf("invalid tweet: %w", err)
+	}
 
-	err := r.db.MasterConn.
+	err = r.db.MasterConn.
 		WithContext(ctx).
-		Create(tweetModel).Error
+		Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(tweetModel).Error; err != nil {
+				return err
+			}
+
+			if t.Poll == nil {
+				return nil
+			}
+
+			t.Poll.TweetID = tweetModel.ID.String()
+			if err := pollrepo.CreatePoll(tx, t.Poll); err != nil {
+				return fmt.Errorf("error creating poll: %w", err)
+			}
+
+			return nil
+		})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return tweet.ErrTweetExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create tweet: %w", err)
 	}
 
-	tweet.ID = tweetModel.ID.String()
-	tweet.CreatedAt = tweetModel.CreatedAt
-	tweet.UpdatedAt = tweetModel.UpdatedAt
+	t.ID = tweetModel.ID.String()
+	t.CreatedAt = tweetModel.CreatedAt
+	t.UpdatedAt = tweetModel.UpdatedAt
 
 	return nil
 }
 
+// GetTweetsByUserIDs skips tweets of deactivated users.
 func (r *tweetRepository) GetTweetsByUserIDs(ctx context.Context, userIDs []string, limit, offset int) ([]tweet.Tweet, error) {
 	var tweets []Tweet
 	if err := r.db.MasterConn.
 		WithContext(ctx).
-		Where("user_id IN ?", userIDs).
-		Order("created_at DESC").
+		Scopes(preloadMedia, preloadLinks).
+		Joins("JOIN users ON users.id = tweets.user_id AND users.deleted_at IS NULL").
+		Where("tweets.user_id IN ?", userIDs).
+		Order("tweets.created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Find(&tweets).Error; err != nil {
@@ -52,3 +82,21 @@ func (r *tweetRepository) GetTweetsByUserIDs(ctx context.Context, userIDs []stri
 
 	return tweetList, nil
 }
+
+func (r *tweetRepository) GetTweetByID(ctx context.Context, id string) (*tweet.Tweet, error) {
+	var model Tweet
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Scopes(preloadMedia, preloadLinks).
+		Where("id = ?", id).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, tweet.ErrTweetNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tweet: %w", err)
+	}
+
+	t := model.toDomain()
+	return &t, nil
+}
@@ -0,0 +1,124 @@
+package tweet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+)
+
+// claimDueQuery locks the due scheduled tweets for the lease and returns
+// them. SKIP LOCKED lets several instances claim disjoint batches
+// concurrently. Failed tweets and those of deactivated users are left out.
+const claimDueQuery = `
+UPDATE scheduled_tweets s
+SET locked_until = ?
+FROM (
+    SELECT scheduled_tweets.id FROM scheduled_tweets
+    JOIN users ON users.id = scheduled_tweets.user_id AND users.deleted_at IS NULL
+    WHERE publish_at <= ? AND failed_at IS NULL
+      AND (locked_until IS NULL OR locked_until <= ?)
+    ORDER BY publish_at
+    LIMIT ?
+    FOR UPDATE OF scheduled_tweets SKIP LOCKED
+) due
+WHERE s.id = due.id
+RETURNING s.*`
+
+// recordFailedPublishQuery keeps the lease of a tweet that can be retried,
+// so that it is retried once the lease expires.
+const recordFailedPublishQuery = `
+UPDATE scheduled_tweets
+SET attempts = attempts + 1,
+    failed_at = CASE WHEN attempts + 1 >= ? THEN now() END,
+    locked_until = CASE WHEN attempts + 1 >= ? THEN NULL ELSE locked_until END
+WHERE id = ?`
+
+type scheduledTweetRepository struct {
+	db db.Connections
+}
+
+func NewScheduledTweetRepository(db db.Connections) *scheduledTweetRepository {
+	return &scheduledTweetRepository{db: db}
+}
+
+func (r *scheduledTweetRepository) CreateScheduledTweet(ctx context.Context, scheduled *tweet.ScheduledTweet) error {
+	model := scheduledFromDomain(scheduled)
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create scheduled tweet: %w", err)
+	}
+
+	scheduled.ID = model.ID.String()
+	scheduled.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *scheduledTweetRepository) GetScheduledTweets(ctx context.Context, userID string) ([]tweet.ScheduledTweet, error) {
+	var models []ScheduledTweet
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("publish_at, id").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find scheduled tweets: %w", err)
+	}
+
+	return toScheduledTweets(models), nil
+}
+
+// DeleteScheduledTweet skips tweets claimed by a worker so that a tweet
+// being published cannot be cancelled.
+func (r *scheduledTweetRepository) DeleteScheduledTweet(ctx context.Context, userID, id string) error {
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Where("locked_until IS NULL OR locked_until <= ?", time.Now()).
+		Delete(&ScheduledTweet{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete scheduled tweet: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return tweet.ErrScheduledTweetNotFound
+	}
+
+	return nil
+}
+
+func (r *scheduledTweetRepository) ClaimDueScheduledTweets(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]tweet.ScheduledTweet, error) {
+	var models []ScheduledTweet
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(claimDueQuery, now.Add(lease), now, now, limit).
+		Scan(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim scheduled tweets: %w", err)
+	}
+
+	return toScheduledTweets(models), nil
+}
+
+func (r *scheduledTweetRepository) RemoveScheduledTweet(ctx context.Context, id string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&ScheduledTweet{}).Error; err != nil {
+		return fmt.Errorf("failed to remove scheduled tweet: %w", err)
+	}
+
+	return nil
+}
+
+func (r *scheduledTweetRepository) RecordFailedPublish(ctx context.Context, id string, maxAttempts int) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Exec(recordFailedPublishQuery, maxAttempts, maxAttempts, id).Error; err != nil {
+		return fmt.Errorf("failed to record failed publication: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package tweet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+// searchConfigs maps the languages of tweets to their text search
+// configuration. It must match the search_vector column of tweets.
+var searchConfigs = map[string]string{
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fr": "french",
+	"it": "italian",
+	"pt": "portuguese",
+}
+
+// searchIndex is the search.Index backed by the search_vector column of
+// tweets. The column is generated by Postgres, so the index never needs to
+// be told about changes.
+type searchIndex struct {
+	db db.Connections
+}
+
+func NewSearchIndex(db db.Connections) *searchIndex {
+	return &searchIndex{db: db}
+}
+
+func (i *searchIndex) Apply(ctx context.Context, events []search.Event) error {
+	return nil
+}
+
+func (i *searchIndex) Reset(ctx context.Context) error {
+	return nil
+}
+
+// Search matches the text with websearch_to_tsquery against the
+// search_vector column. Without a language the words must appear as typed,
+// ignoring case; with one they are stemmed. Hashtags are matched as words
+// through the index and then checked against the content with a regular
+// expression, since the parser drops the #.
+func (i *searchIndex) Search(ctx context.Context, query search.Query, after *search.Hit, limit int) ([]search.Hit, error) {
+	db := i.db.MasterConn.
+		WithContext(ctx).
+		Model(&Tweet{}).
+		Select("tweets.id, tweets.created_at").
+		Order("tweets.created_at DESC, tweets.id DESC").
+		Limit(limit)
+
+	if query.Text != "" {
+		config := "simple"
+		if query.Language != "" {
+			config = searchConfigs[query.Language]
+		}
+		db = db.Where("tweets.search_vector @@ websearch_to_tsquery(?::regconfig, ?)", config, query.Text)
+	}
+	for _, tag := range query.Hashtags {
+		db = db.Where("tweets.search_vector @@ plainto_tsquery('simple', ?) AND tweets.content ~* ?",
+			tag, `(^|[^[:alnum:]_])#`+tag+`([^[:alnum:]_]|$)`)
+	}
+	if query.AuthorID != "" {
+		db = db.Where("tweets.user_id = ?", query.AuthorID)
+	}
+	if query.Language != "" {
+		db = db.Where("tweets.language = ?", query.Language)
+	}
+	if query.Since != nil {
+		db = db.Where("tweets.created_at >= ?", *query.Since)
+	}
+	if query.Until != nil {
+		db = db.Where("tweets.created_at < ?", *query.Until)
+	}
+	if after != nil {
+		db = db.Where("(tweets.created_at, tweets.id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var models []Tweet
+	if err := db.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to search tweets: %w", err)
+	}
+
+	hits := make([]search.Hit, len(models))
+	for i, m := range models {
+		hits[i] = search.Hit{ID: m.ID.String(), CreatedAt: m.CreatedAt}
+	}
+
+	return hits, nil
+}
+
+// GetVisibleTweets returns the tweets among ids that viewerID can see.
+func (r *tweetRepository) GetVisibleTweets(ctx context.Context, viewerID string, ids []string) ([]tweet.Tweet, error) {
+	var models []Tweet
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Scopes(preloadMedia, preloadLinks, visibleTo(viewerID)).
+		Where("tweets.id IN ?", ids).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find tweets: %w", err)
+	}
+
+	tweets := make([]tweet.Tweet, len(models))
+	for i := range models {
+		tweets[i] = models[i].toDomain()
+	}
+
+	return tweets, nil
+}
+
+// GetDocuments reads the tweets to index, oldest first, skipping those of
+// deactivated users.
+func (r *tweetRepository) GetDocuments(ctx context.Context, after *search.Hit, limit int) ([]search.Document, error) {
+	return r.getDocuments(ctx, "", after, limit)
+}
+
+func (r *tweetRepository) GetUserDocuments(ctx context.Context, userID string, after *search.Hit, limit int) ([]search.Document, error) {
+	return r.getDocuments(ctx, userID, after, limit)
+}
+
+func (r *tweetRepository) getDocuments(ctx context.Context, userID string, after *search.Hit, limit int) ([]search.Document, error) {
+	db := r.db.MasterConn.
+		WithContext(ctx).
+		Joins("JOIN users ON users.id = tweets.user_id AND users.deleted_at IS NULL").
+		Order("tweets.created_at, tweets.id").
+		Limit(limit)
+	if userID != "" {
+		db = db.Where("tweets.user_id = ?", userID)
+	}
+	if after != nil {
+		db = db.Where("(tweets.created_at, tweets.id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var models []Tweet
+	if err := db.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find tweets: %w", err)
+	}
+
+	docs := make([]search.Document, len(models))
+	for i, m := range models {
+		docs[i] = search.Document{
+			ID:        m.ID.String(),
+			UserID:    m.UserID,
+			Content:   m.Content,
+			Language:  m.Language,
+			CreatedAt: m.CreatedAt,
+		}
+	}
+
+	return docs, nil
+}
+
+// visibleTo keeps the tweets of active users that have no block with
+// viewerID in either direction, skipping protected accounts viewerID does
+// not follow.
+func visibleTo(viewerID string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Joins("JOIN users ON users.id = tweets.user_id AND users.deleted_at IS NULL").
+			Where(`NOT EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (blocker_id = ? AND blocked_id = tweets.user_id) OR (blocker_id = tweets.user_id AND blocked_id = ?)
+			)`, viewerID, viewerID).
+			Where(`(NOT users.protected OR users.id = ? OR EXISTS (
+				SELECT 1 FROM follows WHERE follower_id = ? AND followee_id = tweets.user_id
+			))`, viewerID, viewerID)
+	}
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"gorm.io/gorm"
+)
+
+// GetDeactivatedAt returns when the user was deactivated, or the zero time if
+// the account is active.
+func (r *userRepository) GetDeactivatedAt(ctx context.Context, id string) (time.Time, error) {
+	var model User
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Unscoped().
+		Select("id", "deleted_at").
+		Where("id = ?", id).
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, user.ErrUserNotFound
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if !model.DeletedAt.Valid {
+		return time.Time{}, nil
+	}
+
+	return model.DeletedAt.Time, nil
+}
+
+func (r *userRepository) GetDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	var ids []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Unscoped().
+		Model(&User{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deactivated users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// DeactivateUser soft deletes the user, which hides it from every scoped
+// query.
+func (r *userRepository) DeactivateUser(ctx context.Context, id string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&User{}).Error; err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) ReactivateUser(ctx context.Context, id string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Unscoped().
+		Model(&User{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error; err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUser permanently removes the user. Tweets, follows, follow requests,
+// blocks and username history are removed by ON DELETE CASCADE.
+func (r *userRepository) DeleteUser(ctx context.Context, id string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Unscoped().
+		Where("id = ?", id).
+		Delete(&User{}).Error; err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *userRepository) IsBlocking(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Block{}).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to find block: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *userRepository) HasBlockBetween(ctx context.Context, userID, otherID string) (bool, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherID, otherID, userID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to find block: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// GetBlockedAmong returns the IDs in otherIDs that have a block with userID
+// in either direction.
+func (r *userRepository) GetBlockedAmong(ctx context.Context, userID string, otherIDs []string) ([]string, error) {
+	var blocked []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(`SELECT blocked_id FROM blocks WHERE blocker_id = ? AND blocked_id IN ?
+			UNION
+			SELECT blocker_id FROM blocks WHERE blocked_id = ? AND blocker_id IN ?`,
+			userID, otherIDs, userID, otherIDs).
+		Scan(&blocked).Error; err != nil {
+		return nil, fmt.Errorf("failed to find blocks: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// GetHiddenAmong returns the IDs in ids that are deactivated, have a block
+// with viewerID in either direction, or are protected accounts viewerID does
+// not follow. The raw query skips the soft delete scope, so deactivated users
+// are matched explicitly.
+func (r *userRepository) GetHiddenAmong(ctx context.Context, viewerID string, ids []string) ([]string, error) {
+	var hidden []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(`SELECT id FROM users
+			WHERE id IN ? AND id <> ? AND (
+				deleted_at IS NOT NULL
+				OR EXISTS (
+					SELECT 1 FROM blocks
+					WHERE (blocker_id = ? AND blocked_id = users.id) OR (blocker_id = users.id AND blocked_id = ?)
+				)
+				OR (protected AND NOT EXISTS (
+					SELECT 1 FROM follows WHERE follower_id = ? AND followee_id = users.id
+				))
+			)`,
+			ids, viewerID, viewerID, viewerID, viewerID).
+		Scan(&hidden).Error; err != nil {
+		return nil, fmt.Errorf("failed to find hidden users: %w", err)
+	}
+
+	return hidden, nil
+}
+
+// BlockUser stores the block and removes follows and pending follow requests
+// between both users in a single transaction.
+func (r *userRepository) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	blockerUUID, err := uuid.Parse(blockerID)
+	if err != nil {
+		return fmt.Errorf("invalid blockerID: %w", err)
+	}
+
+	blockedUUID, err := uuid.Parse(blockedID)
+	if err != nil {
+		return fmt.Errorf("invalid blockedID: %w", err)
+	}
+
+	return r.db.MasterConn.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&Block{
+				BlockerID: blockerUUID,
+				BlockedID: blockedUUID,
+			}).Error; err != nil {
+				return fmt.Errorf("error creating block: %w", err)
+			}
+
+			if err := tx.
+				Where("(follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)", blockerUUID, blockedUUID, blockedUUID, blockerUUID).
+				Delete(&Follow{}).Error; err != nil {
+				return fmt.Errorf("error deleting follow relationships: %w", err)
+			}
+
+			if err := tx.
+				Where("(requester_id = ? AND target_id = ?) OR (requester_id = ? AND target_id = ?)", blockerUUID, blockedUUID, blockedUUID, blockerUUID).
+				Delete(&FollowRequest{}).Error; err != nil {
+				return fmt.Errorf("error deleting follow requests: %w", err)
+			}
+
+			return nil
+		})
+}
+
+func (r *userRepository) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	blockerUUID, err := uuid.Parse(blockerID)
+	if err != nil {
+		return fmt.Errorf("invalid blockerID: %w", err)
+	}
+
+	blockedUUID, err := uuid.Parse(blockedID)
+	if err != nil {
+		return fmt.Errorf("invalid blockedID: %w", err)
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Delete(&Block{
+			BlockerID: blockerUUID,
+			BlockedID: blockedUUID,
+		}).Error; err != nil {
+		return fmt.Errorf("error deleting block: %w", err)
+	}
+
+	return nil
+}
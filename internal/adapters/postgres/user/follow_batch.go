@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"gorm.io/gorm/clause"
+)
+
+func (r *userRepository) FindByIDs(ctx context.Context, ids []string) ([]user.User, error) {
+	var users []User
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id IN ?", ids).
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	userList := make([]user.User, 0, len(users))
+	for _, u := range users {
+		userList = append(userList, u.toDomain())
+	}
+
+	return userList, nil
+}
+
+func (r *userRepository) GetFollowingAmong(ctx context.Context, followerID string, followeeIDs []string) ([]string, error) {
+	var followees []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Follow{}).
+		Where("follower_id = ? AND followee_id IN ?", followerID, followeeIDs).
+		Pluck("followee_id", &followees).Error; err != nil {
+		return nil, fmt.Errorf("failed to find followees: %w", err)
+	}
+
+	return followees, nil
+}
+
+func (r *userRepository) GetFollowersAmong(ctx context.Context, followeeID string, followerIDs []string) ([]string, error) {
+	var followers []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Follow{}).
+		Where("followee_id = ? AND follower_id IN ?", followeeID, followerIDs).
+		Pluck("follower_id", &followers).Error; err != nil {
+		return nil, fmt.Errorf("failed to find followers: %w", err)
+	}
+
+	return followers, nil
+}
+
+func (r *userRepository) FollowUsers(ctx context.Context, followerID string, followeeIDs []string) error {
+	followerUUID, followeeUUIDs, err := parseFollowBatch(followerID, followeeIDs)
+	if err != nil {
+		return err
+	}
+
+	follows := make([]Follow, len(followeeUUIDs))
+	for i, followeeUUID := range followeeUUIDs {
+		follows[i] = Follow{
+			FollowerID: followerUUID,
+			FolloweeID: followeeUUID,
+		}
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&follows).Error; err != nil {
+		return fmt.Errorf("error creating follow relationships: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) UnfollowUsers(ctx context.Context, followerID string, followeeIDs []string) error {
+	followerUUID, followeeUUIDs, err := parseFollowBatch(followerID, followeeIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("follower_id = ? AND followee_id IN ?", followerUUID, followeeUUIDs).
+		Delete(&Follow{}).Error; err != nil {
+		return fmt.Errorf("error deleting follow relationships: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) CreateFollowRequests(ctx context.Context, requesterID string, targetIDs []string) error {
+	requesterUUID, targetUUIDs, err := parseFollowBatch(requesterID, targetIDs)
+	if err != nil {
+		return err
+	}
+
+	requests := make([]FollowRequest, len(targetUUIDs))
+	for i, targetUUID := range targetUUIDs {
+		requests[i] = FollowRequest{
+			RequesterID: requesterUUID,
+			TargetID:    targetUUID,
+		}
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&requests).Error; err != nil {
+		return fmt.Errorf("error creating follow requests: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) DeleteFollowRequests(ctx context.Context, requesterID string, targetIDs []string) error {
+	requesterUUID, targetUUIDs, err := parseFollowBatch(requesterID, targetIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("requester_id = ? AND target_id IN ?", requesterUUID, targetUUIDs).
+		Delete(&FollowRequest{}).Error; err != nil {
+		return fmt.Errorf("error deleting follow requests: %w", err)
+	}
+
+	return nil
+}
+
+func parseFollowBatch(followerID string, followeeIDs []string) (uuid.UUID, []uuid.UUID, error) {
+	followerUUID, err := uuid.Parse(followerID)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("invalid followerID: %w", err)
+	}
+
+	followeeUUIDs := make([]uuid.UUID, len(followeeIDs))
+	for i, followeeID := range followeeIDs {
+		followeeUUID, err := uuid.Parse(followeeID)
+		if err != nil {
+			return uuid.Nil, nil, fmt.Errorf("invalid followeeID: %w", err)
+		}
+		followeeUUIDs[i] = followeeUUID
+	}
+
+	return followerUUID, followeeUUIDs, nil
+}
@@ -0,0 +1,127 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"gorm.io/gorm"
+)
+
+func (r *userRepository) HasFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&FollowRequest{}).
+		Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to find follow request: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *userRepository) GetFollowRequestsAmong(ctx context.Context, requesterID string, targetIDs []string) ([]string, error) {
+	var targets []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&FollowRequest{}).
+		Where("requester_id = ? AND target_id IN ?", requesterID, targetIDs).
+		Pluck("target_id", &targets).Error; err != nil {
+		return nil, fmt.Errorf("failed to find follow requests: %w", err)
+	}
+
+	return targets, nil
+}
+
+func (r *userRepository) GetFollowRequests(ctx context.Context, targetID string) ([]user.FollowRequest, error) {
+	var requests []FollowRequest
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("target_id = ?", targetID).
+		Order("created_at DESC").
+		Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("failed to find follow requests: %w", err)
+	}
+
+	requestList := make([]user.FollowRequest, 0, len(requests))
+	for _, request := range requests {
+		requestList = append(requestList, request.toDomain())
+	}
+
+	return requestList, nil
+}
+
+func (r *userRepository) CreateFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	request, err := newFollowRequest(requesterID, targetID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(request).Error; err != nil {
+		return fmt.Errorf("error creating follow request: %w", err)
+	}
+
+	return nil
+}
+
+// ApproveFollowRequest deletes the pending request and creates the follow
+// relationship in a single transaction.
+func (r *userRepository) ApproveFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	request, err := newFollowRequest(requesterID, targetID)
+	if err != nil {
+		return err
+	}
+
+	return r.db.MasterConn.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(request).Error; err != nil {
+				return fmt.Errorf("error deleting follow request: %w", err)
+			}
+
+			if err := tx.Create(&Follow{
+				FollowerID: request.RequesterID,
+				FolloweeID: request.TargetID,
+			}).Error; err != nil {
+				return fmt.Errorf("error creating follow relationship: %w", err)
+			}
+
+			return nil
+		})
+}
+
+func (r *userRepository) DeleteFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	request, err := newFollowRequest(requesterID, targetID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Delete(request).Error; err != nil {
+		return fmt.Errorf("error deleting follow request: %w", err)
+	}
+
+	return nil
+}
+
+func newFollowRequest(requesterID, targetID string) (*FollowRequest, error) {
+	requesterUUID, err := uuid.Parse(requesterID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requesterID: %w", err)
+	}
+
+	targetUUID, err := uuid.Parse(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid targetID: %w", err)
+	}
+
+	return &FollowRequest{
+		RequesterID: requesterUUID,
+		TargetID:    targetUUID,
+	}, nil
+}
@@ -9,11 +9,18 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID      `gorm:"primaryKey;column:id"`
-	Username  string         `gorm:"column:username;unique;not null"`
-	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"column:updated_at;autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"index;column:deleted_at"`
+	ID                uuid.UUID      `gorm:"primaryKey;column:id"`
+	Username          string         `gorm:"column:username;unique;not null"`
+	UsernameSkeleton  string         `gorm:"column:username_skeleton;unique;not null"`
+	DisplayName       string         `gorm:"column:display_name;not null;default:''"`
+	Protected         bool           `gorm:"column:protected;not null;default:false"`
+	OpenDMs           bool           `gorm:"column:open_dms;not null;default:false"`
+	PinnedTweetID     *uuid.UUID     `gorm:"column:pinned_tweet_id"`
+	SensitiveMedia    string         `gorm:"column:sensitive_media;not null;default:blur"`
+	TimelineOwnTweets bool           `gorm:"column:timeline_own_tweets;not null;default:false"`
+	CreatedAt         time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt         gorm.DeletedAt `gorm:"index;column:deleted_at"`
 }
 
 type Follow struct {
@@ -22,9 +29,66 @@ type Follow struct {
 	CreatedAt  time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
 }
 
+type Block struct {
+	BlockerID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BlockedID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+type UsernameHistory struct {
+	ID               int64     `gorm:"primaryKey;column:id"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null"`
+	Username         string    `gorm:"column:username;not null"`
+	UsernameSkeleton string    `gorm:"column:username_skeleton;not null"`
+	ChangedAt        time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+func (UsernameHistory) TableName() string {
+	return "username_history"
+}
+
+type FollowRequest struct {
+	RequesterID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TargetID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt   time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+func (f *FollowRequest) toDomain() user.FollowRequest {
+	return user.FollowRequest{
+		RequesterID: f.RequesterID.String(),
+		TargetID:    f.TargetID.String(),
+		CreatedAt:   f.CreatedAt,
+	}
+}
+
+func (u *User) toDomain() user.User {
+	domain := user.User{
+		ID:                u.ID.String(),
+		Username:          u.Username,
+		DisplayName:       u.DisplayName,
+		Protected:         u.Protected,
+		OpenDMs:           u.OpenDMs,
+		SensitiveMedia:    user.SensitiveMedia(u.SensitiveMedia),
+		TimelineOwnTweets: u.TimelineOwnTweets,
+		CreatedAt:         u.CreatedAt,
+		UpdatedAt:         u.UpdatedAt,
+	}
+	if u.PinnedTweetID != nil {
+		domain.PinnedTweetID = u.PinnedTweetID.String()
+	}
+
+	return domain
+}
+
 func fromDomain(u *user.User) *User {
 	return &User{
-		ID:       uuid.New(),
-		Username: u.Username,
+		ID:                uuid.New(),
+		Username:          u.Username,
+		UsernameSkeleton:  user.UsernameSkeleton(u.Username),
+		DisplayName:       u.DisplayName,
+		Protected:         u.Protected,
+		OpenDMs:           u.OpenDMs,
+		SensitiveMedia:    string(u.SensitiveMedia),
+		TimelineOwnTweets: u.TimelineOwnTweets,
 	}
 }
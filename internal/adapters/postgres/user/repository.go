@@ -2,11 +2,13 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
 	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
 )
 
 type userRepository struct {
@@ -17,29 +19,82 @@ func NewUserRepository(db db.Connections) *userRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) CreateUser(ctx context.Context, user *user.User) error {
-	userModel := fromDomain(user)
+func (r *userRepository) CreateUser(ctx context.Context, u *user.User) error {
+	userModel := fromDomain(u)
 
 	err := r.db.MasterConn.
 		WithContext(ctx).
 		Create(userModel).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return user.ErrUsernameExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
-	user.ID = userModel.ID.String()
-	user.CreatedAt = userModel.CreatedAt
-	user.UpdatedAt = userModel.UpdatedAt
+	u.ID = userModel.ID.String()
+	u.CreatedAt = userModel.CreatedAt
+	u.UpdatedAt = userModel.UpdatedAt
+
+	return nil
+}
+
+func (r *userRepository) UpdateUser(ctx context.Context, id string, update user.UserUpdate) error {
+	updates := map[string]any{}
+	if update.DisplayName != nil {
+		updates["display_name"] = *update.DisplayName
+	}
+	if update.Protected != nil {
+		updates["protected"] = *update.Protected
+	}
+	if update.OpenDMs != nil {
+		updates["open_dms"] = *update.OpenDMs
+	}
+	if update.SensitiveMedia != nil {
+		updates["sensitive_media"] = string(*update.SensitiveMedia)
+	}
+	if update.TimelineOwnTweets != nil {
+		updates["timeline_own_tweets"] = *update.TimelineOwnTweets
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
 
 	return nil
 }
 
+// ExistsByUsername includes deactivated users so their usernames stay taken
+// until the account is permanently deleted.
 func (r *userRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var count int64
 	if err := r.db.MasterConn.
 		WithContext(ctx).
+		Unscoped().
+		Model(&User{}).
+		Where("lower(username) = lower(?)", username).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *userRepository) ExistsByUsernameSkeleton(ctx context.Context, skeleton string) (bool, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Unscoped().
 		Model(&User{}).
-		Where("username = ?", username).
+		Where("username_skeleton = ?", skeleton).
 		Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to find user: %w", err)
 	}
@@ -60,6 +115,74 @@ func (r *userRepository) ExistsByID(ctx context.Context, id string) (bool, error
 	return count > 0, nil
 }
 
+func (r *userRepository) IsProtected(ctx context.Context, id string) (bool, error) {
+	var protected []bool
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Limit(1).
+		Pluck("protected", &protected).Error; err != nil {
+		return false, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return len(protected) > 0 && protected[0], nil
+}
+
+// GetSensitiveMediaSetting falls back to SensitiveMediaBlur for unknown
+// users.
+func (r *userRepository) GetSensitiveMediaSetting(ctx context.Context, id string) (user.SensitiveMedia, error) {
+	var settings []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Limit(1).
+		Pluck("sensitive_media", &settings).Error; err != nil {
+		return "", fmt.Errorf("failed to find user: %w", err)
+	}
+	if len(settings) == 0 {
+		return user.SensitiveMediaBlur, nil
+	}
+
+	return user.SensitiveMedia(settings[0]), nil
+}
+
+// GetTimelineOwnTweetsSetting returns false for unknown users.
+func (r *userRepository) GetTimelineOwnTweetsSetting(ctx context.Context, id string) (bool, error) {
+	var settings []bool
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		Limit(1).
+		Pluck("timeline_own_tweets", &settings).Error; err != nil {
+		return false, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return len(settings) > 0 && settings[0], nil
+}
+
+// GetPinnedTweetID returns an empty ID if the user has no pinned tweet or
+// the pinned tweet was soft deleted.
+func (r *userRepository) GetPinnedTweetID(ctx context.Context, id string) (string, error) {
+	var ids []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&User{}).
+		Joins("JOIN tweets ON tweets.id = users.pinned_tweet_id AND tweets.deleted_at IS NULL").
+		Where("users.id = ?", id).
+		Limit(1).
+		Pluck("tweets.id", &ids).Error; err != nil {
+		return "", fmt.Errorf("failed to find pinned tweet: %w", err)
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	return ids[0], nil
+}
+
 // TODO: Consider refactoring this function to a separate package if follow logic grows.
 func (r *userRepository) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
 	var count int64
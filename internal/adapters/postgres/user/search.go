@@ -0,0 +1,85 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper escapes the LIKE wildcards of a search query.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type searchResult struct {
+	User
+	Following     bool  `gorm:"column:following"`
+	FollowerCount int64 `gorm:"column:follower_count"`
+}
+
+// SearchUsers matches the query as a prefix of the username, the display
+// name or any word of it, served by the trigram indexes. Users with a block
+// with viewerID in either direction are left out. Exact username matches
+// come first, then the users viewerID follows and then the most followed.
+func (r *userRepository) SearchUsers(ctx context.Context, viewerID, query string, limit int) ([]user.SearchResult, error) {
+	q := strings.ToLower(query)
+	prefix := likeEscaper.Replace(q) + "%"
+
+	var models []searchResult
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&User{}).
+		Select(`users.*,
+			EXISTS (SELECT 1 FROM follows WHERE follower_id = ? AND followee_id = users.id) AS following,
+			(SELECT count(*) FROM follows WHERE followee_id = users.id) AS follower_count`, viewerID).
+		Where(`lower(username) LIKE ? ESCAPE '\' OR lower(display_name) LIKE ? ESCAPE '\' OR lower(display_name) LIKE ? ESCAPE '\'`,
+			prefix, prefix, "% "+prefix).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = ? AND blocked_id = users.id) OR (blocker_id = users.id AND blocked_id = ?)
+		)`, viewerID, viewerID).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "lower(username) = ? DESC, following DESC, follower_count DESC, username", Vars: []any{q}}}).
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	results := make([]user.SearchResult, len(models))
+	for i := range models {
+		results[i] = user.SearchResult{
+			User:          models[i].User.toDomain(),
+			Following:     models[i].Following,
+			FollowerCount: int(models[i].FollowerCount),
+		}
+	}
+
+	return results, nil
+}
+
+// GetUsersUpdatedBetween returns the active users updated after the
+// checkpoint and before the given time, ordered by (updated_at, id).
+func (r *userRepository) GetUsersUpdatedBetween(ctx context.Context, after user.IndexCheckpoint, before time.Time, limit int) ([]user.User, error) {
+	afterID := after.ID
+	if afterID == "" {
+		afterID = "00000000-0000-0000-0000-000000000000"
+	}
+
+	var models []User
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("(updated_at, id) > (?, ?) AND updated_at < ?", after.UpdatedAt, afterID, before).
+		Order("updated_at, id").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find updated users: %w", err)
+	}
+
+	users := make([]user.User, len(models))
+	for i := range models {
+		users[i] = models[i].toDomain()
+	}
+
+	return users, nil
+}
@@ -0,0 +1,157 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"gorm.io/gorm"
+)
+
+func (r *userRepository) FindByID(ctx context.Context, id string) (*user.User, error) {
+	var model User
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	u := model.toDomain()
+	return &u, nil
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*user.User, error) {
+	var model User
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("lower(username) = lower(?)", username).
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	u := model.toDomain()
+	return &u, nil
+}
+
+// FindIDsByUsernames returns the IDs of the active users whose username
+// matches any of usernames, case-insensitively.
+func (r *userRepository) FindIDsByUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	var ids []string
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&User{}).
+		Where("lower(username) IN ?", usernames).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// FindByPreviousUsername returns the account that most recently released
+// username after since.
+func (r *userRepository) FindByPreviousUsername(ctx context.Context, username string, since time.Time) (*user.User, error) {
+	var model User
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Joins("JOIN username_history h ON h.user_id = users.id").
+		Where("lower(h.username) = lower(?) AND h.changed_at > ?", username, since).
+		Order("h.changed_at DESC").
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find username history: %w", err)
+	}
+
+	u := model.toDomain()
+	return &u, nil
+}
+
+// LastUsernameChange returns when the user last changed their username, or
+// the zero time if they never did.
+func (r *userRepository) LastUsernameChange(ctx context.Context, id string) (time.Time, error) {
+	var changedAt []time.Time
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&UsernameHistory{}).
+		Where("user_id = ?", id).
+		Order("changed_at DESC").
+		Limit(1).
+		Pluck("changed_at", &changedAt).Error; err != nil {
+		return time.Time{}, fmt.Errorf("failed to find username history: %w", err)
+	}
+
+	if len(changedAt) == 0 {
+		return time.Time{}, nil
+	}
+
+	return changedAt[0], nil
+}
+
+// IsUsernameHeld reports whether a username with the given skeleton was
+// released after since by a user other than exceptUserID.
+func (r *userRepository) IsUsernameHeld(ctx context.Context, skeleton, exceptUserID string, since time.Time) (bool, error) {
+	query := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&UsernameHistory{}).
+		Where("username_skeleton = ? AND changed_at > ?", skeleton, since)
+	if exceptUserID != "" {
+		query = query.Where("user_id <> ?", exceptUserID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to find username history: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ChangeUsername records the old username in the history and renames the
+// user in a single transaction.
+func (r *userRepository) ChangeUsername(ctx context.Context, id, oldUsername, newUsername string) error {
+	userUUID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("invalid userID: %w", err)
+	}
+
+	err = r.db.MasterConn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		history := &UsernameHistory{
+			UserID:           userUUID,
+			Username:         oldUsername,
+			UsernameSkeleton: user.UsernameSkeleton(oldUsername),
+		}
+		if err := tx.Create(history).Error; err != nil {
+			return fmt.Errorf("failed to create username history: %w", err)
+		}
+
+		return tx.Model(&User{}).
+			Where("id = ?", userUUID).
+			Updates(map[string]any{
+				"username":          newUsername,
+				"username_skeleton": user.UsernameSkeleton(newUsername),
+			}).Error
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return user.ErrUsernameExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to change username: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+)
+
+// claimDueQuery postpones the due deliveries by the lease and returns them.
+// SKIP LOCKED lets several instances claim disjoint batches concurrently.
+const claimDueQuery = `
+UPDATE webhook_deliveries d
+SET next_attempt_at = ?
+FROM (
+    SELECT id FROM webhook_deliveries
+    WHERE status = ? AND next_attempt_at <= ?
+    ORDER BY next_attempt_at
+    LIMIT ?
+    FOR UPDATE SKIP LOCKED
+) due
+WHERE d.id = due.id
+RETURNING d.*`
+
+type deliveryRepository struct {
+	db db.Connections
+}
+
+func NewDeliveryRepository(db db.Connections) *deliveryRepository {
+	return &deliveryRepository{db: db}
+}
+
+func (r *deliveryRepository) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error {
+	models := make([]*Delivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		model, err := deliveryFromDomain(d)
+		if err != nil {
+			return fmt.Errorf("invalid webhook delivery: %w", err)
+		}
+		models = append(models, model)
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	for i, model := range models {
+		deliveries[i].ID = model.ID.String()
+		deliveries[i].CreatedAt = model.CreatedAt
+	}
+
+	return nil
+}
+
+func (r *deliveryRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+	var models []Delivery
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw(claimDueQuery, now.Add(lease), string(webhook.DeliveryPending), now, limit).
+		Scan(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return toDeliveries(models), nil
+}
+
+func (r *deliveryRepository) UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Delivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]any{
+			"status":          string(delivery.Status),
+			"attempts":        delivery.Attempts,
+			"response_status": delivery.ResponseStatus,
+			"error":           delivery.Error,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"last_attempt_at": delivery.LastAttemptAt,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *deliveryRepository) GetDeliveries(ctx context.Context, subscriptionID string, limit int) ([]webhook.Delivery, error) {
+	var models []Delivery
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+
+	return toDeliveries(models), nil
+}
+
+func toDeliveries(models []Delivery) []webhook.Delivery {
+	deliveries := make([]webhook.Delivery, 0, len(models))
+	for _, m := range models {
+		deliveries = append(deliveries, m.toDomain())
+	}
+
+	return deliveries
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+)
+
+type Subscription struct {
+	ID                  uuid.UUID  `gorm:"primaryKey;column:id"`
+	UserID              uuid.UUID  `gorm:"type:uuid;not null"`
+	URL                 string     `gorm:"column:url;not null"`
+	Secret              string     `gorm:"column:secret;not null"`
+	Events              string     `gorm:"column:events;type:jsonb;not null"`
+	Active              bool       `gorm:"column:active;not null"`
+	ConsecutiveFailures int        `gorm:"column:consecutive_failures;not null"`
+	DisabledAt          *time.Time `gorm:"column:disabled_at"`
+	CreatedAt           time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s *Subscription) toDomain() (webhook.Subscription, error) {
+	var events []webhook.EventType
+	if err := json.Unmarshal([]byte(s.Events), &events); err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	return webhook.Subscription{
+		ID:                  s.ID.String(),
+		UserID:              s.UserID.String(),
+		URL:                 s.URL,
+		Secret:              s.Secret,
+		Events:              events,
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		CreatedAt:           s.CreatedAt,
+	}, nil
+}
+
+func subscriptionFromDomain(s *webhook.Subscription) (*Subscription, error) {
+	userID, err := uuid.Parse(s.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := json.Marshal(s.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscription{
+		ID:     uuid.New(),
+		UserID: userID,
+		URL:    s.URL,
+		Secret: s.Secret,
+		Events: string(events),
+		Active: s.Active,
+	}, nil
+}
+
+type Delivery struct {
+	ID             uuid.UUID  `gorm:"primaryKey;column:id"`
+	SubscriptionID uuid.UUID  `gorm:"type:uuid;not null"`
+	EventType      string     `gorm:"column:event_type;not null"`
+	Payload        string     `gorm:"column:payload;type:jsonb;not null"`
+	Status         string     `gorm:"column:status;not null"`
+	Attempts       int        `gorm:"column:attempts;not null"`
+	ResponseStatus int        `gorm:"column:response_status;not null"`
+	Error          string     `gorm:"column:error;not null"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;not null"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (d *Delivery) toDomain() webhook.Delivery {
+	return webhook.Delivery{
+		ID:             d.ID.String(),
+		SubscriptionID: d.SubscriptionID.String(),
+		EventType:      webhook.EventType(d.EventType),
+		Payload:        json.RawMessage(d.Payload),
+		Status:         webhook.DeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func deliveryFromDomain(d webhook.Delivery) (*Delivery, error) {
+	subscriptionID, err := uuid.Parse(d.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Delivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		EventType:      string(d.EventType),
+		Payload:        string(d.Payload),
+		Status:         string(d.Status),
+		NextAttemptAt:  d.NextAttemptAt,
+	}, nil
+}
@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+	db "github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/pg"
+	"gorm.io/gorm"
+)
+
+type subscriptionRepository struct {
+	db db.Connections
+}
+
+func NewSubscriptionRepository(db db.Connections) *subscriptionRepository {
+	return &subscriptionRepository{db: db}
+}
+
+func (r *subscriptionRepository) CreateSubscription(ctx context.Context, subscription *webhook.Subscription) error {
+	model, err := subscriptionFromDomain(subscription)
+	if err != nil {
+		return fmt.Errorf("invalid webhook subscription: %w", err)
+	}
+
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	subscription.ID = model.ID.String()
+	subscription.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *subscriptionRepository) CountSubscriptions(ctx context.Context, userID string) (int, error) {
+	var count int64
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Subscription{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count webhook subscriptions: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (r *subscriptionRepository) GetSubscriptions(ctx context.Context, userID string) ([]webhook.Subscription, error) {
+	return r.findSubscriptions(r.db.MasterConn.
+		WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC"))
+}
+
+func (r *subscriptionRepository) GetSubscription(ctx context.Context, userID, id string) (*webhook.Subscription, error) {
+	var model Subscription
+	err := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscription: %w", err)
+	}
+
+	subscription, err := model.toDomain()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode webhook subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+func (r *subscriptionRepository) GetSubscriptionsByIDs(ctx context.Context, ids []string) ([]webhook.Subscription, error) {
+	return r.findSubscriptions(r.db.MasterConn.
+		WithContext(ctx).
+		Where("id IN ?", ids))
+}
+
+// GetActiveSubscriptions returns the active subscriptions of the user that
+// include the event type.
+func (r *subscriptionRepository) GetActiveSubscriptions(ctx context.Context, userID string, eventType webhook.EventType) ([]webhook.Subscription, error) {
+	return r.findSubscriptions(r.db.MasterConn.
+		WithContext(ctx).
+		Where("user_id = ? AND active AND events @> jsonb_build_array(?::text)", userID, string(eventType)))
+}
+
+func (r *subscriptionRepository) DeleteSubscription(ctx context.Context, userID, id string) error {
+	result := r.db.MasterConn.
+		WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&Subscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *subscriptionRepository) EnableSubscription(ctx context.Context, id string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Subscription{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"active":               true,
+			"consecutive_failures": 0,
+			"disabled_at":          nil,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to enable webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *subscriptionRepository) RecordFailure(ctx context.Context, id string) (int, error) {
+	var failures int
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Raw("UPDATE webhook_subscriptions SET consecutive_failures = consecutive_failures + 1, updated_at = now() WHERE id = ? RETURNING consecutive_failures", id).
+		Scan(&failures).Error; err != nil {
+		return 0, fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+
+	return failures, nil
+}
+
+func (r *subscriptionRepository) ResetFailures(ctx context.Context, id string) error {
+	if err := r.db.MasterConn.
+		WithContext(ctx).
+		Model(&Subscription{}).
+		Where("id = ?", id).
+		Update("consecutive_failures", 0).Error; err != nil {
+		return fmt.Errorf("failed to reset webhook failures: %w", err)
+	}
+
+	return nil
+}
+
+// DisableSubscription deactivates the subscription and fails its pending
+// deliveries in a single transaction.
+func (r *subscriptionRepository) DisableSubscription(ctx context.Context, id string, at time.Time) error {
+	return r.db.MasterConn.
+		WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if err := tx.
+				Model(&Subscription{}).
+				Where("id = ?", id).
+				Updates(map[string]any{
+					"active":      false,
+					"disabled_at": at,
+				}).Error; err != nil {
+				return fmt.Errorf("error disabling webhook subscription: %w", err)
+			}
+
+			if err := tx.
+				Model(&Delivery{}).
+				Where("subscription_id = ? AND status = ?", id, string(webhook.DeliveryPending)).
+				Updates(map[string]any{
+					"status": string(webhook.DeliveryFailed),
+					"error":  "subscription disabled",
+				}).Error; err != nil {
+				return fmt.Errorf("error failing pending deliveries: %w", err)
+			}
+
+			return nil
+		})
+}
+
+func (r *subscriptionRepository) findSubscriptions(query *gorm.DB) ([]webhook.Subscription, error) {
+	var models []Subscription
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+
+	subscriptions := make([]webhook.Subscription, 0, len(models))
+	for _, m := range models {
+		subscription, err := m.toDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode webhook subscription %s: %w", m.ID, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,95 @@
+package poll
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// incrementScript only increments the counter of a cached poll, so that a
+// vote cast after the tallies expired does not cache a partial tally.
+var incrementScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("HINCRBY", KEYS[1], ARGV[1], 1)
+end
+return 0
+`)
+
+type tallyCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewCache(c *redis.Client, cfg config.Cache) (*tallyCache, error) {
+	return &tallyCache{client: c, ttl: cfg.PollTalliesTTL}, nil
+}
+
+func talliesKey(tweetID string) string {
+	return fmt.Sprintf("poll:tallies:%s", tweetID)
+}
+
+func (r *tallyCache) GetTallies(ctx context.Context, tweetIDs []string) (map[string]poll.Tallies, error) {
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(tweetIDs))
+	for i, id := range tweetIDs {
+		cmds[i] = pipe.HGetAll(ctx, talliesKey(id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to retrieve poll tallies: %w", err)
+	}
+
+	tallies := make(map[string]poll.Tallies, len(tweetIDs))
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			continue
+		}
+
+		t := make(poll.Tallies, len(fields))
+		for field, value := range fields {
+			position, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid poll tallies for tweet %s: %w", tweetIDs[i], err)
+			}
+			votes, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid poll tallies for tweet %s: %w", tweetIDs[i], err)
+			}
+			t[position] = votes
+		}
+		tallies[tweetIDs[i]] = t
+	}
+
+	return tallies, nil
+}
+
+func (r *tallyCache) SetTallies(ctx context.Context, tweetID string, tallies poll.Tallies) error {
+	key := talliesKey(tweetID)
+
+	values := make(map[string]any, len(tallies))
+	for position, votes := range tallies {
+		values[strconv.Itoa(position)] = votes
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, values)
+	pipe.Expire(ctx, key, r.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set poll tallies for tweet %s: %w", tweetID, err)
+	}
+
+	return nil
+}
+
+func (r *tallyCache) Increment(ctx context.Context, tweetID string, option int) error {
+	if err := incrementScript.Run(ctx, r.client, []string{talliesKey(tweetID)}, option).Err(); err != nil {
+		return fmt.Errorf("failed to increment poll tallies for tweet %s: %w", tweetID, err)
+	}
+
+	return nil
+}
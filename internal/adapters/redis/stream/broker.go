@@ -0,0 +1,213 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+// publishScript appends the event to the topic history and publishes it with
+// the ID Redis assigned, so that live and replayed events share IDs.
+var publishScript = redis.NewScript(`
+local id = redis.call('XADD', KEYS[1], 'MAXLEN', '~', ARGV[1], '*', 'type', ARGV[2], 'data', ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+redis.call('PUBLISH', ARGV[5], id .. '\n' .. ARGV[2] .. '\n' .. ARGV[3])
+return id
+`)
+
+var eventIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// broker shares a single Redis pub/sub connection per API instance and fans
+// messages out to the local subscriptions of each topic.
+type broker struct {
+	client *redis.Client
+	cfg    config.Stream
+
+	mu     sync.Mutex
+	pubsub *redis.PubSub
+	topics map[string]map[*subscription]struct{}
+}
+
+// NewBroker returns a broker whose pub/sub connection is closed when the
+// application stops.
+func NewBroker(lc fx.Lifecycle, client *redis.Client, cfg config.Stream) *broker {
+	b := &broker{
+		client: client,
+		cfg:    cfg,
+		topics: make(map[string]map[*subscription]struct{}),
+	}
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			return b.close()
+		},
+	})
+
+	return b
+}
+
+func (b *broker) Publish(ctx context.Context, topics []string, event stream.Event) error {
+	pipe := b.client.Pipeline()
+	for _, topic := range topics {
+		publishScript.Eval(ctx, pipe,
+			[]string{historyKey(topic)},
+			b.cfg.HistorySize, string(event.Type), string(event.Data), b.cfg.HistoryTTL.Milliseconds(), channel(topic),
+		)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+func (b *broker) Broadcast(ctx context.Context, topics []string, event stream.Event) error {
+	payload := formatMessage(event)
+
+	pipe := b.client.Pipeline()
+	for _, topic := range topics {
+		pipe.Publish(ctx, channel(topic), payload)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to broadcast event: %w", err)
+	}
+
+	return nil
+}
+
+func (b *broker) Subscribe(ctx context.Context, topics []string) (stream.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pubsub == nil {
+		b.pubsub = b.client.Subscribe(context.Background())
+		go b.dispatch(b.pubsub.Channel())
+	}
+
+	var channels []string
+	for _, topic := range topics {
+		if len(b.topics[topic]) == 0 {
+			channels = append(channels, channel(topic))
+		}
+	}
+	if len(channels) > 0 {
+		if err := b.pubsub.Subscribe(ctx, channels...); err != nil {
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+	}
+
+	sub := &subscription{
+		broker: b,
+		topics: topics,
+		events: make(chan stream.Event, b.cfg.BufferSize),
+	}
+	for _, topic := range topics {
+		if b.topics[topic] == nil {
+			b.topics[topic] = make(map[*subscription]struct{})
+		}
+		b.topics[topic][sub] = struct{}{}
+	}
+
+	return sub, nil
+}
+
+func (b *broker) History(ctx context.Context, topic, afterID string) ([]stream.Event, error) {
+	if !eventIDPattern.MatchString(afterID) {
+		return nil, stream.ErrInvalidEventID
+	}
+
+	messages, err := b.client.XRangeN(ctx, historyKey(topic), "("+afterID, "+", int64(b.cfg.HistorySize)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event history: %w", err)
+	}
+
+	events := make([]stream.Event, 0, len(messages))
+	for _, m := range messages {
+		eventType, _ := m.Values["type"].(string)
+		data, _ := m.Values["data"].(string)
+		events = append(events, stream.Event{ID: m.ID, Topic: topic, Type: stream.EventType(eventType), Data: []byte(data)})
+	}
+
+	return events, nil
+}
+
+func (b *broker) close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pubsub == nil {
+		return nil
+	}
+
+	return b.pubsub.Close()
+}
+
+func (b *broker) dispatch(messages <-chan *redis.Message) {
+	for msg := range messages {
+		event, ok := parseMessage(msg.Payload)
+		if !ok {
+			continue
+		}
+
+		topic := strings.TrimPrefix(msg.Channel, channelPrefix)
+		event.Topic = topic
+		b.mu.Lock()
+		for sub := range b.topics[topic] {
+			sub.deliver(event)
+		}
+		b.mu.Unlock()
+	}
+}
+
+func (b *broker) unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var channels []string
+	for _, topic := range sub.topics {
+		delete(b.topics[topic], sub)
+		if len(b.topics[topic]) == 0 {
+			delete(b.topics, topic)
+			channels = append(channels, channel(topic))
+		}
+	}
+
+	if len(channels) > 0 && b.pubsub != nil {
+		_ = b.pubsub.Unsubscribe(context.Background(), channels...)
+	}
+}
+
+const (
+	channelPrefix = "stream:"
+	historyPrefix = "stream:history:"
+)
+
+func channel(topic string) string {
+	return channelPrefix + topic
+}
+
+func historyKey(topic string) string {
+	return historyPrefix + topic
+}
+
+// Messages are "id\ntype\ndata"; broadcast events have an empty id.
+func formatMessage(event stream.Event) string {
+	return event.ID + "\n" + string(event.Type) + "\n" + string(event.Data)
+}
+
+func parseMessage(payload string) (stream.Event, bool) {
+	parts := strings.SplitN(payload, "\n", 3)
+	if len(parts) != 3 {
+		return stream.Event{}, false
+	}
+
+	return stream.Event{ID: parts[0], Type: stream.EventType(parts[1]), Data: []byte(parts[2])}, true
+}
@@ -0,0 +1,61 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream"
+)
+
+// subscription buffers up to config.Stream.BufferSize events. A subscriber
+// that lets the buffer fill up is cut off instead of slowing down the
+// dispatch of every other subscriber; it can reconnect and resume from the
+// history.
+type subscription struct {
+	broker *broker
+	topics []string
+	events chan stream.Event
+
+	mu     sync.Mutex
+	closed bool
+	err    error
+}
+
+func (s *subscription) Events() <-chan stream.Event {
+	return s.events
+}
+
+func (s *subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func (s *subscription) Close() {
+	s.broker.unsubscribe(s)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+}
+
+func (s *subscription) deliver(event stream.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.events <- event:
+	default:
+		s.closed = true
+		s.err = stream.ErrSlowConsumer
+		close(s.events)
+	}
+}
@@ -0,0 +1,56 @@
+package suggestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/platform/config"
+	"github.com/redis/go-redis/v9"
+)
+
+type suggestionCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewCache(c *redis.Client, cfg config.Cache) (*suggestionCache, error) {
+	return &suggestionCache{client: c, ttl: cfg.SuggestionsTTL}, nil
+}
+
+func (r *suggestionCache) GetSuggestions(ctx context.Context, userID string) ([]recommendation.Suggestion, error) {
+	key := fmt.Sprintf("suggestions:%s", userID)
+
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("suggestions not found for user %s: %w", userID, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve suggestions cache for user %s: %w", userID, err)
+	}
+
+	var suggestions []recommendation.Suggestion
+	if err := json.Unmarshal([]byte(data), &suggestions); err != nil {
+		return nil, fmt.Errorf("failed to parse suggestions data for user %s: %w", userID, err)
+	}
+
+	return suggestions, nil
+}
+
+func (r *suggestionCache) SetSuggestions(ctx context.Context, userID string, suggestions []recommendation.Suggestion) error {
+	key := fmt.Sprintf("suggestions:%s", userID)
+
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		return fmt.Errorf("failed to serialize suggestions data for user %s: %w", userID, err)
+	}
+
+	if err := r.client.Set(ctx, key, string(data), r.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set suggestions cache for user %s: %w", userID, err)
+	}
+
+	return nil
+}
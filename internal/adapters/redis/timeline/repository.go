@@ -21,8 +21,8 @@ func NewCache(c *redis.Client, ttl time.Duration) (*timelineCache, error) {
 	return &timelineCache{client: c, ttl: ttl}, nil
 }
 
-func (r *timelineCache) GetTimeline(ctx context.Context, userID string) ([]tweet.Tweet, error) {
-	key := fmt.Sprintf("timeline:%s", userID)
+func (r *timelineCache) GetTimeline(ctx context.Context, userID string, includeOwn bool) ([]tweet.Tweet, error) {
+	key := timelineKey(userID, includeOwn)
 
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
@@ -44,8 +44,8 @@ func (r *timelineCache) GetTimeline(ctx context.Context, userID string) ([]tweet
 	return timelineCache, nil
 }
 
-func (r *timelineCache) SetTimeline(ctx context.Context, userID string, tweets []tweet.Tweet) error {
-	key := fmt.Sprintf("timeline:%s", userID)
+func (r *timelineCache) SetTimeline(ctx context.Context, userID string, includeOwn bool, tweets []tweet.Tweet) error {
+	key := timelineKey(userID, includeOwn)
 
 	data, err := json.Marshal(tweets)
 	if err != nil {
@@ -60,9 +60,63 @@ func (r *timelineCache) SetTimeline(ctx context.Context, userID string, tweets [
 }
 
 func (r *timelineCache) InvalidateTimeline(ctx context.Context, userID string) error {
-	key := fmt.Sprintf("timeline:%s", userID)
-	if err := r.client.Del(ctx, key).Err(); err != nil {
+	if err := r.client.Del(ctx, timelineKey(userID, false), timelineKey(userID, true)).Err(); err != nil {
 		return fmt.Errorf("failed to invalidate timeline cache for user %s: %w", userID, err)
 	}
 	return nil
 }
+
+func (r *timelineCache) GetListTimeline(ctx context.Context, listID string) ([]tweet.Tweet, error) {
+	key := fmt.Sprintf("list_timeline:%s", listID)
+
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("timeline not found for list %s: %w", listID, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve timeline cache for list %s: %w", listID, err)
+	}
+
+	var timelineCache []tweet.Tweet
+	if err := json.Unmarshal([]byte(data), &timelineCache); err != nil {
+		return nil, fmt.Errorf("failed to parse timeline data for list %s: %w", listID, err)
+	}
+
+	sort.Slice(timelineCache, func(i, j int) bool {
+		return timelineCache[i].CreatedAt.After(timelineCache[j].CreatedAt)
+	})
+
+	return timelineCache, nil
+}
+
+func (r *timelineCache) SetListTimeline(ctx context.Context, listID string, tweets []tweet.Tweet) error {
+	key := fmt.Sprintf("list_timeline:%s", listID)
+
+	data, err := json.Marshal(tweets)
+	if err != nil {
+		return fmt.Errorf("failed to serialize timeline data for list %s: %w", listID, err)
+	}
+
+	if err := r.client.Set(ctx, key, string(data), r.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set timeline cache for list %s: %w", listID, err)
+	}
+
+	return nil
+}
+
+func (r *timelineCache) InvalidateListTimeline(ctx context.Context, listID string) error {
+	key := fmt.Sprintf("list_timeline:%s", listID)
+	if err := r.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate timeline cache for list %s: %w", listID, err)
+	}
+	return nil
+}
+
+// timelineKey keeps the home timeline with the user's own tweets apart from
+// the one without them, so both can be cached at the same time.
+func timelineKey(userID string, includeOwn bool) string {
+	if includeOwn {
+		return fmt.Sprintf("timeline:%s:own", userID)
+	}
+	return fmt.Sprintf("timeline:%s", userID)
+}
@@ -0,0 +1,126 @@
+package typeahead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// indexKey is a sorted set where every member is "term\x00userID" with
+	// score 0, so ZRANGEBYLEX returns the users whose terms start with a
+	// prefix.
+	indexKey      = "typeahead:users"
+	checkpointKey = "typeahead:users:checkpoint"
+)
+
+// termsKey holds the terms a user is indexed under, so they can be removed
+// when the user is indexed again.
+func termsKey(userID string) string {
+	return fmt.Sprintf("typeahead:users:%s:terms", userID)
+}
+
+func member(term, userID string) string {
+	return term + "\x00" + userID
+}
+
+type typeaheadIndex struct {
+	client *redis.Client
+}
+
+func NewIndex(c *redis.Client) (*typeaheadIndex, error) {
+	return &typeaheadIndex{client: c}, nil
+}
+
+// FindByPrefix returns up to limit user IDs, once each, ordered by term.
+func (r *typeaheadIndex) FindByPrefix(ctx context.Context, prefix string, limit int) ([]string, error) {
+	members, err := r.client.ZRangeByLex(ctx, indexKey, &redis.ZRangeBy{
+		Min:   "[" + prefix,
+		Max:   "[" + prefix + "\xff",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read typeahead index: %w", err)
+	}
+
+	ids := make([]string, 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, m := range members {
+		_, id, found := strings.Cut(m, "\x00")
+		if !found || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (r *typeaheadIndex) GetCheckpoint(ctx context.Context) (user.IndexCheckpoint, error) {
+	raw, err := r.client.Get(ctx, checkpointKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return user.IndexCheckpoint{}, nil
+	}
+	if err != nil {
+		return user.IndexCheckpoint{}, fmt.Errorf("failed to retrieve typeahead checkpoint: %w", err)
+	}
+
+	nanos, id, found := strings.Cut(raw, "|")
+	if !found {
+		return user.IndexCheckpoint{}, fmt.Errorf("invalid typeahead checkpoint %q", raw)
+	}
+	ts, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return user.IndexCheckpoint{}, fmt.Errorf("invalid typeahead checkpoint %q: %w", raw, err)
+	}
+
+	return user.IndexCheckpoint{UpdatedAt: time.Unix(0, ts).UTC(), ID: id}, nil
+}
+
+// IndexUsers replaces the terms of the users and moves the checkpoint in a
+// single transaction.
+func (r *typeaheadIndex) IndexUsers(ctx context.Context, users []user.User, checkpoint user.IndexCheckpoint) error {
+	pipe := r.client.Pipeline()
+	previous := make([]*redis.StringSliceCmd, len(users))
+	for i, u := range users {
+		previous[i] = pipe.SMembers(ctx, termsKey(u.ID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to retrieve indexed terms: %w", err)
+	}
+
+	tx := r.client.TxPipeline()
+	for i, u := range users {
+		if old := previous[i].Val(); len(old) > 0 {
+			members := make([]any, len(old))
+			for j, term := range old {
+				members[j] = member(term, u.ID)
+			}
+			tx.ZRem(ctx, indexKey, members...)
+			tx.Del(ctx, termsKey(u.ID))
+		}
+
+		terms := user.TypeaheadTerms(u)
+		members := make([]redis.Z, len(terms))
+		values := make([]any, len(terms))
+		for j, term := range terms {
+			members[j] = redis.Z{Member: member(term, u.ID)}
+			values[j] = term
+		}
+		tx.ZAdd(ctx, indexKey, members...)
+		tx.SAdd(ctx, termsKey(u.ID), values...)
+	}
+	tx.Set(ctx, checkpointKey, strconv.FormatInt(checkpoint.UpdatedAt.UnixNano(), 10)+"|"+checkpoint.ID, 0)
+	if _, err := tx.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index users: %w", err)
+	}
+
+	return nil
+}
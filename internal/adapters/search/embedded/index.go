@@ -0,0 +1,362 @@
+package embedded
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search"
+)
+
+// compactEvery is how many journaled events trigger a new snapshot.
+const compactEvery = 10000
+
+type (
+	// entry is an indexed tweet with its content split for matching.
+	entry struct {
+		search.Document
+		words    []string
+		hashtags []string
+	}
+
+	// postings maps a term to the tweets that contain it, sorted by
+	// (created_at, id) so that a search walks them newest first from its
+	// cursor instead of sorting them.
+	postings map[string][]*entry
+
+	// index is an inverted index kept in memory and persisted to a
+	// directory as a snapshot plus a journal of the events applied since.
+	// It is meant for a single node: two processes must not open the same
+	// directory.
+	index struct {
+		mu       sync.RWMutex
+		docs     map[string]*entry
+		all      []*entry
+		words    postings
+		hashtags postings
+		authors  postings
+		store    *store
+	}
+)
+
+// Open loads the index stored in dir, creating it if needed.
+func Open(dir string) (*index, error) {
+	s, err := openStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &index{store: s}
+	idx.clear()
+
+	docs, events, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		idx.add(doc)
+	}
+	for _, e := range events {
+		idx.apply(e)
+	}
+
+	return idx, nil
+}
+
+// Apply journals the events before applying them, so they survive a crash.
+func (i *index) Apply(ctx context.Context, events []search.Event) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if err := i.store.journal(events); err != nil {
+		return err
+	}
+	for _, e := range events {
+		i.apply(e)
+	}
+
+	if i.store.journaled >= compactEvery {
+		return i.store.snapshot(i.documents())
+	}
+
+	return nil
+}
+
+// Search walks the most selective posting lists of the query newest first,
+// from the cursor, and checks each tweet against the whole query until it
+// has limit hits. Words are compared as typed, ignoring case: unlike
+// Postgres, a language filter does not stem them.
+func (i *index) Search(ctx context.Context, query search.Query, after *search.Hit, limit int) ([]search.Hit, error) {
+	clauses := parseText(query.Text)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	lists := i.candidates(query, clauses)
+
+	// next[k] is the position past the next tweet to read from lists[k].
+	next := make([]int, len(lists))
+	for k, list := range lists {
+		next[k] = len(list)
+		if after != nil {
+			next[k] = seek(list, after.CreatedAt, after.ID)
+		}
+		if query.Until != nil {
+			next[k] = min(next[k], seek(list, *query.Until, ""))
+		}
+	}
+
+	var hits []search.Hit
+	var last *entry
+	for len(hits) < limit {
+		e := newest(lists, next)
+		if e == nil || (query.Since != nil && e.CreatedAt.Before(*query.Since)) {
+			break
+		}
+		// A tweet in several lists of an or comes out of each of them in a
+		// row.
+		if e == last {
+			continue
+		}
+		last = e
+
+		if e.matches(query, clauses) {
+			hits = append(hits, search.Hit{ID: e.ID, CreatedAt: e.CreatedAt})
+		}
+	}
+
+	return hits, nil
+}
+
+func (i *index) Reset(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if err := i.store.reset(); err != nil {
+		return err
+	}
+	i.clear()
+
+	return nil
+}
+
+// Close snapshots the index, so the next Open does not replay the journal.
+func (i *index) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if err := i.store.snapshot(i.documents()); err != nil {
+		return err
+	}
+
+	return i.store.close()
+}
+
+func (i *index) clear() {
+	i.docs = map[string]*entry{}
+	i.all = nil
+	i.words = postings{}
+	i.hashtags = postings{}
+	i.authors = postings{}
+}
+
+// apply removes the tweets of deactivated and deleted users. A rename needs
+// no event: the index keeps the author ID and from: is resolved at search
+// time.
+func (i *index) apply(e search.Event) {
+	switch e.Type {
+	case search.TweetCreated:
+		i.remove(e.Tweet.ID)
+		i.add(e.Tweet)
+	case search.UserDeactivated, search.UserDeleted:
+		for _, t := range slices.Clone(i.authors[e.UserID]) {
+			i.remove(t.ID)
+		}
+	}
+}
+
+func (i *index) add(doc search.Document) {
+	e := &entry{Document: doc, words: tokenize(doc.Content), hashtags: hashtagsOf(doc.Content)}
+	i.docs[doc.ID] = e
+	i.all = insert(i.all, e)
+	for _, w := range e.words {
+		i.words.add(w, e)
+	}
+	for _, tag := range e.hashtags {
+		i.hashtags.add(tag, e)
+	}
+	i.authors.add(doc.UserID, e)
+}
+
+func (i *index) remove(id string) {
+	e, ok := i.docs[id]
+	if !ok {
+		return
+	}
+	delete(i.docs, id)
+	i.all = remove(i.all, e)
+	for _, w := range e.words {
+		i.words.remove(w, e)
+	}
+	for _, tag := range e.hashtags {
+		i.hashtags.remove(tag, e)
+	}
+	i.authors.remove(e.UserID, e)
+}
+
+// documents returns the tweets oldest first, so that loading a snapshot
+// appends to the posting lists.
+func (i *index) documents() []search.Document {
+	docs := make([]search.Document, len(i.all))
+	for k, e := range i.all {
+		docs[k] = e.Document
+	}
+
+	return docs
+}
+
+// candidates returns the posting lists of the most selective required term,
+// or every tweet if the query only has exclusions and filters. An or
+// clause needs one list per alternative.
+func (i *index) candidates(query search.Query, clauses []clause) [][]*entry {
+	var best [][]*entry
+	bestSize := 0
+	consider := func(lists ...[]*entry) {
+		size := 0
+		for _, list := range lists {
+			size += len(list)
+		}
+		if best == nil || size < bestSize {
+			best, bestSize = lists, size
+		}
+	}
+
+	if query.AuthorID != "" {
+		consider(i.authors[query.AuthorID])
+	}
+	for _, tag := range query.Hashtags {
+		consider(i.hashtags[tag])
+	}
+	for _, c := range clauses {
+		if c.negated {
+			continue
+		}
+		lists := make([][]*entry, len(c.alternatives))
+		for k, alt := range c.alternatives {
+			lists[k] = i.words.smallest(alt)
+		}
+		consider(lists...)
+	}
+
+	if best == nil {
+		return [][]*entry{i.all}
+	}
+
+	return best
+}
+
+func (e *entry) matches(query search.Query, clauses []clause) bool {
+	if query.AuthorID != "" && e.UserID != query.AuthorID {
+		return false
+	}
+	if query.Language != "" && e.Language != query.Language {
+		return false
+	}
+	if query.Since != nil && e.CreatedAt.Before(*query.Since) {
+		return false
+	}
+	if query.Until != nil && !e.CreatedAt.Before(*query.Until) {
+		return false
+	}
+	for _, tag := range query.Hashtags {
+		if !slices.Contains(e.hashtags, tag) {
+			return false
+		}
+	}
+	for _, c := range clauses {
+		if c.matches(e.words) == c.negated {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p postings) add(term string, e *entry) {
+	p[term] = insert(p[term], e)
+}
+
+func (p postings) remove(term string, e *entry) {
+	p[term] = remove(p[term], e)
+	if len(p[term]) == 0 {
+		delete(p, term)
+	}
+}
+
+// smallest returns the shortest posting list among terms, a superset of
+// the tweets containing all of them.
+func (p postings) smallest(terms []string) []*entry {
+	best := p[terms[0]]
+	for _, t := range terms[1:] {
+		if len(p[t]) < len(best) {
+			best = p[t]
+		}
+	}
+	return best
+}
+
+// insert adds e to a list sorted by (created_at, id). New tweets are the
+// newest, so they are usually appended.
+func insert(list []*entry, e *entry) []*entry {
+	k, _ := slices.BinarySearchFunc(list, e, compareEntries)
+	return slices.Insert(list, k, e)
+}
+
+func remove(list []*entry, e *entry) []*entry {
+	k, found := slices.BinarySearchFunc(list, e, compareEntries)
+	if !found {
+		return list
+	}
+	return slices.Delete(list, k, k+1)
+}
+
+// seek returns the position of the first tweet of list that is not older
+// than (createdAt, id).
+func seek(list []*entry, createdAt time.Time, id string) int {
+	k, _ := slices.BinarySearchFunc(list, createdAt, func(e *entry, t time.Time) int {
+		if c := e.CreatedAt.Compare(t); c != 0 {
+			return c
+		}
+		return cmp.Compare(e.ID, id)
+	})
+	return k
+}
+
+// newest pops the newest tweet among the unread ones of lists, or nil once
+// every list is exhausted.
+func newest(lists [][]*entry, next []int) *entry {
+	best := -1
+	for k, list := range lists {
+		if next[k] == 0 {
+			continue
+		}
+		if best < 0 || compareEntries(list[next[k]-1], lists[best][next[best]-1]) > 0 {
+			best = k
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+
+	next[best]--
+	return lists[best][next[best]]
+}
+
+func compareEntries(a, b *entry) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
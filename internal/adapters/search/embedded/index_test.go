@@ -0,0 +1,132 @@
+package embedded_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/adapters/search/embedded"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func created(id, userID, content string, minutes int) search.Event {
+	return search.Event{Type: search.TweetCreated, Tweet: search.Document{
+		ID:        id,
+		UserID:    userID,
+		Content:   content,
+		Language:  "en",
+		CreatedAt: time.Date(2024, 1, 1, 12, minutes, 0, 0, time.UTC),
+	}}
+}
+
+func ids(hits []search.Hit) []string {
+	out := make([]string, len(hits))
+	for i, h := range hits {
+		out[i] = h.ID
+	}
+	return out
+}
+
+func Test_index_Search(t *testing.T) {
+	ctx := context.Background()
+	idx, err := embedded.Open(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { idx.Close() })
+
+	require.NoError(t, idx.Apply(ctx, []search.Event{
+		created("t1", "u1", "Learning Go generics #golang", 1),
+		created("t2", "u2", "Go is fun, generics are not", 2),
+		created("t3", "u1", "Rust or Go? #golang #rust", 3),
+		created("t4", "u3", "Café con leche", 4),
+	}))
+
+	since := time.Date(2024, 1, 1, 12, 2, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    search.Query
+		after    *search.Hit
+		limit    int
+		expected []string
+	}{
+		{name: "should match words ignoring case, newest first", query: search.Query{Text: "go"}, limit: 10, expected: []string{"t3", "t2", "t1"}},
+		{name: "should match every word", query: search.Query{Text: "go generics"}, limit: 10, expected: []string{"t2", "t1"}},
+		{name: "should match phrases in order", query: search.Query{Text: `"go generics"`}, limit: 10, expected: []string{"t1"}},
+		{name: "should match either side of or", query: search.Query{Text: "rust or café"}, limit: 10, expected: []string{"t4", "t3"}},
+		{name: "should return tweets matching several alternatives once", query: search.Query{Text: "go or generics"}, limit: 10, expected: []string{"t3", "t2", "t1"}},
+		{name: "should skip excluded words", query: search.Query{Text: "go -rust"}, limit: 10, expected: []string{"t2", "t1"}},
+		{name: "should filter by hashtag and author", query: search.Query{Hashtags: []string{"golang"}, AuthorID: "u1"}, limit: 10, expected: []string{"t3", "t1"}},
+		{name: "should filter by date", query: search.Query{Text: "go", Since: &since}, limit: 10, expected: []string{"t3", "t2"}},
+		{name: "should filter by language", query: search.Query{Text: "go", Language: "es"}, limit: 10, expected: []string{}},
+		{
+			name:     "should resume after the cursor",
+			query:    search.Query{Text: "go"},
+			after:    &search.Hit{ID: "t3", CreatedAt: time.Date(2024, 1, 1, 12, 3, 0, 0, time.UTC)},
+			limit:    1,
+			expected: []string{"t2"},
+		},
+		{
+			name:     "should resume after the cursor within the date range",
+			query:    search.Query{Until: &since},
+			after:    &search.Hit{ID: "t4", CreatedAt: time.Date(2024, 1, 1, 12, 4, 0, 0, time.UTC)},
+			limit:    10,
+			expected: []string{"t1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := idx.Search(ctx, tt.query, tt.after, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids(hits))
+		})
+	}
+}
+
+func Test_index_Apply(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	idx, err := embedded.Open(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Apply(ctx, []search.Event{
+		created("t1", "u1", "hello world", 1),
+		created("t2", "u2", "hello there", 2),
+		created("t3", "u3", "hello again", 3),
+		{Type: search.UserDeactivated, UserID: "u3"},
+		{Type: search.UserDeleted, UserID: "u2"},
+	}))
+
+	hits, err := idx.Search(ctx, search.Query{Text: "hello"}, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1"}, ids(hits))
+
+	// A reactivation sends the tweets of the user again.
+	require.NoError(t, idx.Apply(ctx, []search.Event{created("t3", "u3", "hello again", 3)}))
+	hits, err = idx.Search(ctx, search.Query{Text: "hello"}, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t3", "t1"}, ids(hits))
+	require.NoError(t, idx.Apply(ctx, []search.Event{{Type: search.UserDeactivated, UserID: "u3"}}))
+
+	// Reopening without closing replays the journal.
+	reopened, err := embedded.Open(dir)
+	require.NoError(t, err)
+	hits, err = reopened.Search(ctx, search.Query{Text: "hello"}, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1"}, ids(hits))
+
+	// Closing snapshots the index, including events applied after the reopen.
+	require.NoError(t, reopened.Apply(ctx, []search.Event{created("t4", "u3", "hello from the snapshot", 4)}))
+	require.NoError(t, reopened.Close())
+	reopened, err = embedded.Open(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { reopened.Close() })
+	hits, err = reopened.Search(ctx, search.Query{Text: "hello"}, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t4", "t1"}, ids(hits))
+
+	require.NoError(t, reopened.Reset(ctx))
+	hits, err = reopened.Search(ctx, search.Query{Text: "hello"}, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
@@ -0,0 +1,151 @@
+package embedded
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search"
+)
+
+const (
+	snapshotFile = "snapshot.gob"
+	journalFile  = "journal.jsonl"
+)
+
+// store persists the index as a gob snapshot of the documents and a
+// journal with one JSON event per line, applied on top of it.
+type store struct {
+	dir       string
+	file      *os.File
+	journaled int
+}
+
+func openStore(dir string) (*store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create search index directory: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, journalFile), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open search index journal: %w", err)
+	}
+
+	return &store{dir: dir, file: file}, nil
+}
+
+// load reads the snapshot and the events journaled after it. A torn last
+// line, left by a crash in the middle of a write, is ignored.
+func (s *store) load() ([]search.Document, []search.Event, error) {
+	var docs []search.Document
+	f, err := os.Open(filepath.Join(s.dir, snapshotFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, nil, fmt.Errorf("failed to open search index snapshot: %w", err)
+	default:
+		defer f.Close()
+		if err := gob.NewDecoder(f).Decode(&docs); err != nil {
+			return nil, nil, fmt.Errorf("failed to read search index snapshot: %w", err)
+		}
+	}
+
+	if _, err := s.file.Seek(0, 0); err != nil {
+		return nil, nil, fmt.Errorf("failed to read search index journal: %w", err)
+	}
+	var events []search.Event
+	var valid int64
+	scanner := bufio.NewScanner(s.file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e search.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			break
+		}
+		events = append(events, e)
+		valid += int64(len(scanner.Bytes())) + 1
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read search index journal: %w", err)
+	}
+	// Drop the torn line so that new events are not appended to it.
+	if err := s.file.Truncate(valid); err != nil {
+		return nil, nil, fmt.Errorf("failed to truncate search index journal: %w", err)
+	}
+	s.journaled = len(events)
+
+	return docs, events, nil
+}
+
+func (s *store) journal(events []search.Event) error {
+	w := bufio.NewWriter(s.file)
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to write search index journal: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write search index journal: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync search index journal: %w", err)
+	}
+	s.journaled += len(events)
+
+	return nil
+}
+
+// snapshot replaces the snapshot with docs and empties the journal. The
+// snapshot is written to a temporary file first, so a crash leaves either
+// the old one or the new one.
+func (s *store) snapshot(docs []search.Document) error {
+	tmp, err := os.CreateTemp(s.dir, snapshotFile+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create search index snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(docs); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write search index snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync search index snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write search index snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, snapshotFile)); err != nil {
+		return fmt.Errorf("failed to replace search index snapshot: %w", err)
+	}
+
+	return s.truncate()
+}
+
+func (s *store) reset() error {
+	if err := os.Remove(filepath.Join(s.dir, snapshotFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove search index snapshot: %w", err)
+	}
+
+	return s.truncate()
+}
+
+func (s *store) truncate() error {
+	if err := s.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate search index journal: %w", err)
+	}
+	s.journaled = 0
+
+	return nil
+}
+
+func (s *store) close() error {
+	return s.file.Close()
+}
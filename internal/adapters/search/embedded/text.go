@@ -0,0 +1,115 @@
+package embedded
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// hashtagPattern matches the hashtags of a tweet, like the Postgres index
+// does: a # not preceded by a letter, digit or underscore.
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])#([\p{L}\p{N}_]+)`)
+
+// clause is a required or, if negated, excluded part of a query. It matches
+// if any of its alternatives, a word or a phrase, appears in the tweet.
+type clause struct {
+	alternatives [][]string
+	negated      bool
+}
+
+// tokenize splits text into lowercased words of letters and digits, in
+// order.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func hashtagsOf(content string) []string {
+	var tags []string
+	for _, m := range hashtagPattern.FindAllStringSubmatch(content, -1) {
+		tag := strings.ToLower(m[1])
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// parseText reads the websearch_to_tsquery syntax used by Postgres: words
+// and "quoted phrases" are required, "or" between two of them makes either
+// enough and a leading - excludes one.
+func parseText(text string) []clause {
+	var clauses []clause
+	orNext := false
+	for _, term := range splitQuoted(text) {
+		negated := strings.HasPrefix(term, "-") && len(term) > 1
+		if negated {
+			term = term[1:]
+		}
+		if !negated && strings.EqualFold(term, "or") {
+			orNext = len(clauses) > 0
+			continue
+		}
+
+		words := tokenize(term)
+		if len(words) == 0 {
+			continue
+		}
+		if last := len(clauses) - 1; orNext && !negated && !clauses[last].negated {
+			clauses[last].alternatives = append(clauses[last].alternatives, words)
+		} else {
+			clauses = append(clauses, clause{alternatives: [][]string{words}, negated: negated})
+		}
+		orNext = false
+	}
+
+	return clauses
+}
+
+// splitQuoted splits text on spaces, except the ones between double quotes.
+func splitQuoted(text string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if unicode.IsSpace(r) && !quoted {
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+			continue
+		}
+		term.WriteRune(r)
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+
+	return terms
+}
+
+func (c clause) matches(words []string) bool {
+	for _, alt := range c.alternatives {
+		if containsSequence(words, alt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsSequence(words, seq []string) bool {
+	for i := 0; i+len(seq) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(seq)], seq) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package dm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+)
+
+var (
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrInvalidParticipants  = errors.New("invalid conversation participants")
+	ErrTooManyParticipants  = errors.New("too many conversation participants")
+	ErrNotAllowed           = errors.New("not allowed to message user")
+	ErrEmptyMessage         = errors.New("message is empty")
+	ErrMessageTooLong       = errors.New("message is too long")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+)
+
+const (
+	// MaxParticipants caps the members of a group conversation, creator
+	// included.
+	MaxParticipants  = 10
+	MaxMessageLength = 1000
+
+	DefaultLimit = 50
+	MaxLimit     = 100
+)
+
+type (
+	Conversation struct {
+		ID           string
+		IsGroup      bool
+		CreatedBy    string
+		Participants []Participant
+		CreatedAt    time.Time
+		// LastMessageAt is nil until the first message is sent.
+		LastMessageAt *time.Time
+		// UnreadCount is the number of messages from other participants sent
+		// after the caller's read marker.
+		UnreadCount int
+	}
+
+	// Participant is a member of a conversation. LastReadAt is its read
+	// marker: every message sent up to that instant has been read.
+	Participant struct {
+		UserID     string
+		Username   string
+		LastReadAt *time.Time
+	}
+
+	Message struct {
+		ID             string
+		ConversationID string
+		SenderID       string
+		Content        string
+		CreatedAt      time.Time
+	}
+
+	MessagePage struct {
+		Messages   []Message
+		NextCursor string
+	}
+
+	// Cursor points at the last message of a page. The next page starts
+	// strictly after it in (created_at, id) descending order.
+	Cursor struct {
+		CreatedAt time.Time
+		ID        string
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		FindByIDs(ctx context.Context, ids []string) ([]user.User, error)
+		IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+		GetBlockedAmong(ctx context.Context, userID string, otherIDs []string) ([]string, error)
+	}
+
+	//go:generate mockery --name=ConversationRepository --output=mocks --outpkg=mocks --filename=conversation_repository.go
+	ConversationRepository interface {
+		CreateConversation(ctx context.Context, conversation *Conversation) error
+		// FindDirectConversation returns the one-to-one conversation between
+		// both users, or ErrConversationNotFound.
+		FindDirectConversation(ctx context.Context, userID, otherID string) (*Conversation, error)
+		// GetConversation returns the conversation if userID takes part in
+		// it, or ErrConversationNotFound.
+		GetConversation(ctx context.Context, userID, id string) (*Conversation, error)
+		// GetConversations returns the conversations of userID, most recently
+		// active first.
+		GetConversations(ctx context.Context, userID string, limit int) ([]Conversation, error)
+		CreateMessage(ctx context.Context, message *Message) error
+		// GetMessages returns up to limit messages, newest first, strictly
+		// after the given cursor.
+		GetMessages(ctx context.Context, conversationID string, after *Cursor, limit int) ([]Message, error)
+		MarkAsRead(ctx context.Context, conversationID, userID string, at time.Time) error
+	}
+
+	// Publisher pushes new messages to the live stream of the participants.
+	//
+	//go:generate mockery --name=Publisher --output=mocks --outpkg=mocks --filename=publisher.go
+	Publisher interface {
+		PublishMessage(ctx context.Context, recipientIDs []string, message Message) error
+	}
+)
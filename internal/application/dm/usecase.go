@@ -0,0 +1,313 @@
+package dm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/cursor"
+)
+
+type usecase struct {
+	userFinder UserFinder
+	repo       ConversationRepository
+	publisher  Publisher
+}
+
+func NewDMUseCase(userFinder UserFinder, repo ConversationRepository, publisher Publisher) *usecase {
+	return &usecase{userFinder: userFinder, repo: repo, publisher: publisher}
+}
+
+// CreateConversation starts a conversation between userID and the given
+// participants. With a single participant the existing one-to-one
+// conversation is returned if there is one; created reports whether a new
+// conversation was stored.
+func (uc *usecase) CreateConversation(ctx context.Context, userID string, participantIDs []string) (conversation *Conversation, created bool, err error) {
+	if userID == "" {
+		return nil, false, user.ErrInvalidInput
+	}
+
+	others := make([]string, 0, len(participantIDs))
+	seen := map[string]bool{userID: true}
+	for _, id := range participantIDs {
+		if !seen[id] {
+			seen[id] = true
+			others = append(others, id)
+		}
+	}
+	if len(others) == 0 {
+		return nil, false, ErrInvalidParticipants
+	}
+	if len(others)+1 > MaxParticipants {
+		return nil, false, ErrTooManyParticipants
+	}
+
+	users, err := uc.userFinder.FindByIDs(ctx, append([]string{userID}, others...))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find participants: %w", err)
+	}
+	if len(users) != len(others)+1 {
+		return nil, false, user.ErrUserNotFound
+	}
+
+	if len(others) == 1 {
+		existing, err := uc.repo.FindDirectConversation(ctx, userID, others[0])
+		if err == nil {
+			return existing, false, nil
+		}
+		if !errors.Is(err, ErrConversationNotFound) {
+			return nil, false, fmt.Errorf("failed to find conversation: %w", err)
+		}
+	}
+
+	recipients := make([]user.User, 0, len(others))
+	participants := make([]Participant, 0, len(users))
+	for _, u := range users {
+		if u.ID != userID {
+			recipients = append(recipients, u)
+		}
+		participants = append(participants, Participant{UserID: u.ID, Username: u.Username})
+	}
+
+	if err := uc.checkAllowed(ctx, userID, recipients); err != nil {
+		return nil, false, err
+	}
+
+	conversation = &Conversation{
+		IsGroup:      len(others) > 1,
+		CreatedBy:    userID,
+		Participants: participants,
+	}
+	if err := uc.repo.CreateConversation(ctx, conversation); err != nil {
+		return nil, false, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	return conversation, true, nil
+}
+
+func (uc *usecase) GetConversation(ctx context.Context, userID, id string) (*Conversation, error) {
+	if userID == "" || id == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	conversation, err := uc.repo.GetConversation(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, ErrConversationNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+func (uc *usecase) GetConversations(ctx context.Context, userID string, limit int) ([]Conversation, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	if limit <= 0 || limit > MaxLimit {
+		limit = DefaultLimit
+	}
+
+	conversations, err := uc.repo.GetConversations(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversations: %w", err)
+	}
+
+	return conversations, nil
+}
+
+// SendMessage stores a message from userID and pushes it to the live stream
+// of every participant. In one-to-one conversations the permission rules are
+// checked again, so a block or a lost follow stops the conversation; in groups
+// only blocks are, so nobody receives messages from a user they blocked.
+func (uc *usecase) SendMessage(ctx context.Context, userID, conversationID, content string) (*Message, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(content) > MaxMessageLength {
+		return nil, ErrMessageTooLong
+	}
+
+	conversation, err := uc.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if conversation.IsGroup {
+		err = uc.checkGroupAllowed(ctx, userID, conversation)
+	} else {
+		err = uc.checkDirectAllowed(ctx, userID, conversation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Message{
+		ConversationID: conversation.ID,
+		SenderID:       userID,
+		Content:        content,
+	}
+	if err := uc.repo.CreateMessage(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	recipients := make([]string, len(conversation.Participants))
+	for i, p := range conversation.Participants {
+		recipients[i] = p.UserID
+	}
+	go uc.publishMessageAsync(twcontext.NewDetachedWithRequestID(ctx), recipients, *message)
+
+	return message, nil
+}
+
+// GetMessages returns a page of messages, newest first. cursor is the
+// NextCursor of the previous page, or empty for the first one.
+func (uc *usecase) GetMessages(ctx context.Context, userID, conversationID, cursor string, limit int) (*MessagePage, error) {
+	if limit <= 0 || limit > MaxLimit {
+		limit = DefaultLimit
+	}
+
+	var after *Cursor
+	if cursor != "" {
+		var err error
+		if after, err = decodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	conversation, err := uc.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one extra message to know whether there is a next page.
+	messages, err := uc.repo.GetMessages(ctx, conversation.ID, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	page := &MessagePage{Messages: messages}
+	if len(messages) > limit {
+		page.Messages = messages[:limit]
+		last := page.Messages[limit-1]
+		page.NextCursor = encodeCursor(Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+// MarkAsRead moves the read marker of userID to now.
+func (uc *usecase) MarkAsRead(ctx context.Context, userID, conversationID string) error {
+	conversation, err := uc.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.MarkAsRead(ctx, conversation.ID, userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark conversation as read: %w", err)
+	}
+
+	return nil
+}
+
+// checkAllowed reports ErrNotAllowed unless every recipient either follows
+// senderID or accepts messages from anyone, and none has a block with
+// senderID in either direction.
+func (uc *usecase) checkAllowed(ctx context.Context, senderID string, recipients []user.User) error {
+	ids := make([]string, len(recipients))
+	for i, r := range recipients {
+		ids[i] = r.ID
+	}
+
+	blocked, err := uc.userFinder.GetBlockedAmong(ctx, senderID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to check blocks: %w", err)
+	}
+	if len(blocked) > 0 {
+		return ErrNotAllowed
+	}
+
+	for _, r := range recipients {
+		if r.OpenDMs {
+			continue
+		}
+
+		follows, err := uc.userFinder.IsFollowing(ctx, r.ID, senderID)
+		if err != nil {
+			return fmt.Errorf("failed to check follow relationship: %w", err)
+		}
+		if !follows {
+			return ErrNotAllowed
+		}
+	}
+
+	return nil
+}
+
+func (uc *usecase) checkDirectAllowed(ctx context.Context, senderID string, conversation *Conversation) error {
+	var otherID string
+	for _, p := range conversation.Participants {
+		if p.UserID != senderID {
+			otherID = p.UserID
+		}
+	}
+
+	users, err := uc.userFinder.FindByIDs(ctx, []string{otherID})
+	if err != nil {
+		return fmt.Errorf("failed to find participants: %w", err)
+	}
+	if len(users) == 0 {
+		// The other participant deactivated the account.
+		return ErrNotAllowed
+	}
+
+	return uc.checkAllowed(ctx, senderID, users)
+}
+
+// checkGroupAllowed reports ErrNotAllowed if senderID has a block, in either
+// direction, with any other participant of the group.
+func (uc *usecase) checkGroupAllowed(ctx context.Context, senderID string, conversation *Conversation) error {
+	others := make([]string, 0, len(conversation.Participants))
+	for _, p := range conversation.Participants {
+		if p.UserID != senderID {
+			others = append(others, p.UserID)
+		}
+	}
+
+	blocked, err := uc.userFinder.GetBlockedAmong(ctx, senderID, others)
+	if err != nil {
+		return fmt.Errorf("failed to check blocks: %w", err)
+	}
+	if len(blocked) > 0 {
+		return ErrNotAllowed
+	}
+
+	return nil
+}
+
+func (uc *usecase) publishMessageAsync(ctx context.Context, recipientIDs []string, message Message) {
+	if err := uc.publisher.PublishMessage(ctx, recipientIDs, message); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("message_id", message.ID).Error("failed to publish message")
+	}
+}
+
+func encodeCursor(c Cursor) string {
+	return cursor.Encode(c.CreatedAt, c.ID)
+}
+
+func decodeCursor(s string) (*Cursor, error) {
+	createdAt, id, err := cursor.Decode(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
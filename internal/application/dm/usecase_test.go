@@ -0,0 +1,485 @@
+package dm_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	userFinder *mocks.UserFinder
+	repo       *mocks.ConversationRepository
+	publisher  *mocks.Publisher
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func cursorFor(createdAt time.Time, id string) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func Test_usecase_CreateConversation(t *testing.T) {
+	type input struct {
+		ctx            context.Context
+		userID         string
+		participantIDs []string
+	}
+
+	type output struct {
+		conversation *dm.Conversation
+		created      bool
+		err          error
+	}
+
+	alice := user.User{ID: "u1", Username: "alice"}
+	bob := user.User{ID: "u2", Username: "bob"}
+	carol := user.User{ID: "u3", Username: "carol", OpenDMs: true}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if the only participant is the caller",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1", participantIDs: []string{"u1"}},
+			output:       output{err: dm.ErrInvalidParticipants},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if the group is too large",
+			input: input{
+				ctx:            twcontext.NewTestContext(),
+				userID:         "u1",
+				participantIDs: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"},
+			},
+			output:       output{err: dm.ErrTooManyParticipants},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if a participant does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", participantIDs: []string{"u2"}},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u1", "u2"}).Return([]user.User{alice}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return the existing one-to-one conversation",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", participantIDs: []string{"u2", "u2"}},
+			output: output{conversation: &dm.Conversation{ID: "c1"}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u1", "u2"}).Return([]user.User{alice, bob}, nil)
+				d.repo.On("FindDirectConversation", in.ctx, "u1", "u2").Return(&dm.Conversation{ID: "c1"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if there is a block with the recipient",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", participantIDs: []string{"u3"}},
+			output: output{err: dm.ErrNotAllowed},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u1", "u3"}).Return([]user.User{alice, carol}, nil)
+				d.repo.On("FindDirectConversation", in.ctx, "u1", "u3").Return(nil, dm.ErrConversationNotFound)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u3"}).Return([]string{"u3"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the recipient does not follow the caller nor allow open DMs",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", participantIDs: []string{"u2"}},
+			output: output{err: dm.ErrNotAllowed},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u1", "u2"}).Return([]user.User{alice, bob}, nil)
+				d.repo.On("FindDirectConversation", in.ctx, "u1", "u2").Return(nil, dm.ErrConversationNotFound)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2"}).Return(nil, nil)
+				d.userFinder.On("IsFollowing", in.ctx, "u2", "u1").Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:  "should create a group conversation",
+			input: input{ctx: twcontext.NewTestContext(), userID: "u1", participantIDs: []string{"u2", "u3"}},
+			output: output{
+				conversation: &dm.Conversation{
+					ID:        "c1",
+					IsGroup:   true,
+					CreatedBy: "u1",
+					Participants: []dm.Participant{
+						{UserID: "u1", Username: "alice"},
+						{UserID: "u2", Username: "bob"},
+						{UserID: "u3", Username: "carol"},
+					},
+				},
+				created: true,
+			},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u1", "u2", "u3"}).Return([]user.User{alice, bob, carol}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2", "u3"}).Return(nil, nil)
+				d.userFinder.On("IsFollowing", in.ctx, "u2", "u1").Return(true, nil)
+				d.repo.On("CreateConversation", in.ctx, mock.AnythingOfType("*dm.Conversation")).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*dm.Conversation).ID = "c1"
+				})
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewConversationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := dm.NewDMUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.conversation, actual.created, actual.err = uc.CreateConversation(tt.input.ctx, tt.input.userID, tt.input.participantIDs)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_SendMessage(t *testing.T) {
+	type input struct {
+		ctx            context.Context
+		userID         string
+		conversationID string
+		content        string
+	}
+
+	type output struct {
+		message *dm.Message
+		err     error
+	}
+
+	direct := &dm.Conversation{
+		ID:           "c1",
+		Participants: []dm.Participant{{UserID: "u1"}, {UserID: "u2"}},
+	}
+	group := &dm.Conversation{
+		ID:           "c2",
+		IsGroup:      true,
+		Participants: []dm.Participant{{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}},
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if the message is blank",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1", content: "  \n "},
+			output:       output{err: dm.ErrEmptyMessage},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:         "should return error if the message is too long",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1", content: strings.Repeat("ñ", dm.MaxMessageLength+1)},
+			output:       output{err: dm.ErrMessageTooLong},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the caller is not a participant",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u9", conversationID: "c1", content: "hi"},
+			output: output{err: dm.ErrConversationNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(nil, dm.ErrConversationNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the other participant blocked the caller",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1", content: "hi"},
+			output: output{err: dm.ErrNotAllowed},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(direct, nil)
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u2"}).Return([]user.User{{ID: "u2", OpenDMs: true}}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2"}).Return([]string{"u2"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the other participant deactivated the account",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1", content: "hi"},
+			output: output{err: dm.ErrNotAllowed},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(direct, nil)
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u2"}).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if a group participant has a block with the caller",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c2", content: "hi"},
+			output: output{err: dm.ErrNotAllowed},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(group, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2", "u3"}).Return([]string{"u3"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if repo.CreateMessage returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c2", content: "hi"},
+			output: output{err: fmt.Errorf("failed to create message: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(group, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2", "u3"}).Return(nil, nil)
+				d.repo.On("CreateMessage", in.ctx, mock.Anything).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should store the trimmed message",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1", content: " hi bob "},
+			output: output{message: &dm.Message{ID: "m1", ConversationID: "c1", SenderID: "u1", Content: "hi bob"}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(direct, nil)
+				d.userFinder.On("FindByIDs", in.ctx, []string{"u2"}).Return([]user.User{{ID: "u2"}}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2"}).Return(nil, nil)
+				d.userFinder.On("IsFollowing", in.ctx, "u2", "u1").Return(true, nil)
+				d.repo.On("CreateMessage", in.ctx, &dm.Message{ConversationID: "c1", SenderID: "u1", Content: "hi bob"}).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*dm.Message).ID = "m1"
+				})
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewConversationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+
+			var done chan struct{}
+			// Synchronize with the goroutine
+			if tt.output.message != nil {
+				done = make(chan struct{})
+				d.publisher.On("PublishMessage", twcontext.NewDetachedWithRequestID(tt.input.ctx), []string{"u1", "u2"}, *tt.output.message).Return(nil).Run(func(args mock.Arguments) {
+					close(done)
+				})
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := dm.NewDMUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.message, actual.err = uc.SendMessage(tt.input.ctx, tt.input.userID, tt.input.conversationID, tt.input.content)
+
+			// Wait for the goroutine to finish
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(2 * time.Second):
+					t.Error("goroutine did not finish in time")
+				}
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_GetMessages(t *testing.T) {
+	type input struct {
+		ctx            context.Context
+		userID         string
+		conversationID string
+		cursor         string
+		limit          int
+	}
+
+	type output struct {
+		page *dm.MessagePage
+		err  error
+	}
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	messages := []dm.Message{
+		{ID: "m3", CreatedAt: now},
+		{ID: "m2", CreatedAt: now.Add(-time.Minute)},
+		{ID: "m1", CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if the cursor is malformed",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1", cursor: "not-a-cursor"},
+			output:       output{err: dm.ErrInvalidCursor},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return a next cursor if there are more messages",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1", limit: 2},
+			output: output{page: &dm.MessagePage{Messages: messages[:2], NextCursor: cursorFor(messages[1].CreatedAt, "m2")}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(&dm.Conversation{ID: "c1"}, nil)
+				d.repo.On("GetMessages", in.ctx, "c1", (*dm.Cursor)(nil), 3).Return(messages, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should continue after the cursor and stop at the last page",
+			input: input{
+				ctx:            twcontext.NewTestContext(),
+				userID:         "u1",
+				conversationID: "c1",
+				cursor:         cursorFor(messages[1].CreatedAt, "m2"),
+				limit:          2,
+			},
+			output: output{page: &dm.MessagePage{Messages: messages[2:]}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(&dm.Conversation{ID: "c1"}, nil)
+				d.repo.On("GetMessages", in.ctx, "c1", &dm.Cursor{CreatedAt: messages[1].CreatedAt, ID: "m2"}, 3).Return(messages[2:], nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewConversationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := dm.NewDMUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.page, actual.err = uc.GetMessages(tt.input.ctx, tt.input.userID, tt.input.conversationID, tt.input.cursor, tt.input.limit)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_MarkAsRead(t *testing.T) {
+	type input struct {
+		ctx            context.Context
+		userID         string
+		conversationID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if the caller is not a participant",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u9", conversationID: "c1"},
+			output: output{err: dm.ErrConversationNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(nil, dm.ErrConversationNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should move the read marker of the caller",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", conversationID: "c1"},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetConversation", in.ctx, in.userID, in.conversationID).Return(&dm.Conversation{ID: "c1"}, nil)
+				d.repo.On("MarkAsRead", in.ctx, "c1", "u1", mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewConversationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := dm.NewDMUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.err = uc.MarkAsRead(tt.input.ctx, tt.input.userID, tt.input.conversationID)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
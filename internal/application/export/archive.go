@@ -0,0 +1,224 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeArchive writes data as a zip archive with a single data.json holding
+// everything, one CSV file per list, for spreadsheet tools, and the uploaded
+// media files read from blobs.
+func writeArchive(ctx context.Context, w io.Writer, data *Data, blobs BlobStore) error {
+	zw := zip.NewWriter(w)
+
+	for i := range data.Media {
+		if err := writeMedia(ctx, zw, &data.Media[i], blobs); err != nil {
+			return err
+		}
+	}
+
+	if err := writeJSON(zw, "data.json", data); err != nil {
+		return err
+	}
+
+	tweets := [][]string{{"id", "content", "created_at"}}
+	for _, t := range data.Tweets {
+		tweets = append(tweets, []string{t.ID, t.Content, formatTime(t.CreatedAt)})
+	}
+
+	history := [][]string{{"username", "changed_at"}}
+	for _, h := range data.UsernameHistory {
+		history = append(history, []string{h.Username, formatTime(h.ChangedAt)})
+	}
+
+	requests := [][]string{{"requester_id", "target_id", "created_at"}}
+	for _, r := range data.FollowRequests {
+		requests = append(requests, []string{r.RequesterID, r.TargetID, formatTime(r.CreatedAt)})
+	}
+
+	scheduled := [][]string{{"id", "content", "publish_at", "failed_at", "created_at"}}
+	for _, t := range data.ScheduledTweets {
+		scheduled = append(scheduled, []string{t.ID, t.Content, formatTime(t.PublishAt), formatOptionalTime(t.FailedAt), formatTime(t.CreatedAt)})
+	}
+
+	drafts := [][]string{{"id", "part", "text", "created_at", "updated_at"}}
+	for _, d := range data.Drafts {
+		for i, part := range d.Parts {
+			drafts = append(drafts, []string{d.ID, strconv.Itoa(i + 1), part, formatTime(d.CreatedAt), formatTime(d.UpdatedAt)})
+		}
+	}
+
+	media := [][]string{{"id", "file", "content_type", "size", "width", "height", "alt_text", "sensitive", "created_at"}}
+	for _, m := range data.Media {
+		media = append(media, []string{
+			m.ID, m.File, m.ContentType, strconv.FormatInt(m.Size, 10), strconv.Itoa(m.Width), strconv.Itoa(m.Height),
+			m.AltText, strconv.FormatBool(m.Sensitive), formatTime(m.CreatedAt),
+		})
+	}
+
+	conversations := [][]string{{"id", "is_group", "participant_ids", "created_at"}}
+	for _, c := range data.Conversations {
+		conversations = append(conversations, []string{c.ID, strconv.FormatBool(c.IsGroup), strings.Join(c.ParticipantIDs, " "), formatTime(c.CreatedAt)})
+	}
+
+	messages := [][]string{{"id", "conversation_id", "sender_id", "content", "created_at"}}
+	for _, m := range data.Messages {
+		messages = append(messages, []string{m.ID, m.ConversationID, m.SenderID, m.Content, formatTime(m.CreatedAt)})
+	}
+
+	lists := [][]string{{"id", "name", "description", "private", "created_at"}}
+	members := [][]string{{"list_id", "user_id", "username", "created_at"}}
+	for _, l := range data.Lists {
+		lists = append(lists, []string{l.ID, l.Name, l.Description, strconv.FormatBool(l.Private), formatTime(l.CreatedAt)})
+		for _, m := range l.Members {
+			members = append(members, []string{l.ID, m.UserID, m.Username, formatTime(m.CreatedAt)})
+		}
+	}
+
+	notifications := [][]string{{"id", "type", "actor_id", "tweet_id", "created_at", "read_at"}}
+	for _, n := range data.Notifications {
+		notifications = append(notifications, []string{n.ID, n.Type, n.ActorID, n.TweetID, formatTime(n.CreatedAt), formatOptionalTime(n.ReadAt)})
+	}
+
+	webhooks := [][]string{{"id", "url", "events", "active", "disabled_at", "created_at"}}
+	for _, wh := range data.WebhookSubscriptions {
+		webhooks = append(webhooks, []string{wh.ID, wh.URL, strings.Join(wh.Events, " "), strconv.FormatBool(wh.Active), formatOptionalTime(wh.DisabledAt), formatTime(wh.CreatedAt)})
+	}
+
+	files := []struct {
+		name string
+		rows [][]string
+	}{
+		{"tweets.csv", tweets},
+		{"scheduled_tweets.csv", scheduled},
+		{"drafts.csv", drafts},
+		{"media.csv", media},
+		{"username_history.csv", history},
+		{"following.csv", relationRows(data.Following)},
+		{"followers.csv", relationRows(data.Followers)},
+		{"blocked.csv", relationRows(data.Blocked)},
+		{"follow_requests.csv", requests},
+		{"conversations.csv", conversations},
+		{"messages.csv", messages},
+		{"lists.csv", lists},
+		{"list_members.csv", members},
+		{"list_memberships.csv", listRefRows(data.ListMemberships)},
+		{"list_subscriptions.csv", listRefRows(data.ListSubscriptions)},
+		{"notifications.csv", notifications},
+		{"webhook_subscriptions.csv", webhooks},
+	}
+	for _, f := range files {
+		if err := writeCSV(zw, f.name, f.rows); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeMedia copies the file of m into the archive and sets m.File. Files
+// already gone from the blob store are skipped.
+func writeMedia(ctx context.Context, zw *zip.Writer, m *Media, blobs BlobStore) error {
+	r, err := blobs.Get(ctx, m.BlobKey)
+	if errors.Is(err, ErrBlobNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open media %s: %w", m.ID, err)
+	}
+	defer r.Close()
+
+	name := "media/" + m.ID + mediaExtension(m.ContentType)
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	m.File = name
+
+	return nil
+}
+
+func mediaExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	}
+	return ""
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func writeCSV(zw *zip.Writer, name string, rows [][]string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+
+	cw := csv.NewWriter(f)
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func relationRows(relations []Relation) [][]string {
+	rows := [][]string{{"user_id", "username", "created_at"}}
+	for _, r := range relations {
+		rows = append(rows, []string{r.UserID, r.Username, formatTime(r.CreatedAt)})
+	}
+
+	return rows
+}
+
+func listRefRows(refs []ListRef) [][]string {
+	rows := [][]string{{"list_id", "name", "owner_id", "created_at"}}
+	for _, r := range refs {
+		rows = append(rows, []string{r.ListID, r.Name, r.OwnerID, formatTime(r.CreatedAt)})
+	}
+
+	return rows
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,225 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrExportNotFound    = errors.New("export not found")
+	ErrExportInProgress  = errors.New("an export is already in progress")
+	ErrExportNotReady    = errors.New("export is not ready")
+	ErrExportLinkExpired = errors.New("export link has expired")
+	ErrBlobNotFound      = errors.New("blob not found")
+)
+
+// Status is the lifecycle state of an export job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusExpired   Status = "expired"
+)
+
+const (
+	// LinkTTL is how long the download link of a completed export stays
+	// valid.
+	LinkTTL = 48 * time.Hour
+
+	// PendingTimeout is how long a pending export blocks new requests. Builds
+	// run in-process, so a restart can leave a job pending forever.
+	PendingTimeout = time.Hour
+)
+
+type (
+	Job struct {
+		ID          string
+		UserID      string
+		Status      Status
+		Token       string
+		BlobKey     string
+		Error       string
+		CreatedAt   time.Time
+		CompletedAt *time.Time
+		ExpiresAt   *time.Time
+	}
+
+	// Data is everything stored about a user, as included in the archive.
+	Data struct {
+		Profile              Profile
+		UsernameHistory      []UsernameChange
+		Tweets               []Tweet
+		ScheduledTweets      []ScheduledTweet
+		Drafts               []Draft
+		Media                []Media
+		Following            []Relation
+		Followers            []Relation
+		Blocked              []Relation
+		FollowRequests       []FollowRequest
+		Conversations        []Conversation
+		Messages             []Message
+		Lists                []List
+		ListMemberships      []ListRef
+		ListSubscriptions    []ListRef
+		Notifications        []Notification
+		WebhookSubscriptions []WebhookSubscription
+	}
+
+	// Profile holds the account and its settings.
+	Profile struct {
+		ID                string    `json:"id"`
+		Username          string    `json:"username"`
+		DisplayName       string    `json:"display_name"`
+		Protected         bool      `json:"protected"`
+		OpenDMs           bool      `json:"open_dms"`
+		SensitiveMedia    string    `json:"sensitive_media"`
+		TimelineOwnTweets bool      `json:"timeline_own_tweets"`
+		PinnedTweetID     string    `json:"pinned_tweet_id,omitempty"`
+		CreatedAt         time.Time `json:"created_at"`
+		UpdatedAt         time.Time `json:"updated_at"`
+	}
+
+	UsernameChange struct {
+		Username  string    `json:"username"`
+		ChangedAt time.Time `json:"changed_at"`
+	}
+
+	Tweet struct {
+		ID        string    `json:"id"`
+		Content   string    `json:"content"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// Relation is another user linked to the exporting user, together with
+	// when the link was created.
+	Relation struct {
+		UserID    string    `json:"user_id"`
+		Username  string    `json:"username"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	FollowRequest struct {
+		RequesterID string    `json:"requester_id"`
+		TargetID    string    `json:"target_id"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	ScheduledTweet struct {
+		ID        string     `json:"id"`
+		Content   string     `json:"content"`
+		PublishAt time.Time  `json:"publish_at"`
+		FailedAt  *time.Time `json:"failed_at,omitempty"`
+		CreatedAt time.Time  `json:"created_at"`
+	}
+
+	Draft struct {
+		ID        string    `json:"id"`
+		Parts     []string  `json:"parts"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// Media is an uploaded file. File is its path inside the archive, empty
+	// when the file is no longer in the blob store.
+	Media struct {
+		ID          string    `json:"id"`
+		ContentType string    `json:"content_type"`
+		Size        int64     `json:"size"`
+		Width       int       `json:"width"`
+		Height      int       `json:"height"`
+		AltText     string    `json:"alt_text"`
+		Sensitive   bool      `json:"sensitive"`
+		File        string    `json:"file,omitempty"`
+		CreatedAt   time.Time `json:"created_at"`
+		BlobKey     string    `json:"-"`
+	}
+
+	Conversation struct {
+		ID             string    `json:"id"`
+		IsGroup        bool      `json:"is_group"`
+		ParticipantIDs []string  `json:"participant_ids"`
+		CreatedAt      time.Time `json:"created_at"`
+	}
+
+	// Message is a direct message sent or received by the user.
+	Message struct {
+		ID             string    `json:"id"`
+		ConversationID string    `json:"conversation_id"`
+		SenderID       string    `json:"sender_id"`
+		Content        string    `json:"content"`
+		CreatedAt      time.Time `json:"created_at"`
+	}
+
+	// List is a list owned by the user, with its members.
+	List struct {
+		ID          string     `json:"id"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Private     bool       `json:"private"`
+		Members     []Relation `json:"members"`
+		CreatedAt   time.Time  `json:"created_at"`
+	}
+
+	// ListRef is a list owned by someone else that the user is a member of
+	// or subscribed to, with when that happened.
+	ListRef struct {
+		ListID    string    `json:"list_id"`
+		Name      string    `json:"name"`
+		OwnerID   string    `json:"owner_id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	Notification struct {
+		ID        string     `json:"id"`
+		Type      string     `json:"type"`
+		ActorID   string     `json:"actor_id"`
+		TweetID   string     `json:"tweet_id,omitempty"`
+		CreatedAt time.Time  `json:"created_at"`
+		ReadAt    *time.Time `json:"read_at,omitempty"`
+	}
+
+	// WebhookSubscription leaves out the signing secret, which the archive
+	// must not carry.
+	WebhookSubscription struct {
+		ID         string     `json:"id"`
+		URL        string     `json:"url"`
+		Events     []string   `json:"events"`
+		Active     bool       `json:"active"`
+		DisabledAt *time.Time `json:"disabled_at,omitempty"`
+		CreatedAt  time.Time  `json:"created_at"`
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		ExistsByID(ctx context.Context, id string) (bool, error)
+	}
+
+	//go:generate mockery --name=JobRepository --output=mocks --outpkg=mocks --filename=job_repository.go
+	JobRepository interface {
+		CreateJob(ctx context.Context, job *Job) error
+		UpdateJob(ctx context.Context, job *Job) error
+		GetJob(ctx context.Context, userID, jobID string) (*Job, error)
+		GetJobByToken(ctx context.Context, token string) (*Job, error)
+		HasPendingJob(ctx context.Context, userID string, since time.Time) (bool, error)
+		GetExpiredJobs(ctx context.Context, now time.Time, limit int) ([]Job, error)
+	}
+
+	//go:generate mockery --name=DataSource --output=mocks --outpkg=mocks --filename=data_source.go
+	DataSource interface {
+		GetUserData(ctx context.Context, userID string) (*Data, error)
+	}
+
+	// BlobStore stores export artifacts. Implementations must return
+	// ErrBlobNotFound from Get when the key does not exist.
+	//
+	//go:generate mockery --name=BlobStore --output=mocks --outpkg=mocks --filename=blob_store.go
+	BlobStore interface {
+		Put(ctx context.Context, key string, r io.Reader) error
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+	}
+)
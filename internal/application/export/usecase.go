@@ -0,0 +1,193 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// purgeBatchSize bounds how many expired exports a single purge run removes.
+const purgeBatchSize = 100
+
+type exportUseCase struct {
+	finder UserFinder
+	jobs   JobRepository
+	data   DataSource
+	blobs  BlobStore
+}
+
+func NewExportUseCase(finder UserFinder, jobs JobRepository, data DataSource, blobs BlobStore) *exportUseCase {
+	return &exportUseCase{finder: finder, jobs: jobs, data: data, blobs: blobs}
+}
+
+// RequestExport creates an export job and builds the archive in the
+// background. Only one pending export per user is allowed at a time.
+func (uc *exportUseCase) RequestExport(ctx context.Context, userID string) (*Job, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	if exists, err := uc.finder.ExistsByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	} else if !exists {
+		return nil, user.ErrUserNotFound
+	}
+
+	pending, err := uc.jobs.HasPendingJob(ctx, userID, time.Now().Add(-PendingTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check pending exports: %w", err)
+	}
+	if pending {
+		return nil, ErrExportInProgress
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate export token: %w", err)
+	}
+
+	job := &Job{
+		UserID: userID,
+		Status: StatusPending,
+		Token:  token,
+	}
+	if err := uc.jobs.CreateJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	go uc.buildExportAsync(twcontext.NewDetachedWithRequestID(ctx), *job)
+
+	return job, nil
+}
+
+func (uc *exportUseCase) GetExport(ctx context.Context, userID, jobID string) (*Job, error) {
+	if userID == "" || jobID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	job, err := uc.jobs.GetJob(ctx, userID, jobID)
+	if err != nil {
+		if errors.Is(err, ErrExportNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+
+	return job, nil
+}
+
+// OpenExport returns the archive behind a download token. The caller must
+// close the returned reader.
+func (uc *exportUseCase) OpenExport(ctx context.Context, token string) (*Job, io.ReadCloser, error) {
+	if token == "" {
+		return nil, nil, user.ErrInvalidInput
+	}
+
+	job, err := uc.jobs.GetJobByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrExportNotFound) {
+			return nil, nil, ErrExportNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+
+	switch {
+	case job.Status == StatusExpired:
+		return nil, nil, ErrExportLinkExpired
+	case job.Status != StatusCompleted:
+		return nil, nil, ErrExportNotReady
+	case job.ExpiresAt != nil && time.Now().After(*job.ExpiresAt):
+		return nil, nil, ErrExportLinkExpired
+	}
+
+	archive, err := uc.blobs.Get(ctx, job.BlobKey)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil, nil, ErrExportLinkExpired
+		}
+		return nil, nil, fmt.Errorf("failed to open export archive: %w", err)
+	}
+
+	return job, archive, nil
+}
+
+// PurgeExpiredExports deletes the archives of exports whose link expired and
+// marks them as expired. It returns the number of purged exports.
+func (uc *exportUseCase) PurgeExpiredExports(ctx context.Context) (int, error) {
+	jobs, err := uc.jobs.GetExpiredJobs(ctx, time.Now(), purgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired exports: %w", err)
+	}
+
+	purged := 0
+	for _, job := range jobs {
+		if err := uc.blobs.Delete(ctx, job.BlobKey); err != nil && !errors.Is(err, ErrBlobNotFound) {
+			return purged, fmt.Errorf("failed to delete export archive %s: %w", job.BlobKey, err)
+		}
+
+		job.Status = StatusExpired
+		if err := uc.jobs.UpdateJob(ctx, &job); err != nil {
+			return purged, fmt.Errorf("failed to update export job %s: %w", job.ID, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (uc *exportUseCase) buildExportAsync(ctx context.Context, job Job) {
+	logger := twcontext.Logger(ctx).WithField("export_id", job.ID)
+
+	if err := uc.buildExport(ctx, &job); err != nil {
+		logger.WithError(err).Error("failed to build export")
+		job.Status = StatusFailed
+		job.Error = "failed to build export"
+	}
+
+	if err := uc.jobs.UpdateJob(ctx, &job); err != nil {
+		logger.WithError(err).Error("failed to update export job")
+	}
+}
+
+func (uc *exportUseCase) buildExport(ctx context.Context, job *Job) error {
+	data, err := uc.data.GetUserData(ctx, job.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user data: %w", err)
+	}
+
+	var archive bytes.Buffer
+	if err := writeArchive(ctx, &archive, data, uc.blobs); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.zip", job.UserID, job.ID)
+	if err := uc.blobs.Put(ctx, key, &archive); err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	completedAt := time.Now()
+	expiresAt := completedAt.Add(LinkTTL)
+	job.Status = StatusCompleted
+	job.BlobKey = key
+	job.CompletedAt = &completedAt
+	job.ExpiresAt = &expiresAt
+
+	return nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
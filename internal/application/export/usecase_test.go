@@ -0,0 +1,398 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/export/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	finder *mocks.UserFinder
+	jobs   *mocks.JobRepository
+	data   *mocks.DataSource
+	blobs  *mocks.BlobStore
+}
+
+func newDependencies(t *testing.T) *dependencies {
+	return &dependencies{
+		finder: mocks.NewUserFinder(t),
+		jobs:   mocks.NewJobRepository(t),
+		data:   mocks.NewDataSource(t),
+		blobs:  mocks.NewBlobStore(t),
+	}
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func Test_usecase_RequestExport(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+	}
+
+	type output struct {
+		job *export.Job
+		err error
+	}
+
+	newJob := mock.MatchedBy(func(job *export.Job) bool {
+		return job.Status == export.StatusPending && len(job.Token) == 64
+	})
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		async        bool
+		dependencies func(in input, d *dependencies, wg *sync.WaitGroup)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if userID is empty",
+			input:        input{ctx: twcontext.NewTestContext()},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if user does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1"},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.finder.On("ExistsByID", in.ctx, in.userID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if an export is already pending",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1"},
+			output: output{err: export.ErrExportInProgress},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.finder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.jobs.On("HasPendingJob", in.ctx, in.userID, mock.AnythingOfType("time.Time")).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if jobs.CreateJob returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1"},
+			output: output{err: fmt.Errorf("failed to create export job: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.finder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.jobs.On("HasPendingJob", in.ctx, in.userID, mock.AnythingOfType("time.Time")).Return(false, nil)
+				d.jobs.On("CreateJob", in.ctx, newJob).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should mark the job as failed if user data cannot be read",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1"},
+			output: output{job: &export.Job{ID: "j1", UserID: "u1", Status: export.StatusPending}},
+			async:  true,
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				ctx := twcontext.NewDetachedWithRequestID(in.ctx)
+				d.finder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.jobs.On("HasPendingJob", in.ctx, in.userID, mock.AnythingOfType("time.Time")).Return(false, nil)
+				d.jobs.On("CreateJob", in.ctx, newJob).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*export.Job).ID = "j1"
+				})
+				d.data.On("GetUserData", ctx, in.userID).Return(nil, assert.AnError)
+				d.jobs.On("UpdateJob", ctx, mock.MatchedBy(func(job *export.Job) bool {
+					return job.Status == export.StatusFailed && job.Error != ""
+				})).Return(nil).Run(func(args mock.Arguments) { wg.Done() })
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.NoError(t, actual.err)
+				assert.Equal(t, expected.job.ID, actual.job.ID)
+			},
+		},
+		{
+			name:   "should build and store the archive in the background",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1"},
+			output: output{job: &export.Job{ID: "j1", UserID: "u1", Status: export.StatusPending}},
+			async:  true,
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				ctx := twcontext.NewDetachedWithRequestID(in.ctx)
+				d.finder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.jobs.On("HasPendingJob", in.ctx, in.userID, mock.AnythingOfType("time.Time")).Return(false, nil)
+				d.jobs.On("CreateJob", in.ctx, newJob).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*export.Job).ID = "j1"
+				})
+				d.data.On("GetUserData", ctx, in.userID).Return(&export.Data{
+					Profile: export.Profile{ID: "u1", Username: "alice", DisplayName: "Alice", SensitiveMedia: "blur"},
+					Tweets:  []export.Tweet{{ID: "t1", Content: "hello, world"}},
+					Drafts:  []export.Draft{{ID: "d1", Parts: []string{"first", "second"}}},
+					Media: []export.Media{
+						{ID: "m1", ContentType: "image/png", BlobKey: "media/u1/m1"},
+						{ID: "m2", ContentType: "image/gif", BlobKey: "media/u1/m2"},
+					},
+					Messages: []export.Message{{ID: "dm1", ConversationID: "c1", SenderID: "u2", Content: "hi alice"}},
+					Lists:    []export.List{{ID: "l1", Name: "friends", Members: []export.Relation{{UserID: "u2", Username: "bob"}}}},
+					WebhookSubscriptions: []export.WebhookSubscription{
+						{ID: "w1", URL: "https://example.com/hook", Events: []string{"tweet.created", "user.followed"}},
+					},
+				}, nil)
+				d.blobs.On("Get", ctx, "media/u1/m1").Return(io.NopCloser(strings.NewReader("png bytes")), nil)
+				d.blobs.On("Get", ctx, "media/u1/m2").Return(nil, export.ErrBlobNotFound)
+				d.blobs.On("Put", ctx, "exports/u1/j1.zip", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					files := readArchive(t, args.Get(2).(io.Reader))
+					assert.Equal(t, []string{
+						"blocked.csv", "conversations.csv", "data.json", "drafts.csv", "follow_requests.csv",
+						"followers.csv", "following.csv", "list_members.csv", "list_memberships.csv",
+						"list_subscriptions.csv", "lists.csv", "media.csv", "media/m1.png", "messages.csv",
+						"notifications.csv", "scheduled_tweets.csv", "tweets.csv", "username_history.csv",
+						"webhook_subscriptions.csv",
+					}, sortedKeys(files))
+					assert.Contains(t, files["data.json"], `"username": "alice"`)
+					assert.Contains(t, files["data.json"], `"display_name": "Alice"`)
+					assert.Contains(t, files["data.json"], `"file": "media/m1.png"`)
+					assert.NotContains(t, files["data.json"], "media/u1/m1")
+					assert.Equal(t, "png bytes", files["media/m1.png"])
+					assert.Contains(t, files["tweets.csv"], `t1,"hello, world"`)
+					assert.Contains(t, files["drafts.csv"], "d1,2,second")
+					assert.Contains(t, files["messages.csv"], "dm1,c1,u2,hi alice")
+					assert.Contains(t, files["list_members.csv"], "l1,u2,bob")
+					assert.Contains(t, files["webhook_subscriptions.csv"], "w1,https://example.com/hook,tweet.created user.followed")
+				})
+				d.jobs.On("UpdateJob", ctx, mock.MatchedBy(func(job *export.Job) bool {
+					return job.Status == export.StatusCompleted &&
+						job.BlobKey == "exports/u1/j1.zip" &&
+						job.ExpiresAt != nil && job.ExpiresAt.Sub(*job.CompletedAt) == export.LinkTTL
+				})).Return(nil).Run(func(args mock.Arguments) { wg.Done() })
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.NoError(t, actual.err)
+				assert.Equal(t, expected.job.ID, actual.job.ID)
+				assert.Equal(t, expected.job.Status, actual.job.Status)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDependencies(t)
+			var wg sync.WaitGroup
+			if tt.async {
+				wg.Add(1)
+			}
+			tt.dependencies(tt.input, d, &wg)
+
+			uc := export.NewExportUseCase(d.finder, d.jobs, d.data, d.blobs)
+			var actual output
+			actual.job, actual.err = uc.RequestExport(tt.input.ctx, tt.input.userID)
+
+			// Wait for the goroutine to finish
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Error("goroutine did not finish in time")
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_OpenExport(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		token string
+	}
+
+	type output struct {
+		content string
+		err     error
+	}
+
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+	}{
+		{
+			name:         "should return error if token is empty",
+			input:        input{ctx: twcontext.NewTestContext()},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+		},
+		{
+			name:   "should return error if token is unknown",
+			input:  input{ctx: twcontext.NewTestContext(), token: "tk"},
+			output: output{err: export.ErrExportNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.jobs.On("GetJobByToken", in.ctx, in.token).Return(nil, export.ErrExportNotFound)
+			},
+		},
+		{
+			name:   "should return error if export is still pending",
+			input:  input{ctx: twcontext.NewTestContext(), token: "tk"},
+			output: output{err: export.ErrExportNotReady},
+			dependencies: func(in input, d *dependencies) {
+				d.jobs.On("GetJobByToken", in.ctx, in.token).Return(&export.Job{Status: export.StatusPending}, nil)
+			},
+		},
+		{
+			name:   "should return error if link has expired but was not purged yet",
+			input:  input{ctx: twcontext.NewTestContext(), token: "tk"},
+			output: output{err: export.ErrExportLinkExpired},
+			dependencies: func(in input, d *dependencies) {
+				d.jobs.On("GetJobByToken", in.ctx, in.token).Return(&export.Job{Status: export.StatusCompleted, ExpiresAt: &past}, nil)
+			},
+		},
+		{
+			name:   "should return error if export was purged",
+			input:  input{ctx: twcontext.NewTestContext(), token: "tk"},
+			output: output{err: export.ErrExportLinkExpired},
+			dependencies: func(in input, d *dependencies) {
+				d.jobs.On("GetJobByToken", in.ctx, in.token).Return(&export.Job{Status: export.StatusExpired}, nil)
+			},
+		},
+		{
+			name:   "should open the archive",
+			input:  input{ctx: twcontext.NewTestContext(), token: "tk"},
+			output: output{content: "zip"},
+			dependencies: func(in input, d *dependencies) {
+				d.jobs.On("GetJobByToken", in.ctx, in.token).Return(&export.Job{Status: export.StatusCompleted, BlobKey: "k", ExpiresAt: &future}, nil)
+				d.blobs.On("Get", in.ctx, "k").Return(io.NopCloser(strings.NewReader("zip")), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDependencies(t)
+			tt.dependencies(tt.input, d)
+
+			uc := export.NewExportUseCase(d.finder, d.jobs, d.data, d.blobs)
+			_, archive, err := uc.OpenExport(tt.input.ctx, tt.input.token)
+
+			assert.Equal(t, tt.output.err, err)
+			if archive != nil {
+				defer archive.Close()
+				content, _ := io.ReadAll(archive)
+				assert.Equal(t, tt.output.content, string(content))
+			}
+		})
+	}
+}
+
+func Test_usecase_PurgeExpiredExports(t *testing.T) {
+	type output struct {
+		purged int
+		err    error
+	}
+
+	now := mock.AnythingOfType("time.Time")
+
+	tests := []struct {
+		name         string
+		output       output
+		dependencies func(ctx context.Context, d *dependencies)
+	}{
+		{
+			name:   "should return error if jobs.GetExpiredJobs returns error",
+			output: output{err: fmt.Errorf("failed to get expired exports: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.jobs.On("GetExpiredJobs", ctx, now, 100).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:   "should stop if blobs.Delete fails",
+			output: output{err: fmt.Errorf("failed to delete export archive k1: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.jobs.On("GetExpiredJobs", ctx, now, 100).Return([]export.Job{{ID: "j1", BlobKey: "k1"}}, nil)
+				d.blobs.On("Delete", ctx, "k1").Return(assert.AnError)
+			},
+		},
+		{
+			name:   "should delete archives and mark jobs as expired, ignoring missing blobs",
+			output: output{purged: 2},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.jobs.On("GetExpiredJobs", ctx, now, 100).Return([]export.Job{{ID: "j1", BlobKey: "k1"}, {ID: "j2", BlobKey: "k2"}}, nil)
+				d.blobs.On("Delete", ctx, "k1").Return(nil)
+				d.blobs.On("Delete", ctx, "k2").Return(export.ErrBlobNotFound)
+				d.jobs.On("UpdateJob", ctx, &export.Job{ID: "j1", BlobKey: "k1", Status: export.StatusExpired}).Return(nil)
+				d.jobs.On("UpdateJob", ctx, &export.Job{ID: "j2", BlobKey: "k2", Status: export.StatusExpired}).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := twcontext.NewTestContext()
+			d := newDependencies(t)
+			tt.dependencies(ctx, d)
+
+			uc := export.NewExportUseCase(d.finder, d.jobs, d.data, d.blobs)
+			var actual output
+			actual.purged, actual.err = uc.PurgeExpiredExports(ctx)
+
+			assert.Equal(t, tt.output, actual)
+		})
+	}
+}
+
+func readArchive(t *testing.T, r io.Reader) map[string]string {
+	data, err := io.ReadAll(r)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	files := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	return files
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,71 @@
+package link
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/tweettext"
+)
+
+// Extract returns the links of content in order of appearance, as found
+// by tweettext.FindURLs.
+func Extract(content string) []Entity {
+	var entities []Entity
+	for _, span := range tweettext.FindURLs(content) {
+		normalized, err := Normalize(content[span[0]:span[1]])
+		if err != nil {
+			continue
+		}
+
+		start := utf8.RuneCountInString(content[:span[0]])
+		entities = append(entities, Entity{
+			URL:   normalized,
+			Start: start,
+			End:   start + utf8.RuneCountInString(content[span[0]:span[1]]),
+		})
+	}
+
+	return entities
+}
+
+// Normalize returns the canonical form of an http or https URL, so that
+// the same page shares a single preview: scheme and host are lowercased,
+// the default port and the fragment are dropped and an empty path becomes
+// "/". URLs with credentials are rejected.
+func Normalize(raw string) (string, error) {
+	if len(raw) > tweettext.MaxURLLength {
+		return "", ErrInvalidURL
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", ErrInvalidURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Opaque != "" || u.User != nil {
+		return "", ErrInvalidURL
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return "", ErrInvalidURL
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	port := u.Port()
+	if port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+
+	u.Host = host
+	if u.Path == "" {
+		u.Path = "/"
+		u.RawPath = ""
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String(), nil
+}
@@ -0,0 +1,83 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidURL = errors.New("invalid URL")
+	// ErrNoPreview is returned by a Fetcher when the page has nothing to
+	// show, e.g. it is not HTML.
+	ErrNoPreview = errors.New("no preview available")
+)
+
+const (
+	MaxTitleLength       = 300
+	MaxDescriptionLength = 1000
+
+	// PreviewTTL is how long a preview is reused before a new tweet with
+	// the same URL fetches it again.
+	PreviewTTL = 24 * time.Hour
+	// FailedPreviewTTL is the same for URLs whose fetch failed.
+	FailedPreviewTTL = time.Hour
+)
+
+// PreviewStatus is the outcome of the last fetch of a URL.
+type PreviewStatus string
+
+const (
+	PreviewPending PreviewStatus = "pending"
+	PreviewReady   PreviewStatus = "ready"
+	PreviewFailed  PreviewStatus = "failed"
+)
+
+type (
+	// Entity is a link found in a tweet. Start and End are the positions of
+	// the first code point of the URL and the one after the last. URL is
+	// the normalized form.
+	Entity struct {
+		URL   string
+		Start int
+		End   int
+	}
+
+	// Preview holds the Open Graph metadata of a URL. Previews are shared
+	// by every tweet linking to the URL.
+	Preview struct {
+		URL         string
+		Status      PreviewStatus
+		Title       string
+		Description string
+		ImageURL    string
+		FetchedAt   *time.Time
+	}
+
+	// Fetcher reads the metadata of the page at url. It fails with
+	// ErrNoPreview when the page has none.
+	//
+	//go:generate mockery --name=Fetcher --output=mocks --outpkg=mocks --filename=fetcher.go
+	Fetcher interface {
+		Fetch(ctx context.Context, url string) (*Preview, error)
+	}
+
+	// PreviewRepository caches the previews by URL and queues the URLs to
+	// fetch.
+	//
+	//go:generate mockery --name=PreviewRepository --output=mocks --outpkg=mocks --filename=preview_repository.go
+	PreviewRepository interface {
+		// QueuePreviews queues the URLs never fetched, and the ones whose
+		// last fetch succeeded before readyBefore or failed before
+		// failedBefore. URLs already queued are left untouched.
+		QueuePreviews(ctx context.Context, urls []string, readyBefore, failedBefore time.Time) error
+		// ClaimQueuedPreviews hides the queued URLs from other claims for
+		// the lease and returns them.
+		ClaimQueuedPreviews(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]string, error)
+		// SavePreview stores the result of a fetch and removes the URL from
+		// the queue.
+		SavePreview(ctx context.Context, preview *Preview) error
+		// GetPreviews returns the ready previews of the given URLs.
+		GetPreviews(ctx context.Context, urls []string) ([]Preview, error)
+	}
+)
@@ -0,0 +1,117 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/tweettext"
+)
+
+const (
+	// fetchBatchSize bounds how many URLs a single fetch run handles.
+	fetchBatchSize = 20
+	// claimLease is how long claimed URLs stay hidden from other runs. It
+	// must exceed the time needed to fetch a whole batch.
+	claimLease = 10 * time.Minute
+)
+
+type usecase struct {
+	previews PreviewRepository
+	fetcher  Fetcher
+}
+
+func NewLinkUseCase(previews PreviewRepository, fetcher Fetcher) *usecase {
+	return &usecase{
+		previews: previews,
+		fetcher:  fetcher,
+	}
+}
+
+// RequestPreviews queues the URLs whose preview is missing or stale. They
+// are fetched in the background by FetchQueuedPreviews.
+func (uc *usecase) RequestPreviews(ctx context.Context, urls []string) error {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if err := uc.previews.QueuePreviews(ctx, urls, now.Add(-PreviewTTL), now.Add(-FailedPreviewTTL)); err != nil {
+		return fmt.Errorf("failed to queue link previews: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreviews returns the ready previews of the given URLs, by URL.
+func (uc *usecase) GetPreviews(ctx context.Context, urls []string) (map[string]Preview, error) {
+	previews, err := uc.previews.GetPreviews(ctx, urls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link previews: %w", err)
+	}
+
+	byURL := make(map[string]Preview, len(previews))
+	for _, p := range previews {
+		byURL[p.URL] = p
+	}
+
+	return byURL, nil
+}
+
+// FetchQueuedPreviews fetches the queued URLs and returns how many were
+// fetched. A failed fetch is stored as such, so that the URL is not retried
+// until FailedPreviewTTL has passed.
+func (uc *usecase) FetchQueuedPreviews(ctx context.Context) (int, error) {
+	urls, err := uc.previews.ClaimQueuedPreviews(ctx, time.Now(), claimLease, fetchBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim link previews: %w", err)
+	}
+
+	for i, url := range urls {
+		preview := uc.fetch(ctx, url)
+		if err := uc.previews.SavePreview(ctx, preview); err != nil {
+			return i, fmt.Errorf("failed to save link preview: %w", err)
+		}
+	}
+
+	return len(urls), nil
+}
+
+func (uc *usecase) fetch(ctx context.Context, url string) *Preview {
+	now := time.Now()
+
+	preview, err := uc.fetcher.Fetch(ctx, url)
+	if err != nil {
+		if !errors.Is(err, ErrNoPreview) {
+			twcontext.Logger(ctx).WithError(err).WithField("url", url).Warn("failed to fetch link preview")
+		}
+		return &Preview{URL: url, Status: PreviewFailed, FetchedAt: &now}
+	}
+
+	imageURL := preview.ImageURL
+	if len(imageURL) > tweettext.MaxURLLength {
+		imageURL = ""
+	}
+
+	return &Preview{
+		URL:         url,
+		Status:      PreviewReady,
+		Title:       truncate(strings.TrimSpace(preview.Title), MaxTitleLength),
+		Description: truncate(strings.TrimSpace(preview.Description), MaxDescriptionLength),
+		ImageURL:    imageURL,
+		FetchedAt:   &now,
+	}
+}
+
+// truncate cuts s to at most n characters.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,188 @@
+package link_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	previews *mocks.PreviewRepository
+	fetcher  *mocks.Fetcher
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func Test_usecase_RequestPreviews(t *testing.T) {
+	type input struct {
+		ctx  context.Context
+		urls []string
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		err          error
+		dependencies func(in input, d *dependencies)
+	}{
+		{
+			name:         "should do nothing without URLs",
+			input:        input{ctx: twcontext.NewTestContext()},
+			dependencies: func(in input, d *dependencies) {},
+		},
+		{
+			name:  "should queue the URLs with the TTLs",
+			input: input{ctx: twcontext.NewTestContext(), urls: []string{"https://a.com/"}},
+			dependencies: func(in input, d *dependencies) {
+				d.previews.On("QueuePreviews", in.ctx, in.urls,
+					mock.MatchedBy(func(readyBefore time.Time) bool {
+						return time.Since(readyBefore) >= link.PreviewTTL
+					}),
+					mock.MatchedBy(func(failedBefore time.Time) bool {
+						return time.Since(failedBefore) >= link.FailedPreviewTTL && time.Since(failedBefore) < link.PreviewTTL
+					}),
+				).Return(nil)
+			},
+		},
+		{
+			name:  "should return error if previews.QueuePreviews returns error",
+			input: input{ctx: twcontext.NewTestContext(), urls: []string{"https://a.com/"}},
+			err:   fmt.Errorf("failed to queue link previews: %w", assert.AnError),
+			dependencies: func(in input, d *dependencies) {
+				d.previews.On("QueuePreviews", in.ctx, in.urls, mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				previews: mocks.NewPreviewRepository(t),
+				fetcher:  mocks.NewFetcher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := link.NewLinkUseCase(d.previews, d.fetcher)
+			err := uc.RequestPreviews(tt.input.ctx, tt.input.urls)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_usecase_FetchQueuedPreviews(t *testing.T) {
+	type output struct {
+		fetched int
+		err     error
+	}
+
+	isPreview := func(expected link.Preview) any {
+		return mock.MatchedBy(func(p *link.Preview) bool {
+			actual := *p
+			actual.FetchedAt = nil
+			return p.FetchedAt != nil && assert.ObjectsAreEqual(expected, actual)
+		})
+	}
+
+	tests := []struct {
+		name         string
+		output       output
+		dependencies func(ctx context.Context, d *dependencies)
+	}{
+		{
+			name:   "should do nothing if the queue is empty",
+			output: output{fetched: 0},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.previews.On("ClaimQueuedPreviews", ctx, mock.Anything, mock.Anything, mock.Anything).Return([]string{}, nil)
+			},
+		},
+		{
+			name:   "should save ready and failed previews",
+			output: output{fetched: 3},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.previews.On("ClaimQueuedPreviews", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return([]string{"https://a.com/", "https://b.com/", "https://c.com/"}, nil)
+				d.fetcher.On("Fetch", ctx, "https://a.com/").Return(&link.Preview{
+					Title:       "  A  ",
+					Description: strings.Repeat("d", link.MaxDescriptionLength+1),
+					ImageURL:    "https://a.com/a.png",
+				}, nil)
+				d.fetcher.On("Fetch", ctx, "https://b.com/").Return(nil, link.ErrNoPreview)
+				d.fetcher.On("Fetch", ctx, "https://c.com/").Return(nil, assert.AnError)
+				d.previews.On("SavePreview", ctx, isPreview(link.Preview{
+					URL:         "https://a.com/",
+					Status:      link.PreviewReady,
+					Title:       "A",
+					Description: strings.Repeat("d", link.MaxDescriptionLength),
+					ImageURL:    "https://a.com/a.png",
+				})).Return(nil)
+				d.previews.On("SavePreview", ctx, isPreview(link.Preview{URL: "https://b.com/", Status: link.PreviewFailed})).Return(nil)
+				d.previews.On("SavePreview", ctx, isPreview(link.Preview{URL: "https://c.com/", Status: link.PreviewFailed})).Return(nil)
+			},
+		},
+		{
+			name:   "should return error if previews.ClaimQueuedPreviews returns error",
+			output: output{err: fmt.Errorf("failed to claim link previews: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.previews.On("ClaimQueuedPreviews", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:   "should stop if previews.SavePreview returns error",
+			output: output{fetched: 0, err: fmt.Errorf("failed to save link preview: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.previews.On("ClaimQueuedPreviews", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return([]string{"https://a.com/", "https://b.com/"}, nil)
+				d.fetcher.On("Fetch", ctx, "https://a.com/").Return(nil, link.ErrNoPreview)
+				d.previews.On("SavePreview", ctx, mock.Anything).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := twcontext.NewTestContext()
+			d := &dependencies{
+				previews: mocks.NewPreviewRepository(t),
+				fetcher:  mocks.NewFetcher(t),
+			}
+			tt.dependencies(ctx, d)
+
+			uc := link.NewLinkUseCase(d.previews, d.fetcher)
+			var actual output
+			actual.fetched, actual.err = uc.FetchQueuedPreviews(ctx)
+			assert.Equal(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_GetPreviews(t *testing.T) {
+	ctx := twcontext.NewTestContext()
+	urls := []string{"https://a.com/", "https://b.com/"}
+
+	t.Run("should index the previews by URL", func(t *testing.T) {
+		previews := mocks.NewPreviewRepository(t)
+		previews.On("GetPreviews", ctx, urls).Return([]link.Preview{{URL: "https://a.com/", Title: "A"}}, nil)
+
+		actual, err := link.NewLinkUseCase(previews, mocks.NewFetcher(t)).GetPreviews(ctx, urls)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]link.Preview{"https://a.com/": {URL: "https://a.com/", Title: "A"}}, actual)
+	})
+
+	t.Run("should return error if previews.GetPreviews returns error", func(t *testing.T) {
+		previews := mocks.NewPreviewRepository(t)
+		previews.On("GetPreviews", ctx, urls).Return(nil, assert.AnError)
+
+		_, err := link.NewLinkUseCase(previews, mocks.NewFetcher(t)).GetPreviews(ctx, urls)
+
+		assert.Equal(t, fmt.Errorf("failed to get link previews: %w", assert.AnError), err)
+	})
+}
@@ -0,0 +1,110 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+)
+
+var (
+	ErrListNotFound           = errors.New("list not found")
+	ErrNotListOwner           = errors.New("not the owner of the list")
+	ErrInvalidName            = errors.New("invalid list name")
+	ErrDescriptionTooLong     = errors.New("list description is too long")
+	ErrTooManyLists           = errors.New("too many lists")
+	ErrTooManyMembers         = errors.New("too many list members")
+	ErrAlreadyMember          = errors.New("already a list member")
+	ErrNotMember              = errors.New("not a list member")
+	ErrCannotSubscribeOwnList = errors.New("cannot subscribe to own list")
+	ErrAlreadySubscribed      = errors.New("already subscribed")
+	ErrNotSubscribed          = errors.New("not subscribed")
+)
+
+const (
+	MaxNameLength        = 25
+	MaxDescriptionLength = 100
+	// MaxLists caps the lists a user can own.
+	MaxLists = 100
+	// MaxMembers caps the members of a list, so that its timeline can be
+	// read with a single query.
+	MaxMembers = 500
+)
+
+type (
+	// List is a curated timeline of the tweets of its members. Private
+	// lists are only visible to their owner.
+	List struct {
+		ID          string
+		OwnerID     string
+		Name        string
+		Description string
+		Private     bool
+		// MemberCount and SubscriberCount are set on reads.
+		MemberCount     int
+		SubscriberCount int
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
+	}
+
+	// ListUpdate holds the list fields that can be changed after creation.
+	// Nil fields are left untouched.
+	ListUpdate struct {
+		Name        *string
+		Description *string
+		Private     *bool
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		ExistsByID(ctx context.Context, id string) (bool, error)
+		FindByID(ctx context.Context, id string) (*user.User, error)
+		IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+		HasBlockBetween(ctx context.Context, userID, otherID string) (bool, error)
+	}
+
+	//go:generate mockery --name=ListRepository --output=mocks --outpkg=mocks --filename=list_repository.go
+	ListRepository interface {
+		CreateList(ctx context.Context, list *List) error
+		CountLists(ctx context.Context, ownerID string) (int, error)
+		// GetList fails with ErrListNotFound if the list does not exist.
+		GetList(ctx context.Context, id string) (*List, error)
+		// GetLists returns the lists owned by ownerID, newest first, skipping
+		// private ones unless includePrivate is set.
+		GetLists(ctx context.Context, ownerID string, includePrivate bool) ([]List, error)
+		// GetSubscribedLists returns the lists userID subscribes to, most
+		// recent subscription first, skipping the ones made private since.
+		GetSubscribedLists(ctx context.Context, userID string) ([]List, error)
+		UpdateList(ctx context.Context, id string, update ListUpdate) error
+		DeleteList(ctx context.Context, id string) error
+		// AddMember fails with ErrAlreadyMember if userID is a member.
+		AddMember(ctx context.Context, listID, userID string) error
+		// RemoveMember fails with ErrNotMember if userID is not a member.
+		RemoveMember(ctx context.Context, listID, userID string) error
+		// GetMembers returns the members of the list, most recently added
+		// first.
+		GetMembers(ctx context.Context, listID string) ([]user.User, error)
+		GetMemberIDs(ctx context.Context, listID string) ([]string, error)
+		// GetListIDsByMember returns the lists userID is a member of.
+		GetListIDsByMember(ctx context.Context, userID string) ([]string, error)
+		// Subscribe fails with ErrAlreadySubscribed if userID is subscribed.
+		Subscribe(ctx context.Context, listID, userID string) error
+		// Unsubscribe fails with ErrNotSubscribed if userID is not
+		// subscribed.
+		Unsubscribe(ctx context.Context, listID, userID string) error
+	}
+
+	// TimelineCache drops the cached timeline of a list when its members
+	// change.
+	//
+	//go:generate mockery --name=TimelineCache --output=mocks --outpkg=mocks --filename=timeline_cache.go
+	TimelineCache interface {
+		InvalidateListTimeline(ctx context.Context, listID string) error
+	}
+)
+
+// VisibleTo tells whether userID can see the list.
+func (l *List) VisibleTo(userID string) bool {
+	return !l.Private || l.OwnerID == userID
+}
@@ -0,0 +1,315 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type usecase struct {
+	userFinder UserFinder
+	repo       ListRepository
+	cache      TimelineCache
+}
+
+func NewListUseCase(userFinder UserFinder, repo ListRepository, cache TimelineCache) *usecase {
+	return &usecase{userFinder: userFinder, repo: repo, cache: cache}
+}
+
+func (uc *usecase) CreateList(ctx context.Context, list *List) error {
+	name, description, err := validate(list.Name, list.Description)
+	if err != nil {
+		return err
+	}
+	list.Name, list.Description = name, description
+
+	if exist, err := uc.userFinder.ExistsByID(ctx, list.OwnerID); err != nil {
+		return fmt.Errorf("failed to check user ID: %w", err)
+	} else if !exist {
+		return user.ErrUserNotFound
+	}
+
+	count, err := uc.repo.CountLists(ctx, list.OwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to count lists: %w", err)
+	}
+	if count >= MaxLists {
+		return ErrTooManyLists
+	}
+
+	if err := uc.repo.CreateList(ctx, list); err != nil {
+		return fmt.Errorf("failed to create list: %w", err)
+	}
+
+	return nil
+}
+
+// GetList returns the list if viewerID can see it. Private lists of other
+// users and lists of users with a block with viewerID in either direction
+// are reported as not found.
+func (uc *usecase) GetList(ctx context.Context, viewerID, id string) (*List, error) {
+	list, err := uc.repo.GetList(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+	if !list.VisibleTo(viewerID) {
+		return nil, ErrListNotFound
+	}
+
+	if list.OwnerID != viewerID {
+		blocked, err := uc.userFinder.HasBlockBetween(ctx, viewerID, list.OwnerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check block: %w", err)
+		}
+		if blocked {
+			return nil, ErrListNotFound
+		}
+	}
+
+	return list, nil
+}
+
+// GetUserLists returns the lists owned by ownerID that viewerID can see.
+func (uc *usecase) GetUserLists(ctx context.Context, viewerID, ownerID string) ([]List, error) {
+	if exist, err := uc.userFinder.ExistsByID(ctx, ownerID); err != nil {
+		return nil, fmt.Errorf("failed to check user ID: %w", err)
+	} else if !exist {
+		return nil, user.ErrUserNotFound
+	}
+
+	if viewerID != ownerID {
+		blocked, err := uc.userFinder.HasBlockBetween(ctx, viewerID, ownerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check block: %w", err)
+		}
+		if blocked {
+			return nil, user.ErrUserBlocked
+		}
+	}
+
+	lists, err := uc.repo.GetLists(ctx, ownerID, viewerID == ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+func (uc *usecase) GetSubscribedLists(ctx context.Context, userID string) ([]List, error) {
+	lists, err := uc.repo.GetSubscribedLists(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscribed lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+func (uc *usecase) UpdateList(ctx context.Context, userID, id string, update ListUpdate) (*List, error) {
+	list, err := uc.ownedList(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, description := list.Name, list.Description
+	if update.Name != nil {
+		name = *update.Name
+	}
+	if update.Description != nil {
+		description = *update.Description
+	}
+	name, description, err = validate(name, description)
+	if err != nil {
+		return nil, err
+	}
+	if update.Name != nil {
+		update.Name = &name
+	}
+	if update.Description != nil {
+		update.Description = &description
+	}
+
+	if err := uc.repo.UpdateList(ctx, id, update); err != nil {
+		return nil, fmt.Errorf("failed to update list: %w", err)
+	}
+
+	list, err = uc.repo.GetList(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+
+	return list, nil
+}
+
+func (uc *usecase) DeleteList(ctx context.Context, userID, id string) error {
+	if _, err := uc.ownedList(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteList(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete list: %w", err)
+	}
+
+	uc.invalidateTimeline(ctx, id)
+
+	return nil
+}
+
+// AddMember adds memberID to a list of userID. Like following, it is not
+// allowed with a block between both users in either direction, and a
+// protected account can only be added by its followers.
+func (uc *usecase) AddMember(ctx context.Context, userID, listID, memberID string) error {
+	list, err := uc.ownedList(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+	if list.MemberCount >= MaxMembers {
+		return ErrTooManyMembers
+	}
+
+	member, err := uc.userFinder.FindByID(ctx, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to find member: %w", err)
+	}
+
+	if memberID != userID {
+		blocked, err := uc.userFinder.HasBlockBetween(ctx, userID, memberID)
+		if err != nil {
+			return fmt.Errorf("failed to check block: %w", err)
+		}
+		if blocked {
+			return user.ErrUserBlocked
+		}
+
+		if member.Protected {
+			following, err := uc.userFinder.IsFollowing(ctx, userID, memberID)
+			if err != nil {
+				return fmt.Errorf("error checking follow relationship: %w", err)
+			}
+			if !following {
+				return user.ErrProtectedAccount
+			}
+		}
+	}
+
+	if err := uc.repo.AddMember(ctx, listID, memberID); err != nil {
+		return fmt.Errorf("failed to add list member: %w", err)
+	}
+
+	uc.invalidateTimeline(ctx, listID)
+
+	return nil
+}
+
+func (uc *usecase) RemoveMember(ctx context.Context, userID, listID, memberID string) error {
+	if _, err := uc.ownedList(ctx, userID, listID); err != nil {
+		return err
+	}
+
+	if err := uc.repo.RemoveMember(ctx, listID, memberID); err != nil {
+		return fmt.Errorf("failed to remove list member: %w", err)
+	}
+
+	uc.invalidateTimeline(ctx, listID)
+
+	return nil
+}
+
+func (uc *usecase) GetMembers(ctx context.Context, viewerID, listID string) ([]user.User, error) {
+	if _, err := uc.GetList(ctx, viewerID, listID); err != nil {
+		return nil, err
+	}
+
+	members, err := uc.repo.GetMembers(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list members: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetMemberIDs and GetListIDsByMember give the tweet use case what it needs
+// to build and invalidate list timelines.
+func (uc *usecase) GetMemberIDs(ctx context.Context, listID string) ([]string, error) {
+	ids, err := uc.repo.GetMemberIDs(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list members: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (uc *usecase) GetListIDsByMember(ctx context.Context, userID string) ([]string, error) {
+	ids, err := uc.repo.GetListIDsByMember(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists of member: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (uc *usecase) Subscribe(ctx context.Context, userID, listID string) error {
+	list, err := uc.GetList(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+	if list.OwnerID == userID {
+		return ErrCannotSubscribeOwnList
+	}
+
+	if err := uc.repo.Subscribe(ctx, listID, userID); err != nil {
+		return fmt.Errorf("failed to subscribe to list: %w", err)
+	}
+
+	return nil
+}
+
+// Unsubscribe does not check the visibility of the list, so that a list
+// made private can still be left.
+func (uc *usecase) Unsubscribe(ctx context.Context, userID, listID string) error {
+	if err := uc.repo.Unsubscribe(ctx, listID, userID); err != nil {
+		return fmt.Errorf("failed to unsubscribe from list: %w", err)
+	}
+
+	return nil
+}
+
+// ownedList returns the list if userID owns it. Lists userID cannot see are
+// reported as not found.
+func (uc *usecase) ownedList(ctx context.Context, userID, id string) (*List, error) {
+	list, err := uc.GetList(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.OwnerID != userID {
+		return nil, ErrNotListOwner
+	}
+
+	return list, nil
+}
+
+// invalidateTimeline logs the failure instead of returning it: the change is
+// already stored and the cached timeline expires on its own.
+func (uc *usecase) invalidateTimeline(ctx context.Context, listID string) {
+	if err := uc.cache.InvalidateListTimeline(ctx, listID); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("list_id", listID).Error("failed to invalidate list timeline")
+	}
+}
+
+// validate trims the name and description and checks their length.
+func validate(name, description string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", "", ErrInvalidName
+	}
+
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return "", "", ErrDescriptionTooLong
+	}
+
+	return name, description, nil
+}
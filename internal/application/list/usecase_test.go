@@ -0,0 +1,454 @@
+package list_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+type dependencies struct {
+	userFinder *mocks.UserFinder
+	repo       *mocks.ListRepository
+	cache      *mocks.TimelineCache
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func newDependencies(t *testing.T) *dependencies {
+	return &dependencies{
+		userFinder: mocks.NewUserFinder(t),
+		repo:       mocks.NewListRepository(t),
+		cache:      mocks.NewTimelineCache(t),
+	}
+}
+
+func Test_usecase_CreateList(t *testing.T) {
+	type input struct {
+		ctx  context.Context
+		list *list.List
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, in input, expected, actual output)
+	}{
+		{
+			name:         "should return error if the name is blank",
+			input:        input{ctx: twcontext.NewTestContext(), list: &list.List{OwnerID: "u1", Name: "   "}},
+			output:       output{err: list.ErrInvalidName},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, in input, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:         "should return error if the description is too long",
+			input:        input{ctx: twcontext.NewTestContext(), list: &list.List{OwnerID: "u1", Name: "Go", Description: strings.Repeat("á", list.MaxDescriptionLength+1)}},
+			output:       output{err: list.ErrDescriptionTooLong},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, in input, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the owner does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), list: &list.List{OwnerID: "u1", Name: "Go"}},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, "u1").Return(false, nil)
+			},
+			assert: func(t *testing.T, in input, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the owner has too many lists",
+			input:  input{ctx: twcontext.NewTestContext(), list: &list.List{OwnerID: "u1", Name: "Go"}},
+			output: output{err: list.ErrTooManyLists},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, "u1").Return(true, nil)
+				d.repo.On("CountLists", in.ctx, "u1").Return(list.MaxLists, nil)
+			},
+			assert: func(t *testing.T, in input, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if repo.CreateList returns error",
+			input:  input{ctx: twcontext.NewTestContext(), list: &list.List{OwnerID: "u1", Name: "Go"}},
+			output: output{err: fmt.Errorf("failed to create list: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, "u1").Return(true, nil)
+				d.repo.On("CountLists", in.ctx, "u1").Return(0, nil)
+				d.repo.On("CreateList", in.ctx, in.list).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, in input, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should create the list with a trimmed name and description",
+			input:  input{ctx: twcontext.NewTestContext(), list: &list.List{OwnerID: "u1", Name: " Go ", Description: " Gophers ", Private: true}},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, "u1").Return(true, nil)
+				d.repo.On("CountLists", in.ctx, "u1").Return(0, nil)
+				d.repo.On("CreateList", in.ctx, &list.List{OwnerID: "u1", Name: "Go", Description: "Gophers", Private: true}).Return(nil)
+			},
+			assert: func(t *testing.T, in input, expected, actual output) {
+				assert.Equal(t, expected, actual)
+				assert.Equal(t, "Go", in.list.Name)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDependencies(t)
+			tt.dependencies(tt.input, d)
+
+			uc := list.NewListUseCase(d.userFinder, d.repo, d.cache)
+			var actual output
+			actual.err = uc.CreateList(tt.input.ctx, tt.input.list)
+			tt.assert(t, tt.input, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_GetList(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		viewerID string
+		id       string
+	}
+
+	type output struct {
+		list *list.List
+		err  error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if the list does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", id: "l1"},
+			output: output{err: list.ErrListNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.id).Return(nil, list.ErrListNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name:   "should hide private lists of other users",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", id: "l1"},
+			output: output{err: list.ErrListNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.id).Return(&list.List{ID: "l1", OwnerID: "u2", Private: true}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should hide lists of users with a block with the viewer",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", id: "l1"},
+			output: output{err: list.ErrListNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.id).Return(&list.List{ID: "l1", OwnerID: "u2"}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u2").Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return the private list to its owner",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", id: "l1"},
+			output: output{list: &list.List{ID: "l1", OwnerID: "u1", Private: true}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.id).Return(&list.List{ID: "l1", OwnerID: "u1", Private: true}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return public lists of other users",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", id: "l1"},
+			output: output{list: &list.List{ID: "l1", OwnerID: "u2", MemberCount: 3}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.id).Return(&list.List{ID: "l1", OwnerID: "u2", MemberCount: 3}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u2").Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDependencies(t)
+			tt.dependencies(tt.input, d)
+
+			uc := list.NewListUseCase(d.userFinder, d.repo, d.cache)
+			var actual output
+			actual.list, actual.err = uc.GetList(tt.input.ctx, tt.input.viewerID, tt.input.id)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_AddMember(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		userID   string
+		listID   string
+		memberID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if the user does not own the list",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1", memberID: "u3"},
+			output: output{err: list.ErrNotListOwner},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u2"}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u2").Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the list is full",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1", memberID: "u3"},
+			output: output{err: list.ErrTooManyMembers},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1", MemberCount: list.MaxMembers}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the member does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1", memberID: "u3"},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.userFinder.On("FindByID", in.ctx, "u3").Return(nil, user.ErrUserNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name:   "should return error if there is a block between the owner and the member",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1", memberID: "u3"},
+			output: output{err: user.ErrUserBlocked},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.userFinder.On("FindByID", in.ctx, "u3").Return(&user.User{ID: "u3"}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u3").Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the member is protected and not followed",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1", memberID: "u3"},
+			output: output{err: user.ErrProtectedAccount},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.userFinder.On("FindByID", in.ctx, "u3").Return(&user.User{ID: "u3", Protected: true}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u3").Return(false, nil)
+				d.userFinder.On("IsFollowing", in.ctx, "u1", "u3").Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the user is already a member",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1", memberID: "u1"},
+			output: output{err: list.ErrAlreadyMember},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.userFinder.On("FindByID", in.ctx, "u1").Return(&user.User{ID: "u1", Protected: true}, nil)
+				d.repo.On("AddMember", in.ctx, "l1", "u1").Return(list.ErrAlreadyMember)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name:   "should add a followed protected account and invalidate the list timeline",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1", memberID: "u3"},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.userFinder.On("FindByID", in.ctx, "u3").Return(&user.User{ID: "u3", Protected: true}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u3").Return(false, nil)
+				d.userFinder.On("IsFollowing", in.ctx, "u1", "u3").Return(true, nil)
+				d.repo.On("AddMember", in.ctx, "l1", "u3").Return(nil)
+				d.cache.On("InvalidateListTimeline", in.ctx, "l1").Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDependencies(t)
+			tt.dependencies(tt.input, d)
+
+			uc := list.NewListUseCase(d.userFinder, d.repo, d.cache)
+			var actual output
+			actual.err = uc.AddMember(tt.input.ctx, tt.input.userID, tt.input.listID, tt.input.memberID)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_RemoveMember(t *testing.T) {
+	ctx := twcontext.NewTestContext()
+
+	d := newDependencies(t)
+	d.repo.On("GetList", ctx, "l1").Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+	d.repo.On("RemoveMember", ctx, "l1", "u3").Return(nil)
+	d.cache.On("InvalidateListTimeline", ctx, "l1").Return(nil)
+
+	uc := list.NewListUseCase(d.userFinder, d.repo, d.cache)
+	assert.NoError(t, uc.RemoveMember(ctx, "u1", "l1", "u3"))
+}
+
+func Test_usecase_UpdateList(t *testing.T) {
+	ctx := twcontext.NewTestContext()
+	name := " Gophers "
+	private := true
+
+	d := newDependencies(t)
+	d.repo.On("GetList", ctx, "l1").Return(&list.List{ID: "l1", OwnerID: "u1", Name: "Go"}, nil).Once()
+	trimmed := "Gophers"
+	d.repo.On("UpdateList", ctx, "l1", list.ListUpdate{Name: &trimmed, Private: &private}).Return(nil)
+	d.repo.On("GetList", ctx, "l1").Return(&list.List{ID: "l1", OwnerID: "u1", Name: "Gophers", Private: true}, nil).Once()
+
+	uc := list.NewListUseCase(d.userFinder, d.repo, d.cache)
+	updated, err := uc.UpdateList(ctx, "u1", "l1", list.ListUpdate{Name: &name, Private: &private})
+	assert.NoError(t, err)
+	assert.Equal(t, &list.List{ID: "l1", OwnerID: "u1", Name: "Gophers", Private: true}, updated)
+}
+
+func Test_usecase_Subscribe(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		listID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if the list is a private list of another user",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1"},
+			output: output{err: list.ErrListNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u2", Private: true}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the user owns the list",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1"},
+			output: output{err: list.ErrCannotSubscribeOwnList},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if already subscribed",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1"},
+			output: output{err: list.ErrAlreadySubscribed},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u2"}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u2").Return(false, nil)
+				d.repo.On("Subscribe", in.ctx, "l1", "u1").Return(list.ErrAlreadySubscribed)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name:   "should subscribe to a public list of another user",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", listID: "l1"},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetList", in.ctx, in.listID).Return(&list.List{ID: "l1", OwnerID: "u2"}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, "u1", "u2").Return(false, nil)
+				d.repo.On("Subscribe", in.ctx, "l1", "u1").Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDependencies(t)
+			tt.dependencies(tt.input, d)
+
+			uc := list.NewListUseCase(d.userFinder, d.repo, d.cache)
+			var actual output
+			actual.err = uc.Subscribe(tt.input.ctx, tt.input.userID, tt.input.listID)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrMediaNotFound        = errors.New("media not found")
+	ErrMediaTooLarge        = errors.New("media is too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrInvalidMedia         = errors.New("invalid media")
+	ErrAltTextTooLong       = errors.New("alt text is too long")
+)
+
+const (
+	// MaxSize is the largest file accepted on upload.
+	MaxSize = 5 << 20
+	// MaxDimension bounds the width and height of an image, so that a small
+	// file cannot decode into a huge bitmap.
+	MaxDimension = 8192
+	// MaxGIFFrames and MaxGIFPixels bound an animated GIF, whose frames are
+	// all decoded at once: MaxGIFPixels is the sum of the frame areas.
+	MaxGIFFrames = 500
+	MaxGIFPixels = 1 << 26
+	// ThumbnailSize is the largest side of a thumbnail.
+	ThumbnailSize = 400
+	// MaxPerTweet is the number of media a tweet can reference.
+	MaxPerTweet = 4
+	// MaxAltTextLength is the length limit of an alt text, in characters.
+	MaxAltTextLength = 1000
+)
+
+type (
+	Media struct {
+		ID           string
+		UserID       string
+		ContentType  string
+		Size         int64
+		Width        int
+		Height       int
+		BlobKey      string
+		ThumbnailKey string
+		// AltText describes the image for screen readers.
+		AltText string
+		// Sensitive marks the image as not suitable for every audience. A
+		// tweet with a sensitive image is sensitive as well.
+		Sensitive bool
+		CreatedAt time.Time
+	}
+
+	// Metadata is the part of a media its owner can edit.
+	Metadata struct {
+		AltText   string
+		Sensitive bool
+	}
+
+	// Processed is an uploaded image ready to be stored: re-encoded without
+	// its metadata, along with a JPEG thumbnail.
+	Processed struct {
+		ContentType string
+		Data        []byte
+		Width       int
+		Height      int
+		Thumbnail   []byte
+	}
+
+	// Processor sniffs and sanitizes an upload. It fails with
+	// ErrUnsupportedMediaType or ErrInvalidMedia.
+	//
+	//go:generate mockery --name=Processor --output=mocks --outpkg=mocks --filename=processor.go
+	Processor interface {
+		Process(data []byte) (*Processed, error)
+	}
+
+	//go:generate mockery --name=MediaRepository --output=mocks --outpkg=mocks --filename=media_repository.go
+	MediaRepository interface {
+		CreateMedia(ctx context.Context, media *Media) error
+		GetMedia(ctx context.Context, id string) (*Media, error)
+		// UpdateMetadata fails with ErrMediaNotFound if userID does not own
+		// the media.
+		UpdateMetadata(ctx context.Context, userID, id string, metadata Metadata) error
+	}
+
+	// BlobStore stores the media files.
+	//
+	//go:generate mockery --name=BlobStore --output=mocks --outpkg=mocks --filename=blob_store.go
+	BlobStore interface {
+		Put(ctx context.Context, key string, r io.Reader) error
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+	}
+)
+
+func (m Metadata) Validate() error {
+	if utf8.RuneCountInString(m.AltText) > MaxAltTextLength {
+		return ErrAltTextTooLong
+	}
+
+	return nil
+}
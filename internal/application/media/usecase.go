@@ -0,0 +1,140 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type usecase struct {
+	repo      MediaRepository
+	blobs     BlobStore
+	processor Processor
+}
+
+func NewMediaUseCase(repo MediaRepository, blobs BlobStore, processor Processor) *usecase {
+	return &usecase{
+		repo:      repo,
+		blobs:     blobs,
+		processor: processor,
+	}
+}
+
+// Upload stores an image uploaded by userID. Only the re-encoded image is
+// kept, so the metadata of the original file (EXIF, GPS) is never served.
+func (uc *usecase) Upload(ctx context.Context, userID string, r io.Reader, metadata Metadata) (*Media, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+	if err := metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(data) > MaxSize {
+		return nil, ErrMediaTooLarge
+	}
+	if len(data) == 0 {
+		return nil, ErrInvalidMedia
+	}
+
+	processed, err := uc.processor.Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.NewString()
+	media := &Media{
+		ID:           id,
+		UserID:       userID,
+		ContentType:  processed.ContentType,
+		Size:         int64(len(processed.Data)),
+		Width:        processed.Width,
+		Height:       processed.Height,
+		BlobKey:      fmt.Sprintf("media/%s/%s", userID, id),
+		ThumbnailKey: fmt.Sprintf("media/%s/%s_thumb", userID, id),
+		AltText:      metadata.AltText,
+		Sensitive:    metadata.Sensitive,
+	}
+
+	if err := uc.blobs.Put(ctx, media.BlobKey, bytes.NewReader(processed.Data)); err != nil {
+		return nil, fmt.Errorf("failed to store media: %w", err)
+	}
+	if err := uc.blobs.Put(ctx, media.ThumbnailKey, bytes.NewReader(processed.Thumbnail)); err != nil {
+		uc.deleteBlobs(ctx, media.BlobKey)
+		return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	if err := uc.repo.CreateMedia(ctx, media); err != nil {
+		uc.deleteBlobs(ctx, media.BlobKey, media.ThumbnailKey)
+		return nil, fmt.Errorf("failed to create media: %w", err)
+	}
+
+	return media, nil
+}
+
+// UpdateMetadata replaces the alt text and sensitive flag of a media
+// uploaded by userID.
+func (uc *usecase) UpdateMetadata(ctx context.Context, userID, id string, metadata Metadata) (*Media, error) {
+	if userID == "" || id == "" {
+		return nil, user.ErrInvalidInput
+	}
+	if err := metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateMetadata(ctx, userID, id, metadata); err != nil {
+		if errors.Is(err, ErrMediaNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update media: %w", err)
+	}
+
+	media, err := uc.repo.GetMedia(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	return media, nil
+}
+
+// OpenMedia returns the image, or its thumbnail, behind a media ID. The
+// caller must close the returned reader.
+func (uc *usecase) OpenMedia(ctx context.Context, id string, thumbnail bool) (*Media, io.ReadCloser, error) {
+	media, err := uc.repo.GetMedia(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrMediaNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	key := media.BlobKey
+	if thumbnail {
+		key = media.ThumbnailKey
+	}
+
+	blob, err := uc.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open media %s: %w", media.ID, err)
+	}
+
+	return media, blob, nil
+}
+
+func (uc *usecase) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := uc.blobs.Delete(ctx, key); err != nil {
+			twcontext.Logger(ctx).WithError(err).WithField("blob_key", key).Warn("failed to delete media blob")
+		}
+	}
+}
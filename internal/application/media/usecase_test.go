@@ -0,0 +1,359 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	repo      *mocks.MediaRepository
+	blobs     *mocks.BlobStore
+	processor *mocks.Processor
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func Test_usecase_Upload(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		userID   string
+		data     []byte
+		metadata media.Metadata
+	}
+
+	type output struct {
+		media *media.Media
+		err   error
+	}
+
+	processed := &media.Processed{
+		ContentType: "image/png",
+		Data:        []byte("clean image"),
+		Width:       10,
+		Height:      20,
+		Thumbnail:   []byte("thumb"),
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if userID is empty",
+			input:        input{ctx: twcontext.NewTestContext(), data: []byte("image")},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if the alt text is too long",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				userID:   "u1",
+				data:     []byte("image"),
+				metadata: media.Metadata{AltText: strings.Repeat("é", media.MaxAltTextLength+1)},
+			},
+			output:       output{err: media.ErrAltTextTooLong},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:         "should return error if the upload is too large",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1", data: make([]byte, media.MaxSize+1)},
+			output:       output{err: media.ErrMediaTooLarge},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:         "should return error if the upload is empty",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1"},
+			output:       output{err: media.ErrInvalidMedia},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the media type is not supported",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", data: []byte("%PDF-1.4")},
+			output: output{err: media.ErrUnsupportedMediaType},
+			dependencies: func(in input, d *dependencies) {
+				d.processor.On("Process", in.data).Return(nil, media.ErrUnsupportedMediaType)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should delete the image if the thumbnail cannot be stored",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", data: []byte("image")},
+			output: output{err: fmt.Errorf("failed to store thumbnail: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.processor.On("Process", in.data).Return(processed, nil)
+				d.blobs.On("Put", in.ctx, mock.MatchedBy(func(key string) bool {
+					return !strings.HasSuffix(key, "_thumb")
+				}), mock.Anything).Return(nil)
+				d.blobs.On("Put", in.ctx, mock.MatchedBy(func(key string) bool {
+					return strings.HasSuffix(key, "_thumb")
+				}), mock.Anything).Return(assert.AnError)
+				d.blobs.On("Delete", in.ctx, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "media/u1/") && !strings.HasSuffix(key, "_thumb")
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Nil(t, actual.media)
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should delete the blobs if the media cannot be created",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", data: []byte("image")},
+			output: output{err: fmt.Errorf("failed to create media: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.processor.On("Process", in.data).Return(processed, nil)
+				d.blobs.On("Put", in.ctx, mock.Anything, mock.Anything).Return(nil)
+				d.repo.On("CreateMedia", in.ctx, mock.Anything).Return(assert.AnError)
+				d.blobs.On("Delete", in.ctx, mock.Anything).Return(nil).Twice()
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Nil(t, actual.media)
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should store the processed image and its thumbnail",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				userID:   "u1",
+				data:     []byte("image"),
+				metadata: media.Metadata{AltText: "A cat on a keyboard", Sensitive: true},
+			},
+			output: output{media: &media.Media{
+				UserID:      "u1",
+				ContentType: "image/png",
+				Size:        11,
+				Width:       10,
+				Height:      20,
+				AltText:     "A cat on a keyboard",
+				Sensitive:   true,
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.processor.On("Process", in.data).Return(processed, nil)
+				d.blobs.On("Put", in.ctx, mock.MatchedBy(func(key string) bool {
+					return !strings.HasSuffix(key, "_thumb")
+				}), mock.MatchedBy(func(r *bytes.Reader) bool {
+					return r.Size() == int64(len(processed.Data))
+				})).Return(nil)
+				d.blobs.On("Put", in.ctx, mock.MatchedBy(func(key string) bool {
+					return strings.HasSuffix(key, "_thumb")
+				}), mock.MatchedBy(func(r *bytes.Reader) bool {
+					return r.Size() == int64(len(processed.Thumbnail))
+				})).Return(nil)
+				d.repo.On("CreateMedia", in.ctx, mock.AnythingOfType("*media.Media")).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.NoError(t, actual.err)
+				assert.NotEmpty(t, actual.media.ID)
+				assert.Equal(t, "media/u1/"+actual.media.ID, actual.media.BlobKey)
+				assert.Equal(t, "media/u1/"+actual.media.ID+"_thumb", actual.media.ThumbnailKey)
+				actual.media.ID, actual.media.BlobKey, actual.media.ThumbnailKey = "", "", ""
+				assert.Equal(t, expected.media, actual.media)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				repo:      mocks.NewMediaRepository(t),
+				blobs:     mocks.NewBlobStore(t),
+				processor: mocks.NewProcessor(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := media.NewMediaUseCase(d.repo, d.blobs, d.processor)
+			m, err := uc.Upload(tt.input.ctx, tt.input.userID, bytes.NewReader(tt.input.data), tt.input.metadata)
+
+			tt.assert(t, tt.output, output{media: m, err: err})
+		})
+	}
+}
+
+func Test_usecase_UpdateMetadata(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		userID   string
+		id       string
+		metadata media.Metadata
+	}
+
+	type output struct {
+		media *media.Media
+		err   error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if the alt text is too long",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				userID:   "u1",
+				id:       "m1",
+				metadata: media.Metadata{AltText: strings.Repeat("a", media.MaxAltTextLength+1)},
+			},
+			output:       output{err: media.ErrAltTextTooLong},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the media belongs to another user",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "m1", metadata: media.Metadata{AltText: "alt"}},
+			output: output{err: media.ErrMediaNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("UpdateMetadata", in.ctx, in.userID, in.id, in.metadata).Return(media.ErrMediaNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should update the metadata and return the media",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "m1", metadata: media.Metadata{AltText: "alt", Sensitive: true}},
+			output: output{media: &media.Media{ID: "m1", UserID: "u1", AltText: "alt", Sensitive: true}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("UpdateMetadata", in.ctx, in.userID, in.id, in.metadata).Return(nil)
+				d.repo.On("GetMedia", in.ctx, in.id).Return(&media.Media{ID: "m1", UserID: "u1", AltText: "alt", Sensitive: true}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				repo:      mocks.NewMediaRepository(t),
+				blobs:     mocks.NewBlobStore(t),
+				processor: mocks.NewProcessor(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := media.NewMediaUseCase(d.repo, d.blobs, d.processor)
+			m, err := uc.UpdateMetadata(tt.input.ctx, tt.input.userID, tt.input.id, tt.input.metadata)
+
+			tt.assert(t, tt.output, output{media: m, err: err})
+		})
+	}
+}
+
+func Test_usecase_OpenMedia(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		id        string
+		thumbnail bool
+	}
+
+	type output struct {
+		media *media.Media
+		data  string
+		err   error
+	}
+
+	stored := &media.Media{ID: "m1", BlobKey: "media/u1/m1", ThumbnailKey: "media/u1/m1_thumb"}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if media does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), id: "m1"},
+			output: output{err: media.ErrMediaNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetMedia", in.ctx, in.id).Return(nil, media.ErrMediaNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the blob cannot be opened",
+			input:  input{ctx: twcontext.NewTestContext(), id: "m1"},
+			output: output{err: fmt.Errorf("failed to open media m1: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetMedia", in.ctx, in.id).Return(stored, nil)
+				d.blobs.On("Get", in.ctx, stored.BlobKey).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should open the thumbnail",
+			input:  input{ctx: twcontext.NewTestContext(), id: "m1", thumbnail: true},
+			output: output{media: stored, data: "thumb"},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetMedia", in.ctx, in.id).Return(stored, nil)
+				d.blobs.On("Get", in.ctx, stored.ThumbnailKey).Return(io.NopCloser(strings.NewReader("thumb")), nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				repo:      mocks.NewMediaRepository(t),
+				blobs:     mocks.NewBlobStore(t),
+				processor: mocks.NewProcessor(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := media.NewMediaUseCase(d.repo, d.blobs, d.processor)
+			m, blob, err := uc.OpenMedia(tt.input.ctx, tt.input.id, tt.input.thumbnail)
+
+			actual := output{media: m, err: err}
+			if blob != nil {
+				data, _ := io.ReadAll(blob)
+				actual.data = string(data)
+			}
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
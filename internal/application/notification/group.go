@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/cursor"
+)
+
+// maxGroupActors is how many actors are named in a group; the rest are
+// summarized as "N others".
+const maxGroupActors = 3
+
+// groupable reports whether notifications of type t are merged when they
+// are consecutive. Mentions carry their own content and are always shown on
+// their own.
+func groupable(t Type) bool {
+	switch t {
+	case TypeFollow, TypeFollowRequest:
+		return true
+	}
+	return false
+}
+
+// groupNotifications merges consecutive notifications into at most limit
+// groups and returns how many notifications were consumed. Only consecutive
+// runs are merged so that a cursor placed after the last consumed
+// notification never skips or repeats entries.
+func groupNotifications(notifications []Notification, limit int) ([]Group, int) {
+	var groups []Group
+	consumed := 0
+
+	for consumed < len(notifications) {
+		n := notifications[consumed]
+		last := len(groups) - 1
+		if last >= 0 && groupable(n.Type) && groups[last].Type == n.Type && groups[last].TweetID == n.TweetID {
+			addToGroup(&groups[last], n)
+			consumed++
+			continue
+		}
+
+		if len(groups) == limit {
+			break
+		}
+
+		groups = append(groups, Group{
+			Type:     n.Type,
+			TweetID:  n.TweetID,
+			LatestAt: n.CreatedAt,
+			Read:     true,
+		})
+		addToGroup(&groups[len(groups)-1], n)
+		consumed++
+	}
+
+	for i := range groups {
+		groups[i].Summary = summarize(groups[i])
+	}
+
+	return groups, consumed
+}
+
+func addToGroup(g *Group, n Notification) {
+	g.NotificationIDs = append(g.NotificationIDs, n.ID)
+	if n.ReadAt == nil {
+		g.Read = false
+	}
+
+	for _, a := range g.Actors {
+		if a.ID == n.ActorID {
+			return
+		}
+	}
+
+	g.ActorCount++
+	if len(g.Actors) < maxGroupActors {
+		g.Actors = append(g.Actors, Actor{ID: n.ActorID, Username: n.ActorUsername})
+	}
+}
+
+func summarize(g Group) string {
+	var actors string
+	switch {
+	case g.ActorCount == 1:
+		actors = g.Actors[0].Username
+	case g.ActorCount == 2:
+		actors = g.Actors[0].Username + " and " + g.Actors[1].Username
+	default:
+		actors = fmt.Sprintf("%s and %d others", g.Actors[0].Username, g.ActorCount-1)
+	}
+
+	switch g.Type {
+	case TypeFollow:
+		return actors + " followed you"
+	case TypeFollowRequest:
+		return actors + " requested to follow you"
+	case TypeMention:
+		return actors + " mentioned you"
+	}
+
+	return actors
+}
+
+func encodeCursor(c Cursor) string {
+	return cursor.Encode(c.CreatedAt, c.ID)
+}
+
+func decodeCursor(s string) (*Cursor, error) {
+	createdAt, id, err := cursor.Decode(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Type is the kind of event a notification reports.
+type Type string
+
+const (
+	TypeFollow        Type = "follow"
+	TypeFollowRequest Type = "follow_request"
+	TypeMention       Type = "mention"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 50
+)
+
+type (
+	Notification struct {
+		ID            string
+		RecipientID   string
+		ActorID       string
+		ActorUsername string
+		Type          Type
+		TweetID       string
+		CreatedAt     time.Time
+		ReadAt        *time.Time
+	}
+
+	Actor struct {
+		ID       string
+		Username string
+	}
+
+	// Group is a run of consecutive notifications of the same type about the
+	// same tweet, shown as a single inbox entry such as "alice and 4 others
+	// followed you".
+	Group struct {
+		Type            Type
+		TweetID         string
+		Actors          []Actor
+		ActorCount      int
+		Summary         string
+		NotificationIDs []string
+		LatestAt        time.Time
+		Read            bool
+	}
+
+	Page struct {
+		Groups      []Group
+		NextCursor  string
+		UnreadCount int
+	}
+
+	// Cursor points at the last notification of a page. The next page starts
+	// strictly after it in (created_at, id) descending order.
+	Cursor struct {
+		CreatedAt time.Time
+		ID        string
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		ExistsByID(ctx context.Context, id string) (bool, error)
+		FindIDsByUsernames(ctx context.Context, usernames []string) ([]string, error)
+		GetBlockedAmong(ctx context.Context, userID string, otherIDs []string) ([]string, error)
+		IsProtected(ctx context.Context, id string) (bool, error)
+		GetFollowersAmong(ctx context.Context, followeeID string, followerIDs []string) ([]string, error)
+	}
+
+	// Publisher pushes new notifications to the recipient when it is
+	// connected to the live stream.
+	//
+	//go:generate mockery --name=Publisher --output=mocks --outpkg=mocks --filename=publisher.go
+	Publisher interface {
+		PublishNotification(ctx context.Context, notification Notification) error
+	}
+
+	// NotificationRepository stores notifications. CreateNotifications sets
+	// the ID and CreatedAt of the given notifications.
+	//
+	//go:generate mockery --name=NotificationRepository --output=mocks --outpkg=mocks --filename=notification_repository.go
+	NotificationRepository interface {
+		CreateNotifications(ctx context.Context, notifications []Notification) error
+		GetNotifications(ctx context.Context, recipientID string, after *Cursor, limit int) ([]Notification, error)
+		CountUnread(ctx context.Context, recipientID string) (int, error)
+		MarkAsRead(ctx context.Context, recipientID string, ids []string) error
+	}
+)
@@ -0,0 +1,244 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// fetchFactor controls how many raw notifications are read per requested
+// group, so that grouping still fills a page.
+const fetchFactor = 5
+
+// mentionPattern matches @username where the @ is not part of a word, e.g.
+// it ignores e-mail addresses.
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_])@([A-Za-z0-9_]{3,15})\b`)
+
+type usecase struct {
+	userFinder UserFinder
+	repo       NotificationRepository
+	publisher  Publisher
+}
+
+func NewNotificationUseCase(userFinder UserFinder, repo NotificationRepository, publisher Publisher) *usecase {
+	return &usecase{userFinder: userFinder, repo: repo, publisher: publisher}
+}
+
+func (uc *usecase) NotifyFollow(ctx context.Context, followerID, followeeID string) error {
+	return uc.notify(ctx, Notification{RecipientID: followeeID, ActorID: followerID, Type: TypeFollow})
+}
+
+func (uc *usecase) NotifyFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	return uc.notify(ctx, Notification{RecipientID: targetID, ActorID: requesterID, Type: TypeFollowRequest})
+}
+
+// NotifyTweet notifies users mentioned in the tweet. The author, users with a
+// block in either direction and, when the author is protected, users that do
+// not follow the author are skipped, since they could not read the tweet.
+func (uc *usecase) NotifyTweet(ctx context.Context, t tweet.Tweet) error {
+	usernames := extractMentions(t.Content)
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	ids, err := uc.userFinder.FindIDsByUsernames(ctx, usernames)
+	if err != nil {
+		return fmt.Errorf("failed to resolve mentions: %w", err)
+	}
+
+	recipients := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != t.UserID {
+			recipients = append(recipients, id)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	recipients, err = uc.visibleTo(ctx, t.UserID, recipients)
+	if err != nil {
+		return err
+	}
+
+	notifications := make([]Notification, 0, len(recipients))
+	for _, id := range recipients {
+		notifications = append(notifications, Notification{
+			RecipientID: id,
+			ActorID:     t.UserID,
+			Type:        TypeMention,
+			TweetID:     t.ID,
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	if err := uc.repo.CreateNotifications(ctx, notifications); err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+	uc.publish(ctx, notifications)
+
+	return nil
+}
+
+// GetNotifications returns a page of grouped notifications, newest first.
+// cursor is the NextCursor of the previous page, or empty for the first one.
+func (uc *usecase) GetNotifications(ctx context.Context, userID, cursor string, limit int) (*Page, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	if limit <= 0 || limit > MaxLimit {
+		limit = DefaultLimit
+	}
+
+	var after *Cursor
+	if cursor != "" {
+		var err error
+		if after, err = decodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	if exists, err := uc.userFinder.ExistsByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	} else if !exists {
+		return nil, user.ErrUserNotFound
+	}
+
+	fetchSize := limit * fetchFactor
+	notifications, err := uc.repo.GetNotifications(ctx, userID, after, fetchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	unread, err := uc.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	groups, consumed := groupNotifications(notifications, limit)
+	page := &Page{Groups: groups, UnreadCount: unread}
+	if page.Groups == nil {
+		page.Groups = []Group{}
+	}
+
+	if consumed < len(notifications) || len(notifications) == fetchSize {
+		last := notifications[consumed-1]
+		page.NextCursor = encodeCursor(Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+func (uc *usecase) GetUnreadCount(ctx context.Context, userID string) (int, error) {
+	if userID == "" {
+		return 0, user.ErrInvalidInput
+	}
+
+	unread, err := uc.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return unread, nil
+}
+
+// MarkAsRead marks the given notifications as read, or every notification of
+// the user when ids is empty, and returns how many remain unread.
+func (uc *usecase) MarkAsRead(ctx context.Context, userID string, ids []string) (int, error) {
+	if userID == "" {
+		return 0, user.ErrInvalidInput
+	}
+
+	if err := uc.repo.MarkAsRead(ctx, userID, ids); err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return uc.GetUnreadCount(ctx, userID)
+}
+
+// visibleTo returns the users in ids that can read the tweets of authorID.
+func (uc *usecase) visibleTo(ctx context.Context, authorID string, ids []string) ([]string, error) {
+	blocked, err := uc.userFinder.GetBlockedAmong(ctx, authorID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check blocks: %w", err)
+	}
+	hidden := make(map[string]bool, len(blocked))
+	for _, id := range blocked {
+		hidden[id] = true
+	}
+
+	protected, err := uc.userFinder.IsProtected(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check protected author: %w", err)
+	}
+	if protected {
+		followers, err := uc.userFinder.GetFollowersAmong(ctx, authorID, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check followers: %w", err)
+		}
+		following := make(map[string]bool, len(followers))
+		for _, id := range followers {
+			following[id] = true
+		}
+		for _, id := range ids {
+			if !following[id] {
+				hidden[id] = true
+			}
+		}
+	}
+
+	visible := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !hidden[id] {
+			visible = append(visible, id)
+		}
+	}
+
+	return visible, nil
+}
+
+func (uc *usecase) notify(ctx context.Context, n Notification) error {
+	notifications := []Notification{n}
+	if err := uc.repo.CreateNotifications(ctx, notifications); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	uc.publish(ctx, notifications)
+
+	return nil
+}
+
+// publish pushes stored notifications to the live stream. They are already
+// in the inbox, so failures are only logged.
+func (uc *usecase) publish(ctx context.Context, notifications []Notification) {
+	logger := twcontext.Logger(ctx)
+
+	for _, n := range notifications {
+		if err := uc.publisher.PublishNotification(ctx, n); err != nil {
+			logger.WithError(err).WithField("notification_id", n.ID).Warn("failed to publish notification")
+		}
+	}
+}
+
+// extractMentions returns the distinct usernames mentioned in content,
+// lowercased, in order of appearance.
+func extractMentions(content string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		username := strings.ToLower(m[1])
+		if !seen[username] {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+
+	return usernames
+}
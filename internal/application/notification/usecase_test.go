@@ -0,0 +1,593 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	userFinder *mocks.UserFinder
+	repo       *mocks.NotificationRepository
+	publisher  *mocks.Publisher
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func cursorFor(createdAt time.Time, id string) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func Test_usecase_NotifyFollow(t *testing.T) {
+	type input struct {
+		ctx        context.Context
+		followerID string
+		followeeID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if repo.CreateNotifications returns error",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "u1",
+				followeeID: "u2",
+			},
+			output: output{err: fmt.Errorf("failed to create notification: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("CreateNotifications", in.ctx, mock.Anything).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should not fail if the notification cannot be published",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "u1",
+				followeeID: "u2",
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("CreateNotifications", in.ctx, mock.Anything).Return(nil)
+				d.publisher.On("PublishNotification", in.ctx, mock.Anything).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should create and publish a follow notification for the followee",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "u1",
+				followeeID: "u2",
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				created := notification.Notification{ID: "n1", RecipientID: "u2", ActorID: "u1", Type: notification.TypeFollow}
+				d.repo.On("CreateNotifications", in.ctx, []notification.Notification{
+					{RecipientID: "u2", ActorID: "u1", Type: notification.TypeFollow},
+				}).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).([]notification.Notification)[0].ID = created.ID
+				})
+				d.publisher.On("PublishNotification", in.ctx, created).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewNotificationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := notification.NewNotificationUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.err = uc.NotifyFollow(tt.input.ctx, tt.input.followerID, tt.input.followeeID)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_NotifyTweet(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		tweet tweet.Tweet
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should do nothing if the tweet has no mentions",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "write to me at bob@example.com"},
+			},
+			output:       output{},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if userFinder.FindIDsByUsernames returns error",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi @bob"},
+			},
+			output: output{err: fmt.Errorf("failed to resolve mentions: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindIDsByUsernames", in.ctx, []string{"bob"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should not notify the author mentioning themselves",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "note to self @alice"},
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindIDsByUsernames", in.ctx, []string{"alice"}).Return([]string{"u1"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if userFinder.GetBlockedAmong returns error",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi @bob"},
+			},
+			output: output{err: fmt.Errorf("failed to check blocks: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindIDsByUsernames", in.ctx, []string{"bob"}).Return([]string{"u2"}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if userFinder.IsProtected returns error",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi @bob"},
+			},
+			output: output{err: fmt.Errorf("failed to check protected author: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindIDsByUsernames", in.ctx, []string{"bob"}).Return([]string{"u2"}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2"}).Return(nil, nil)
+				d.userFinder.On("IsProtected", in.ctx, "u1").Return(false, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should only notify followers of a protected author",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi @bob and @carol"},
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindIDsByUsernames", in.ctx, []string{"bob", "carol"}).Return([]string{"u2", "u3"}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2", "u3"}).Return(nil, nil)
+				d.userFinder.On("IsProtected", in.ctx, "u1").Return(true, nil)
+				d.userFinder.On("GetFollowersAmong", in.ctx, "u1", []string{"u2", "u3"}).Return([]string{"u3"}, nil)
+				d.repo.On("CreateNotifications", in.ctx, []notification.Notification{
+					{RecipientID: "u3", ActorID: "u1", Type: notification.TypeMention, TweetID: "t1"},
+				}).Return(nil)
+				d.publisher.On("PublishNotification", in.ctx, notification.Notification{RecipientID: "u3", ActorID: "u1", Type: notification.TypeMention, TweetID: "t1"}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should not notify anyone if no mentioned user follows a protected author",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi @bob"},
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindIDsByUsernames", in.ctx, []string{"bob"}).Return([]string{"u2"}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2"}).Return(nil, nil)
+				d.userFinder.On("IsProtected", in.ctx, "u1").Return(true, nil)
+				d.userFinder.On("GetFollowersAmong", in.ctx, "u1", []string{"u2"}).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should notify and publish to each mentioned user once skipping blocked ones",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "@Bob and @carol, also @bob and @dave_"},
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("FindIDsByUsernames", in.ctx, []string{"bob", "carol", "dave_"}).Return([]string{"u2", "u3", "u4"}, nil)
+				d.userFinder.On("GetBlockedAmong", in.ctx, "u1", []string{"u2", "u3", "u4"}).Return([]string{"u3"}, nil)
+				d.userFinder.On("IsProtected", in.ctx, "u1").Return(false, nil)
+				d.repo.On("CreateNotifications", in.ctx, []notification.Notification{
+					{RecipientID: "u2", ActorID: "u1", Type: notification.TypeMention, TweetID: "t1"},
+					{RecipientID: "u4", ActorID: "u1", Type: notification.TypeMention, TweetID: "t1"},
+				}).Return(nil)
+				d.publisher.On("PublishNotification", in.ctx, notification.Notification{RecipientID: "u2", ActorID: "u1", Type: notification.TypeMention, TweetID: "t1"}).Return(nil)
+				d.publisher.On("PublishNotification", in.ctx, notification.Notification{RecipientID: "u4", ActorID: "u1", Type: notification.TypeMention, TweetID: "t1"}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewNotificationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := notification.NewNotificationUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.err = uc.NotifyTweet(tt.input.ctx, tt.input.tweet)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_GetNotifications(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		cursor string
+		limit  int
+	}
+
+	type output struct {
+		page *notification.Page
+		err  error
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(minutesAgo int) time.Time {
+		return now.Add(-time.Duration(minutesAgo) * time.Minute)
+	}
+	readAt := now
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if userID is empty",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if cursor is malformed",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				cursor: "not a cursor",
+			},
+			output:       output{err: notification.ErrInvalidCursor},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if repo.GetNotifications returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+			},
+			output: output{err: fmt.Errorf("failed to get notifications: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.repo.On("GetNotifications", in.ctx, in.userID, (*notification.Cursor)(nil), notification.DefaultLimit*5).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return an empty page without cursor",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  100,
+			},
+			output: output{page: &notification.Page{Groups: []notification.Group{}}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.repo.On("GetNotifications", in.ctx, in.userID, (*notification.Cursor)(nil), notification.DefaultLimit*5).Return([]notification.Notification{}, nil)
+				d.repo.On("CountUnread", in.ctx, in.userID).Return(0, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should group consecutive notifications and continue after the last consumed one",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				cursor: cursorFor(at(0), "n0"),
+				limit:  2,
+			},
+			output: output{page: &notification.Page{
+				Groups: []notification.Group{
+					{
+						Type:            notification.TypeFollow,
+						Actors:          []notification.Actor{{ID: "a1", Username: "alice"}, {ID: "a2", Username: "bob"}, {ID: "a3", Username: "carol"}},
+						ActorCount:      4,
+						Summary:         "alice and 3 others followed you",
+						NotificationIDs: []string{"n1", "n2", "n3", "n4", "n5"},
+						LatestAt:        at(1),
+					},
+					{
+						Type:            notification.TypeMention,
+						TweetID:         "t1",
+						Actors:          []notification.Actor{{ID: "a2", Username: "bob"}},
+						ActorCount:      1,
+						Summary:         "bob mentioned you",
+						NotificationIDs: []string{"n6"},
+						LatestAt:        at(6),
+						Read:            true,
+					},
+				},
+				NextCursor:  cursorFor(at(6), "n6"),
+				UnreadCount: 5,
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.repo.On("GetNotifications", in.ctx, in.userID, &notification.Cursor{CreatedAt: at(0), ID: "n0"}, 10).Return([]notification.Notification{
+					{ID: "n1", ActorID: "a1", ActorUsername: "alice", Type: notification.TypeFollow, CreatedAt: at(1)},
+					{ID: "n2", ActorID: "a2", ActorUsername: "bob", Type: notification.TypeFollow, CreatedAt: at(2), ReadAt: &readAt},
+					{ID: "n3", ActorID: "a1", ActorUsername: "alice", Type: notification.TypeFollow, CreatedAt: at(3)},
+					{ID: "n4", ActorID: "a3", ActorUsername: "carol", Type: notification.TypeFollow, CreatedAt: at(4)},
+					{ID: "n5", ActorID: "a4", ActorUsername: "dave", Type: notification.TypeFollow, CreatedAt: at(5)},
+					{ID: "n6", ActorID: "a2", ActorUsername: "bob", Type: notification.TypeMention, TweetID: "t1", CreatedAt: at(6), ReadAt: &readAt},
+					{ID: "n7", ActorID: "a3", ActorUsername: "carol", Type: notification.TypeMention, TweetID: "t2", CreatedAt: at(7)},
+				}, nil)
+				d.repo.On("CountUnread", in.ctx, in.userID).Return(5, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should not merge mentions nor follows of different types",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  5,
+			},
+			output: output{page: &notification.Page{
+				Groups: []notification.Group{
+					{
+						Type:            notification.TypeMention,
+						TweetID:         "t1",
+						Actors:          []notification.Actor{{ID: "a1", Username: "alice"}},
+						ActorCount:      1,
+						Summary:         "alice mentioned you",
+						NotificationIDs: []string{"n1"},
+						LatestAt:        at(1),
+					},
+					{
+						Type:            notification.TypeMention,
+						TweetID:         "t1",
+						Actors:          []notification.Actor{{ID: "a2", Username: "bob"}},
+						ActorCount:      1,
+						Summary:         "bob mentioned you",
+						NotificationIDs: []string{"n2"},
+						LatestAt:        at(2),
+					},
+					{
+						Type:            notification.TypeFollowRequest,
+						Actors:          []notification.Actor{{ID: "a1", Username: "alice"}, {ID: "a2", Username: "bob"}},
+						ActorCount:      2,
+						Summary:         "alice and bob requested to follow you",
+						NotificationIDs: []string{"n3", "n4"},
+						LatestAt:        at(3),
+					},
+					{
+						Type:            notification.TypeFollow,
+						Actors:          []notification.Actor{{ID: "a3", Username: "carol"}},
+						ActorCount:      1,
+						Summary:         "carol followed you",
+						NotificationIDs: []string{"n5"},
+						LatestAt:        at(5),
+					},
+				},
+				UnreadCount: 5,
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.repo.On("GetNotifications", in.ctx, in.userID, (*notification.Cursor)(nil), 25).Return([]notification.Notification{
+					{ID: "n1", ActorID: "a1", ActorUsername: "alice", Type: notification.TypeMention, TweetID: "t1", CreatedAt: at(1)},
+					{ID: "n2", ActorID: "a2", ActorUsername: "bob", Type: notification.TypeMention, TweetID: "t1", CreatedAt: at(2)},
+					{ID: "n3", ActorID: "a1", ActorUsername: "alice", Type: notification.TypeFollowRequest, CreatedAt: at(3)},
+					{ID: "n4", ActorID: "a2", ActorUsername: "bob", Type: notification.TypeFollowRequest, CreatedAt: at(4)},
+					{ID: "n5", ActorID: "a3", ActorUsername: "carol", Type: notification.TypeFollow, CreatedAt: at(5)},
+				}, nil)
+				d.repo.On("CountUnread", in.ctx, in.userID).Return(5, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewNotificationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := notification.NewNotificationUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.page, actual.err = uc.GetNotifications(tt.input.ctx, tt.input.userID, tt.input.cursor, tt.input.limit)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_MarkAsRead(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		ids    []string
+	}
+
+	type output struct {
+		unread int
+		err    error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if userID is empty",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if repo.MarkAsRead returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+			},
+			output: output{err: fmt.Errorf("failed to mark notifications as read: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("MarkAsRead", in.ctx, in.userID, []string(nil)).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should mark the given notifications as read and return the remaining unread count",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				ids:    []string{"n1", "n2"},
+			},
+			output: output{unread: 3},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("MarkAsRead", in.ctx, in.userID, in.ids).Return(nil)
+				d.repo.On("CountUnread", in.ctx, in.userID).Return(3, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewNotificationRepository(t),
+				publisher:  mocks.NewPublisher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := notification.NewNotificationUseCase(d.userFinder, d.repo, d.publisher)
+			var actual output
+			actual.unread, actual.err = uc.MarkAsRead(tt.input.ctx, tt.input.userID, tt.input.ids)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
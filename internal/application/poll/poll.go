@@ -0,0 +1,122 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrPollNotFound  = errors.New("poll not found")
+	ErrInvalidPoll   = errors.New("invalid poll")
+	ErrInvalidOption = errors.New("invalid poll option")
+	ErrPollClosed    = errors.New("poll is closed")
+	ErrAlreadyVoted  = errors.New("already voted")
+)
+
+const (
+	MinOptions      = 2
+	MaxOptions      = 4
+	MaxOptionLength = 25
+	MinDuration     = 5 * time.Minute
+	MaxDuration     = 7 * 24 * time.Hour
+)
+
+// ResultsVisibility decides when voters can see the tallies of each option.
+// The author always sees them, and everyone does once the poll is closed.
+type ResultsVisibility string
+
+const (
+	ResultsAfterVote  ResultsVisibility = "after_vote"
+	ResultsAfterClose ResultsVisibility = "after_close"
+)
+
+type (
+	// Poll is attached to a tweet and identified by its ID.
+	Poll struct {
+		TweetID           string
+		AuthorID          string
+		Options           []Option
+		ResultsVisibility ResultsVisibility
+		// Duration is only used to compute EndsAt when the poll is created.
+		Duration  time.Duration
+		EndsAt    time.Time
+		CreatedAt time.Time
+
+		// The fields below describe the poll as seen by a viewer.
+		TotalVotes     int
+		VotedOption    *int
+		ResultsVisible bool
+	}
+
+	// Option is a poll choice. Position is its zero-based index, and Votes
+	// is only set when the results are visible to the viewer.
+	Option struct {
+		Position int
+		Text     string
+		Votes    int
+	}
+
+	// Tallies holds the vote count of each option of a poll, by position.
+	Tallies map[int]int
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		IsProtected(ctx context.Context, id string) (bool, error)
+		IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+		HasBlockBetween(ctx context.Context, userID, otherID string) (bool, error)
+	}
+
+	//go:generate mockery --name=PollRepository --output=mocks --outpkg=mocks --filename=poll_repository.go
+	PollRepository interface {
+		GetPolls(ctx context.Context, tweetIDs []string) ([]Poll, error)
+		// CreateVote fails with ErrAlreadyVoted if the user already voted.
+		CreateVote(ctx context.Context, tweetID, userID string, option int) error
+		// GetVotedOptions returns the option voted by userID in each poll,
+		// by tweet ID.
+		GetVotedOptions(ctx context.Context, userID string, tweetIDs []string) (map[string]int, error)
+		CountVotes(ctx context.Context, tweetIDs []string) (map[string]Tallies, error)
+	}
+
+	// TallyCache keeps live vote counters. A counter is only incremented
+	// if the tallies of its poll are cached, so that a partial tally is
+	// never cached.
+	//
+	//go:generate mockery --name=TallyCache --output=mocks --outpkg=mocks --filename=tally_cache.go
+	TallyCache interface {
+		// GetTallies omits the polls whose tallies are not cached.
+		GetTallies(ctx context.Context, tweetIDs []string) (map[string]Tallies, error)
+		SetTallies(ctx context.Context, tweetID string, tallies Tallies) error
+		Increment(ctx context.Context, tweetID string, option int) error
+	}
+)
+
+// Validate checks a poll about to be created.
+func (p *Poll) Validate() error {
+	if len(p.Options) < MinOptions || len(p.Options) > MaxOptions {
+		return ErrInvalidPoll
+	}
+
+	for _, o := range p.Options {
+		if o.Text == "" || utf8.RuneCountInString(o.Text) > MaxOptionLength {
+			return ErrInvalidPoll
+		}
+	}
+
+	if p.Duration < MinDuration || p.Duration > MaxDuration {
+		return ErrInvalidPoll
+	}
+
+	switch p.ResultsVisibility {
+	case ResultsAfterVote, ResultsAfterClose:
+	default:
+		return ErrInvalidPoll
+	}
+
+	return nil
+}
+
+func (p *Poll) Closed(now time.Time) bool {
+	return !now.Before(p.EndsAt)
+}
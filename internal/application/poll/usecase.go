@@ -0,0 +1,221 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+type usecase struct {
+	userFinder UserFinder
+	repo       PollRepository
+	cache      TallyCache
+}
+
+func NewPollUseCase(userFinder UserFinder, repo PollRepository, cache TallyCache) *usecase {
+	return &usecase{
+		userFinder: userFinder,
+		repo:       repo,
+		cache:      cache,
+	}
+}
+
+// Vote records the vote of userID and returns the poll as seen by the voter.
+func (uc *usecase) Vote(ctx context.Context, userID, tweetID string, option int) (*Poll, error) {
+	polls, err := uc.repo.GetPolls(ctx, []string{tweetID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+	if len(polls) == 0 {
+		return nil, ErrPollNotFound
+	}
+	p := polls[0]
+
+	if err := uc.checkVisible(ctx, userID, p.AuthorID); err != nil {
+		return nil, err
+	}
+	if p.Closed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+	if option < 0 || option >= len(p.Options) {
+		return nil, ErrInvalidOption
+	}
+
+	if err := uc.repo.CreateVote(ctx, tweetID, userID, option); err != nil {
+		if errors.Is(err, ErrAlreadyVoted) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create vote: %w", err)
+	}
+
+	if err := uc.cache.Increment(ctx, tweetID, option); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("tweet_id", tweetID).Warn("failed to increment poll tally")
+	}
+
+	return uc.GetPoll(ctx, userID, tweetID)
+}
+
+// GetPoll returns the poll of a tweet as seen by viewerID.
+func (uc *usecase) GetPoll(ctx context.Context, viewerID, tweetID string) (*Poll, error) {
+	polls, err := uc.GetPolls(ctx, viewerID, []string{tweetID})
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := polls[tweetID]
+	if !ok {
+		return nil, ErrPollNotFound
+	}
+
+	if err := uc.checkVisible(ctx, viewerID, p.AuthorID); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// GetPolls returns the polls of the given tweets as seen by viewerID, by
+// tweet ID. Tweets without a poll are omitted. The caller is expected to
+// have checked that the viewer can see the tweets.
+func (uc *usecase) GetPolls(ctx context.Context, viewerID string, tweetIDs []string) (map[string]Poll, error) {
+	if len(tweetIDs) == 0 {
+		return map[string]Poll{}, nil
+	}
+
+	polls, err := uc.repo.GetPolls(ctx, tweetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polls: %w", err)
+	}
+	if len(polls) == 0 {
+		return map[string]Poll{}, nil
+	}
+
+	tallies, err := uc.getTallies(ctx, polls)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(polls))
+	for i, p := range polls {
+		ids[i] = p.TweetID
+	}
+
+	voted, err := uc.repo.GetVotedOptions(ctx, viewerID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes: %w", err)
+	}
+
+	now := time.Now()
+	result := make(map[string]Poll, len(polls))
+	for _, p := range polls {
+		if option, ok := voted[p.TweetID]; ok {
+			p.VotedOption = &option
+		}
+		p.ResultsVisible = viewerID == p.AuthorID || p.Closed(now) ||
+			(p.ResultsVisibility == ResultsAfterVote && p.VotedOption != nil)
+
+		counts := tallies[p.TweetID]
+		p.TotalVotes = 0
+		for i := range p.Options {
+			votes := counts[p.Options[i].Position]
+			p.TotalVotes += votes
+			if p.ResultsVisible {
+				p.Options[i].Votes = votes
+			} else {
+				p.Options[i].Votes = 0
+			}
+		}
+
+		result[p.TweetID] = p
+	}
+
+	return result, nil
+}
+
+// getTallies reads the tallies from the cache and counts the votes of the
+// polls missing from it, caching them for the next reads.
+func (uc *usecase) getTallies(ctx context.Context, polls []Poll) (map[string]Tallies, error) {
+	logger := twcontext.Logger(ctx)
+
+	ids := make([]string, len(polls))
+	for i, p := range polls {
+		ids[i] = p.TweetID
+	}
+
+	tallies, err := uc.cache.GetTallies(ctx, ids)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get poll tallies from cache")
+		tallies = map[string]Tallies{}
+	}
+
+	var missing []Poll
+	for _, p := range polls {
+		if _, ok := tallies[p.TweetID]; !ok {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		return tallies, nil
+	}
+
+	missingIDs := make([]string, len(missing))
+	for i, p := range missing {
+		missingIDs[i] = p.TweetID
+	}
+
+	counted, err := uc.repo.CountVotes(ctx, missingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count votes: %w", err)
+	}
+
+	for _, p := range missing {
+		// Every option gets a counter, even without votes, so that the
+		// cached tallies can be incremented.
+		counts := make(Tallies, len(p.Options))
+		for _, o := range p.Options {
+			counts[o.Position] = counted[p.TweetID][o.Position]
+		}
+		tallies[p.TweetID] = counts
+
+		if err := uc.cache.SetTallies(ctx, p.TweetID, counts); err != nil {
+			logger.WithError(err).WithField("tweet_id", p.TweetID).Warn("failed to cache poll tallies")
+		}
+	}
+
+	return tallies, nil
+}
+
+// checkVisible applies the visibility rules of the author's tweets.
+func (uc *usecase) checkVisible(ctx context.Context, viewerID, authorID string) error {
+	if viewerID == authorID {
+		return nil
+	}
+
+	blocked, err := uc.userFinder.HasBlockBetween(ctx, viewerID, authorID)
+	if err != nil {
+		return fmt.Errorf("failed to check block: %w", err)
+	}
+	if blocked {
+		return user.ErrUserBlocked
+	}
+
+	protected, err := uc.userFinder.IsProtected(ctx, authorID)
+	if err != nil {
+		return fmt.Errorf("failed to check user ID: %w", err)
+	}
+	if protected {
+		following, err := uc.userFinder.IsFollowing(ctx, viewerID, authorID)
+		if err != nil {
+			return fmt.Errorf("error checking follow relationship: %w", err)
+		}
+		if !following {
+			return user.ErrProtectedAccount
+		}
+	}
+
+	return nil
+}
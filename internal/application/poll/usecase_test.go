@@ -0,0 +1,381 @@
+package poll_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+type dependencies struct {
+	userFinder *mocks.UserFinder
+	repo       *mocks.PollRepository
+	cache      *mocks.TallyCache
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func newPoll(visibility poll.ResultsVisibility, endsAt time.Time) poll.Poll {
+	return poll.Poll{
+		TweetID:  "t1",
+		AuthorID: "author",
+		Options: []poll.Option{
+			{Position: 0, Text: "yes"},
+			{Position: 1, Text: "no"},
+		},
+		ResultsVisibility: visibility,
+		EndsAt:            endsAt,
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func Test_Poll_Validate(t *testing.T) {
+	valid := func() poll.Poll {
+		return poll.Poll{
+			Options:           []poll.Option{{Position: 0, Text: "yes"}, {Position: 1, Text: "no"}},
+			ResultsVisibility: poll.ResultsAfterVote,
+			Duration:          time.Hour,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *poll.Poll)
+		err    error
+	}{
+		{name: "should accept a valid poll", modify: func(p *poll.Poll) {}},
+		{name: "should reject a single option", modify: func(p *poll.Poll) { p.Options = p.Options[:1] }, err: poll.ErrInvalidPoll},
+		{name: "should reject more than four options", modify: func(p *poll.Poll) {
+			for i := 2; i < 5; i++ {
+				p.Options = append(p.Options, poll.Option{Position: i, Text: "x"})
+			}
+		}, err: poll.ErrInvalidPoll},
+		{name: "should reject an empty option", modify: func(p *poll.Poll) { p.Options[1].Text = "" }, err: poll.ErrInvalidPoll},
+		{name: "should reject a long option", modify: func(p *poll.Poll) { p.Options[1].Text = "abcdefghijklmnopqrstuvwxyz" }, err: poll.ErrInvalidPoll},
+		{name: "should reject a short duration", modify: func(p *poll.Poll) { p.Duration = time.Minute }, err: poll.ErrInvalidPoll},
+		{name: "should reject a long duration", modify: func(p *poll.Poll) { p.Duration = 8 * 24 * time.Hour }, err: poll.ErrInvalidPoll},
+		{name: "should reject an unknown results visibility", modify: func(p *poll.Poll) { p.ResultsVisibility = "never" }, err: poll.ErrInvalidPoll},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.modify(&p)
+			assert.Equal(t, tt.err, p.Validate())
+		})
+	}
+}
+
+func Test_usecase_Vote(t *testing.T) {
+	type input struct {
+		ctx     context.Context
+		userID  string
+		tweetID string
+		option  int
+	}
+
+	type output struct {
+		poll *poll.Poll
+		err  error
+	}
+
+	open := newPoll(poll.ResultsAfterVote, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return not found if the tweet has no poll",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: poll.ErrPollNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the voter and the author block each other",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: user.ErrUserBlocked},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{open}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.userID, open.AuthorID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the author is protected and the voter does not follow them",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: user.ErrProtectedAccount},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{open}, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.userID, open.AuthorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, open.AuthorID).Return(true, nil)
+				d.userFinder.On("IsFollowing", in.ctx, in.userID, open.AuthorID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the poll is closed",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "author", tweetID: "t1"},
+			output: output{err: poll.ErrPollClosed},
+			dependencies: func(in input, d *dependencies) {
+				closed := newPoll(poll.ResultsAfterVote, time.Now().Add(-time.Minute))
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{closed}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the option does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "author", tweetID: "t1", option: 2},
+			output: output{err: poll.ErrInvalidOption},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{open}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if the user already voted",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "author", tweetID: "t1", option: 1},
+			output: output{err: poll.ErrAlreadyVoted},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{open}, nil)
+				d.repo.On("CreateVote", in.ctx, in.tweetID, in.userID, in.option).Return(poll.ErrAlreadyVoted)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if repo.CreateVote returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "author", tweetID: "t1", option: 1},
+			output: output{err: fmt.Errorf("failed to create vote: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{open}, nil)
+				d.repo.On("CreateVote", in.ctx, in.tweetID, in.userID, in.option).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:  "should record the vote and return the results",
+			input: input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1", option: 1},
+			output: output{poll: &poll.Poll{
+				TweetID:  "t1",
+				AuthorID: "author",
+				Options: []poll.Option{
+					{Position: 0, Text: "yes", Votes: 2},
+					{Position: 1, Text: "no", Votes: 4},
+				},
+				ResultsVisibility: poll.ResultsAfterVote,
+				EndsAt:            open.EndsAt,
+				TotalVotes:        6,
+				VotedOption:       intPtr(1),
+				ResultsVisible:    true,
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{open}, nil).Once()
+				d.userFinder.On("HasBlockBetween", in.ctx, in.userID, open.AuthorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, open.AuthorID).Return(false, nil)
+				d.repo.On("CreateVote", in.ctx, in.tweetID, in.userID, in.option).Return(nil)
+				d.cache.On("Increment", in.ctx, in.tweetID, in.option).Return(assert.AnError)
+
+				d.repo.On("GetPolls", in.ctx, []string{in.tweetID}).Return([]poll.Poll{newPoll(poll.ResultsAfterVote, open.EndsAt)}, nil).Once()
+				d.cache.On("GetTallies", in.ctx, []string{in.tweetID}).Return(map[string]poll.Tallies{"t1": {0: 2, 1: 4}}, nil)
+				d.repo.On("GetVotedOptions", in.ctx, in.userID, []string{in.tweetID}).Return(map[string]int{"t1": 1}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewPollRepository(t),
+				cache:      mocks.NewTallyCache(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := poll.NewPollUseCase(d.userFinder, d.repo, d.cache)
+			var actual output
+			actual.poll, actual.err = uc.Vote(tt.input.ctx, tt.input.userID, tt.input.tweetID, tt.input.option)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_GetPolls(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		viewerID string
+		tweetIDs []string
+	}
+
+	type output struct {
+		polls map[string]poll.Poll
+		err   error
+	}
+
+	endsAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return no polls if the tweets have none",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", tweetIDs: []string{"t1", "t2"}},
+			output: output{polls: map[string]poll.Poll{}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, in.tweetIDs).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:  "should hide the results until the viewer votes",
+			input: input{ctx: twcontext.NewTestContext(), viewerID: "u1", tweetIDs: []string{"t1"}},
+			output: output{polls: map[string]poll.Poll{"t1": {
+				TweetID:           "t1",
+				AuthorID:          "author",
+				Options:           []poll.Option{{Position: 0, Text: "yes"}, {Position: 1, Text: "no"}},
+				ResultsVisibility: poll.ResultsAfterVote,
+				EndsAt:            endsAt,
+				TotalVotes:        3,
+			}}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, in.tweetIDs).Return([]poll.Poll{newPoll(poll.ResultsAfterVote, endsAt)}, nil)
+				d.cache.On("GetTallies", in.ctx, in.tweetIDs).Return(map[string]poll.Tallies{"t1": {0: 1, 1: 2}}, nil)
+				d.repo.On("GetVotedOptions", in.ctx, in.viewerID, in.tweetIDs).Return(map[string]int{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:  "should hide the results until the poll closes even after voting",
+			input: input{ctx: twcontext.NewTestContext(), viewerID: "u1", tweetIDs: []string{"t1"}},
+			output: output{polls: map[string]poll.Poll{"t1": {
+				TweetID:           "t1",
+				AuthorID:          "author",
+				Options:           []poll.Option{{Position: 0, Text: "yes"}, {Position: 1, Text: "no"}},
+				ResultsVisibility: poll.ResultsAfterClose,
+				EndsAt:            endsAt,
+				TotalVotes:        3,
+				VotedOption:       intPtr(0),
+			}}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, in.tweetIDs).Return([]poll.Poll{newPoll(poll.ResultsAfterClose, endsAt)}, nil)
+				d.cache.On("GetTallies", in.ctx, in.tweetIDs).Return(map[string]poll.Tallies{"t1": {0: 1, 1: 2}}, nil)
+				d.repo.On("GetVotedOptions", in.ctx, in.viewerID, in.tweetIDs).Return(map[string]int{"t1": 0}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:  "should show the results to the author",
+			input: input{ctx: twcontext.NewTestContext(), viewerID: "author", tweetIDs: []string{"t1"}},
+			output: output{polls: map[string]poll.Poll{"t1": {
+				TweetID:           "t1",
+				AuthorID:          "author",
+				Options:           []poll.Option{{Position: 0, Text: "yes", Votes: 1}, {Position: 1, Text: "no", Votes: 2}},
+				ResultsVisibility: poll.ResultsAfterClose,
+				EndsAt:            endsAt,
+				TotalVotes:        3,
+				ResultsVisible:    true,
+			}}},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, in.tweetIDs).Return([]poll.Poll{newPoll(poll.ResultsAfterClose, endsAt)}, nil)
+				d.cache.On("GetTallies", in.ctx, in.tweetIDs).Return(map[string]poll.Tallies{"t1": {0: 1, 1: 2}}, nil)
+				d.repo.On("GetVotedOptions", in.ctx, in.viewerID, in.tweetIDs).Return(map[string]int{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:  "should count the votes of polls missing from the cache and cache every option",
+			input: input{ctx: twcontext.NewTestContext(), viewerID: "u1", tweetIDs: []string{"t1"}},
+			output: output{polls: map[string]poll.Poll{"t1": {
+				TweetID:           "t1",
+				AuthorID:          "author",
+				Options:           []poll.Option{{Position: 0, Text: "yes", Votes: 0}, {Position: 1, Text: "no", Votes: 5}},
+				ResultsVisibility: poll.ResultsAfterVote,
+				EndsAt:            time.Time{},
+				TotalVotes:        5,
+				ResultsVisible:    true,
+			}}},
+			dependencies: func(in input, d *dependencies) {
+				closed := newPoll(poll.ResultsAfterVote, time.Time{})
+				d.repo.On("GetPolls", in.ctx, in.tweetIDs).Return([]poll.Poll{closed}, nil)
+				d.cache.On("GetTallies", in.ctx, in.tweetIDs).Return(nil, assert.AnError)
+				d.repo.On("CountVotes", in.ctx, []string{"t1"}).Return(map[string]poll.Tallies{"t1": {1: 5}}, nil)
+				d.cache.On("SetTallies", in.ctx, "t1", poll.Tallies{0: 0, 1: 5}).Return(nil)
+				d.repo.On("GetVotedOptions", in.ctx, in.viewerID, in.tweetIDs).Return(map[string]int{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if repo.CountVotes returns error",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", tweetIDs: []string{"t1"}},
+			output: output{err: fmt.Errorf("failed to count votes: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.repo.On("GetPolls", in.ctx, in.tweetIDs).Return([]poll.Poll{newPoll(poll.ResultsAfterVote, endsAt)}, nil)
+				d.cache.On("GetTallies", in.ctx, in.tweetIDs).Return(map[string]poll.Tallies{}, nil)
+				d.repo.On("CountVotes", in.ctx, []string{"t1"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder: mocks.NewUserFinder(t),
+				repo:       mocks.NewPollRepository(t),
+				cache:      mocks.NewTallyCache(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := poll.NewPollUseCase(d.userFinder, d.repo, d.cache)
+			var actual output
+			actual.polls, actual.err = uc.GetPolls(tt.input.ctx, tt.input.viewerID, tt.input.tweetIDs)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package recommendation
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Candidate is a user that could be suggested, together with the signals
+	// used to rank it.
+	Candidate struct {
+		UserID          string
+		Username        string
+		MutualCount     int
+		MutualUsernames []string
+		FollowerCount   int
+		LastTweetAt     *time.Time
+	}
+
+	Suggestion struct {
+		UserID        string
+		Username      string
+		MutualCount   int
+		FollowerCount int
+		Reason        string
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		ExistsByID(ctx context.Context, id string) (bool, error)
+	}
+
+	// CandidateFinder returns candidates that the user does not follow yet,
+	// has not requested to follow and has no block with.
+	//
+	//go:generate mockery --name=CandidateFinder --output=mocks --outpkg=mocks --filename=candidate_finder.go
+	CandidateFinder interface {
+		GetFriendsOfFriends(ctx context.Context, userID string, limit int) ([]Candidate, error)
+		GetPopularUsers(ctx context.Context, userID string, limit int) ([]Candidate, error)
+		// GetCandidatesAmong returns the IDs among ids that are still
+		// candidates for userID, in any order.
+		GetCandidatesAmong(ctx context.Context, userID string, ids []string) ([]string, error)
+	}
+
+	//go:generate mockery --name=SuggestionCache --output=mocks --outpkg=mocks --filename=suggestion_cache.go
+	SuggestionCache interface {
+		GetSuggestions(ctx context.Context, userID string) ([]Suggestion, error)
+		SetSuggestions(ctx context.Context, userID string, suggestions []Suggestion) error
+	}
+)
@@ -0,0 +1,202 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+const (
+	DefaultSuggestions = 10
+	MaxSuggestions     = 50
+
+	friendsOfFriendsPoolSize = 200
+	popularPoolSize          = 50
+
+	mutualWeight     = 3.0
+	popularityWeight = 1.0
+	recencyWeight    = 2.0
+	recencyHalfLife  = 7 * 24 * time.Hour
+)
+
+type usecase struct {
+	userFinder      UserFinder
+	candidateFinder CandidateFinder
+	cache           SuggestionCache
+}
+
+func NewRecommendationUseCase(userFinder UserFinder, candidateFinder CandidateFinder, cache SuggestionCache) *usecase {
+	return &usecase{
+		userFinder:      userFinder,
+		candidateFinder: candidateFinder,
+		cache:           cache,
+	}
+}
+
+// GetSuggestions returns up to limit users that userID may want to follow,
+// ranked by friends-of-friends overlap, popularity and recent activity.
+func (uc *usecase) GetSuggestions(ctx context.Context, userID string, limit int) ([]Suggestion, error) {
+	logger := twcontext.Logger(ctx)
+
+	if limit <= 0 {
+		limit = DefaultSuggestions
+	}
+	if limit > MaxSuggestions {
+		limit = MaxSuggestions
+	}
+
+	if exist, err := uc.userFinder.ExistsByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to check user ID: %w", err)
+	} else if !exist {
+		return nil, user.ErrUserNotFound
+	}
+
+	suggestions, err := uc.cache.GetSuggestions(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get suggestions from cache")
+	} else {
+		return uc.stillCandidates(ctx, userID, suggestions, limit)
+	}
+
+	suggestions, err = uc.buildSuggestions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.cache.SetSuggestions(ctx, userID, suggestions); err != nil {
+		logger.WithError(err).Error("Failed to set suggestions cache")
+	}
+
+	return truncate(suggestions, limit), nil
+}
+
+func (uc *usecase) buildSuggestions(ctx context.Context, userID string) ([]Suggestion, error) {
+	friendsOfFriends, err := uc.candidateFinder.GetFriendsOfFriends(ctx, userID, friendsOfFriendsPoolSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get friends of friends: %w", err)
+	}
+
+	popular, err := uc.candidateFinder.GetPopularUsers(ctx, userID, popularPoolSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular users: %w", err)
+	}
+
+	candidates := make(map[string]Candidate, len(friendsOfFriends)+len(popular))
+	for _, c := range append(friendsOfFriends, popular...) {
+		if c.UserID == userID {
+			continue
+		}
+		// Friends-of-friends come first and carry the mutual signal.
+		if _, ok := candidates[c.UserID]; !ok {
+			candidates[c.UserID] = c
+		}
+	}
+
+	type scored struct {
+		suggestion Suggestion
+		score      float64
+	}
+
+	now := time.Now()
+	ranked := make([]scored, 0, len(candidates))
+	for _, c := range candidates {
+		ranked = append(ranked, scored{
+			suggestion: Suggestion{
+				UserID:        c.UserID,
+				Username:      c.Username,
+				MutualCount:   c.MutualCount,
+				FollowerCount: c.FollowerCount,
+				Reason:        explain(c),
+			},
+			score: score(c, now),
+		})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].suggestion.UserID < ranked[j].suggestion.UserID
+	})
+
+	suggestions := make([]Suggestion, 0, min(len(ranked), MaxSuggestions))
+	for _, r := range truncate(ranked, MaxSuggestions) {
+		suggestions = append(suggestions, r.suggestion)
+	}
+
+	return suggestions, nil
+}
+
+// stillCandidates drops the cached suggestions that stopped being
+// candidates since the cache entry was built: users followed, requested,
+// blocked in either direction or deactivated in the meantime.
+func (uc *usecase) stillCandidates(ctx context.Context, userID string, suggestions []Suggestion, limit int) ([]Suggestion, error) {
+	if len(suggestions) == 0 {
+		return []Suggestion{}, nil
+	}
+
+	ids := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		ids[i] = s.UserID
+	}
+
+	candidates, err := uc.candidateFinder.GetCandidatesAmong(ctx, userID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error checking cached suggestions: %w", err)
+	}
+
+	candidate := make(map[string]bool, len(candidates))
+	for _, id := range candidates {
+		candidate[id] = true
+	}
+
+	filtered := make([]Suggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		if candidate[s.UserID] {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return truncate(filtered, limit), nil
+}
+
+func score(c Candidate, now time.Time) float64 {
+	s := mutualWeight*float64(c.MutualCount) + popularityWeight*math.Log1p(float64(c.FollowerCount))
+	if c.LastTweetAt != nil {
+		age := now.Sub(*c.LastTweetAt)
+		if age < 0 {
+			age = 0
+		}
+		s += recencyWeight * math.Exp(-math.Ln2*float64(age)/float64(recencyHalfLife))
+	}
+
+	return s
+}
+
+func explain(c Candidate) string {
+	if c.MutualCount == 0 || len(c.MutualUsernames) == 0 {
+		return "Popular on the platform"
+	}
+
+	switch others := c.MutualCount - 1; others {
+	case 0:
+		return fmt.Sprintf("Followed by %s", c.MutualUsernames[0])
+	case 1:
+		return fmt.Sprintf("Followed by %s and 1 other", c.MutualUsernames[0])
+	default:
+		return fmt.Sprintf("Followed by %s and %d others", c.MutualUsernames[0], others)
+	}
+}
+
+func truncate[T any](items []T, limit int) []T {
+	if len(items) > limit {
+		return items[:limit]
+	}
+
+	return items
+}
@@ -0,0 +1,178 @@
+package recommendation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/recommendation/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	userFinder      *mocks.UserFinder
+	candidateFinder *mocks.CandidateFinder
+	cache           *mocks.SuggestionCache
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func Test_usecase_GetSuggestions(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+
+	type output struct {
+		suggestions []recommendation.Suggestion
+		err         error
+	}
+
+	recently := time.Now().Add(-time.Hour)
+	longAgo := time.Now().Add(-90 * 24 * time.Hour)
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return cached suggestions without the users that stopped being candidates",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+			},
+			output: output{suggestions: []recommendation.Suggestion{{UserID: "u3"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.cache.On("GetSuggestions", in.ctx, in.userID).Return([]recommendation.Suggestion{{UserID: "u2"}, {UserID: "u3"}}, nil)
+				d.candidateFinder.On("GetCandidatesAmong", in.ctx, in.userID, []string{"u2", "u3"}).Return([]string{"u3"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return the default number of suggestions if limit is missing",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  0,
+			},
+			output: output{suggestions: func() []recommendation.Suggestion {
+				suggestions := make([]recommendation.Suggestion, recommendation.DefaultSuggestions)
+				for i := range suggestions {
+					suggestions[i] = recommendation.Suggestion{UserID: fmt.Sprintf("u%d", i)}
+				}
+				return suggestions
+			}()},
+			dependencies: func(in input, d *dependencies) {
+				cached := make([]recommendation.Suggestion, recommendation.DefaultSuggestions+5)
+				ids := make([]string, len(cached))
+				for i := range cached {
+					cached[i] = recommendation.Suggestion{UserID: fmt.Sprintf("u%d", i)}
+					ids[i] = cached[i].UserID
+				}
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.cache.On("GetSuggestions", in.ctx, in.userID).Return(cached, nil)
+				d.candidateFinder.On("GetCandidatesAmong", in.ctx, in.userID, ids).Return(ids, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if candidateFinder.GetFriendsOfFriends returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+			},
+			output: output{err: fmt.Errorf("failed to get friends of friends: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.cache.On("GetSuggestions", in.ctx, in.userID).Return(nil, assert.AnError)
+				d.candidateFinder.On("GetFriendsOfFriends", in.ctx, in.userID, mock.Anything).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should rank candidates, explain them and cache the result",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  3,
+			},
+			output: output{suggestions: []recommendation.Suggestion{
+				{UserID: "u2", Username: "bob", MutualCount: 4, FollowerCount: 10, Reason: "Followed by carol and 3 others"},
+				{UserID: "u4", Username: "erin", MutualCount: 1, FollowerCount: 2, Reason: "Followed by carol"},
+				{UserID: "u5", Username: "frank", MutualCount: 0, FollowerCount: 50, Reason: "Popular on the platform"},
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.cache.On("GetSuggestions", in.ctx, in.userID).Return(nil, assert.AnError)
+				d.candidateFinder.On("GetFriendsOfFriends", in.ctx, in.userID, mock.Anything).Return([]recommendation.Candidate{
+					{UserID: "u4", Username: "erin", MutualCount: 1, MutualUsernames: []string{"carol"}, FollowerCount: 2, LastTweetAt: &recently},
+					{UserID: "u2", Username: "bob", MutualCount: 4, MutualUsernames: []string{"carol", "dave"}, FollowerCount: 10, LastTweetAt: &longAgo},
+				}, nil)
+				d.candidateFinder.On("GetPopularUsers", in.ctx, in.userID, mock.Anything).Return([]recommendation.Candidate{
+					{UserID: "u2", Username: "bob", FollowerCount: 10},
+					{UserID: "u5", Username: "frank", FollowerCount: 50},
+					{UserID: "u6", Username: "grace", FollowerCount: 1, LastTweetAt: &longAgo},
+				}, nil)
+				d.cache.On("SetSuggestions", in.ctx, in.userID, []recommendation.Suggestion{
+					{UserID: "u2", Username: "bob", MutualCount: 4, FollowerCount: 10, Reason: "Followed by carol and 3 others"},
+					{UserID: "u4", Username: "erin", MutualCount: 1, FollowerCount: 2, Reason: "Followed by carol"},
+					{UserID: "u5", Username: "frank", MutualCount: 0, FollowerCount: 50, Reason: "Popular on the platform"},
+					{UserID: "u6", Username: "grace", MutualCount: 0, FollowerCount: 1, Reason: "Popular on the platform"},
+				}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:      mocks.NewUserFinder(t),
+				candidateFinder: mocks.NewCandidateFinder(t),
+				cache:           mocks.NewSuggestionCache(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := recommendation.NewRecommendationUseCase(d.userFinder, d.candidateFinder, d.cache)
+			var actual output
+			actual.suggestions, actual.err = uc.GetSuggestions(tt.input.ctx, tt.input.userID, tt.input.limit)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
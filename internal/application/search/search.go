@@ -0,0 +1,107 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+)
+
+const (
+	TweetCreated    EventType = "tweet.created"
+	UserDeactivated EventType = "user.deactivated"
+	UserDeleted     EventType = "user.deleted"
+
+	// reindexBatchSize is how many tweets are read from the database and
+	// sent to the index at once while reindexing.
+	reindexBatchSize = 1000
+)
+
+type (
+	// EventType names a change to the tweets or users that the index may
+	// need to follow.
+	EventType string
+
+	// Event is a change sent to the index. Tweet events carry the tweet;
+	// user events carry UserID. A reactivated user has their tweets sent
+	// again as TweetCreated.
+	Event struct {
+		Type   EventType
+		Tweet  Document
+		UserID string
+	}
+
+	// Document is what the index knows about a tweet.
+	Document struct {
+		ID        string
+		UserID    string
+		Content   string
+		Language  string
+		CreatedAt time.Time
+	}
+
+	// Query is a tweet.SearchQuery with the author resolved to its ID.
+	Query struct {
+		Text     string
+		AuthorID string
+		Hashtags []string
+		Since    *time.Time
+		Until    *time.Time
+		Language string
+	}
+
+	// Hit is a tweet matched by the index. It is also the position to
+	// resume a search from.
+	Hit struct {
+		ID        string
+		CreatedAt time.Time
+	}
+)
+
+type (
+	// Index matches tweets against a Query. It only knows what the events
+	// told it: who can see a tweet is checked against the database after
+	// the search, so blocks and protected accounts never need to be
+	// indexed. Authors are kept by ID, so renames need no event either.
+	//
+	//go:generate mockery --name=Index --output=mocks --outpkg=mocks --filename=index.go
+	Index interface {
+		// Apply updates the index with the events, in order. Events the
+		// index does not need are ignored.
+		Apply(ctx context.Context, events []Event) error
+		// Search returns up to limit hits, newest first, older than after
+		// if it is set.
+		Search(ctx context.Context, query Query, after *Hit, limit int) ([]Hit, error)
+		// Reset empties the index before it is rebuilt.
+		Reset(ctx context.Context) error
+	}
+
+	//go:generate mockery --name=TweetReader --output=mocks --outpkg=mocks --filename=tweet_reader.go
+	TweetReader interface {
+		// GetVisibleTweets returns the tweets among ids that viewerID can
+		// see, in any order.
+		GetVisibleTweets(ctx context.Context, viewerID string, ids []string) ([]tweet.Tweet, error)
+		// GetDocuments returns up to limit tweets of active users, oldest
+		// first, newer than after if it is set.
+		GetDocuments(ctx context.Context, after *Hit, limit int) ([]Document, error)
+		// GetUserDocuments is GetDocuments restricted to the tweets of
+		// userID.
+		GetUserDocuments(ctx context.Context, userID string, after *Hit, limit int) ([]Document, error)
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		FindByUsername(ctx context.Context, username string) (*user.User, error)
+	}
+)
+
+func documentOf(t tweet.Tweet) Document {
+	return Document{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Content:   t.Content,
+		Language:  t.Language,
+		CreatedAt: t.CreatedAt,
+	}
+}
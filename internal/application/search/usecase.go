@@ -0,0 +1,169 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+)
+
+type usecase struct {
+	index  Index
+	tweets TweetReader
+	users  UserFinder
+}
+
+func NewSearchUseCase(index Index, tweets TweetReader, users UserFinder) *usecase {
+	return &usecase{
+		index:  index,
+		tweets: tweets,
+		users:  users,
+	}
+}
+
+// SearchTweets returns up to limit tweets matching the query that viewerID
+// can see, newest first. Hits the viewer cannot see are skipped and more
+// are read from the index until limit is reached or it runs out.
+func (uc *usecase) SearchTweets(ctx context.Context, viewerID string, q tweet.SearchQuery, after *tweet.SearchCursor, limit int) ([]tweet.Tweet, error) {
+	query := Query{
+		Text:     q.Text,
+		Hashtags: q.Hashtags,
+		Since:    q.Since,
+		Until:    q.Until,
+		Language: q.Language,
+	}
+	if q.From != "" {
+		author, err := uc.users.FindByUsername(ctx, q.From)
+		if errors.Is(err, user.ErrUserNotFound) {
+			return []tweet.Tweet{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find author: %w", err)
+		}
+		query.AuthorID = author.ID
+	}
+
+	var from *Hit
+	if after != nil {
+		from = &Hit{ID: after.ID, CreatedAt: after.CreatedAt}
+	}
+
+	tweets := []tweet.Tweet{}
+	for len(tweets) < limit {
+		hits, err := uc.index.Search(ctx, query, from, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search index: %w", err)
+		}
+		if len(hits) == 0 {
+			break
+		}
+
+		ids := make([]string, len(hits))
+		for i, h := range hits {
+			ids[i] = h.ID
+		}
+		visible, err := uc.tweets.GetVisibleTweets(ctx, viewerID, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tweets: %w", err)
+		}
+
+		byID := make(map[string]tweet.Tweet, len(visible))
+		for _, t := range visible {
+			byID[t.ID] = t
+		}
+		for _, id := range ids {
+			if t, ok := byID[id]; ok && len(tweets) < limit {
+				tweets = append(tweets, t)
+			}
+		}
+
+		if len(hits) < limit {
+			break
+		}
+		from = &hits[len(hits)-1]
+	}
+
+	return tweets, nil
+}
+
+// IndexTweet sends a new tweet to the index.
+func (uc *usecase) IndexTweet(ctx context.Context, t tweet.Tweet) error {
+	if err := uc.index.Apply(ctx, []Event{{Type: TweetCreated, Tweet: documentOf(t)}}); err != nil {
+		return fmt.Errorf("failed to index tweet %s: %w", t.ID, err)
+	}
+
+	return nil
+}
+
+// UserDeactivated takes the tweets of the user out of the index until a
+// reactivation.
+func (uc *usecase) UserDeactivated(ctx context.Context, id string) error {
+	if err := uc.index.Apply(ctx, []Event{{Type: UserDeactivated, UserID: id}}); err != nil {
+		return fmt.Errorf("failed to remove user %s from index: %w", id, err)
+	}
+
+	return nil
+}
+
+// UserReactivated sends the tweets of the user to the index again.
+func (uc *usecase) UserReactivated(ctx context.Context, id string) error {
+	_, err := uc.indexDocuments(ctx, func(after *Hit) ([]Document, error) {
+		return uc.tweets.GetUserDocuments(ctx, id, after, reindexBatchSize)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore user %s in index: %w", id, err)
+	}
+
+	return nil
+}
+
+// UserDeleted tells the index that the user and their tweets are gone.
+func (uc *usecase) UserDeleted(ctx context.Context, id string) error {
+	if err := uc.index.Apply(ctx, []Event{{Type: UserDeleted, UserID: id}}); err != nil {
+		return fmt.Errorf("failed to remove user %s from index: %w", id, err)
+	}
+
+	return nil
+}
+
+// Reindex empties the index and rebuilds it from the tweets in the
+// database, oldest first. It returns the number of tweets indexed.
+func (uc *usecase) Reindex(ctx context.Context) (int, error) {
+	if err := uc.index.Reset(ctx); err != nil {
+		return 0, fmt.Errorf("failed to reset index: %w", err)
+	}
+
+	return uc.indexDocuments(ctx, func(after *Hit) ([]Document, error) {
+		return uc.tweets.GetDocuments(ctx, after, reindexBatchSize)
+	})
+}
+
+// indexDocuments sends the batches returned by read to the index until it
+// returns none, and returns the number of tweets indexed.
+func (uc *usecase) indexDocuments(ctx context.Context, read func(after *Hit) ([]Document, error)) (int, error) {
+	indexed := 0
+	var after *Hit
+	for {
+		docs, err := read(after)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to get tweets: %w", err)
+		}
+		if len(docs) == 0 {
+			return indexed, nil
+		}
+
+		events := make([]Event, len(docs))
+		for i, doc := range docs {
+			events[i] = Event{Type: TweetCreated, Tweet: doc}
+		}
+		if err := uc.index.Apply(ctx, events); err != nil {
+			return indexed, fmt.Errorf("failed to index tweets: %w", err)
+		}
+		indexed += len(docs)
+
+		last := docs[len(docs)-1]
+		after = &Hit{ID: last.ID, CreatedAt: last.CreatedAt}
+	}
+}
@@ -0,0 +1,266 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/search/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+type dependencies struct {
+	index  *mocks.Index
+	tweets *mocks.TweetReader
+	users  *mocks.UserFinder
+}
+
+func newDependencies(t *testing.T) *dependencies {
+	return &dependencies{
+		index:  mocks.NewIndex(t),
+		tweets: mocks.NewTweetReader(t),
+		users:  mocks.NewUserFinder(t),
+	}
+}
+
+func Test_usecase_SearchTweets(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return time.Date(2024, 1, 1, 12, minutes, 0, 0, time.UTC)
+	}
+
+	type input struct {
+		ctx      context.Context
+		viewerID string
+		query    tweet.SearchQuery
+		after    *tweet.SearchCursor
+		limit    int
+	}
+
+	type output struct {
+		tweets []tweet.Tweet
+		err    error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return no tweets if the author does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", query: tweet.SearchQuery{From: "nobody"}, limit: 2},
+			output: output{tweets: []tweet.Tweet{}},
+			dependencies: func(in input, d *dependencies) {
+				d.users.On("FindByUsername", in.ctx, "nobody").Return(nil, user.ErrUserNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if index.Search returns error",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", query: tweet.SearchQuery{Text: "go"}, limit: 2},
+			output: output{err: fmt.Errorf("failed to search index: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.index.On("Search", in.ctx, search.Query{Text: "go"}, (*search.Hit)(nil), 2).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should resolve the author and resume after the cursor",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				query:    tweet.SearchQuery{From: "ana", Hashtags: []string{"go"}},
+				after:    &tweet.SearchCursor{CreatedAt: at(9), ID: "t9"},
+				limit:    2,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t8"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.users.On("FindByUsername", in.ctx, "ana").Return(&user.User{ID: "u2", Username: "ana"}, nil)
+				query := search.Query{AuthorID: "u2", Hashtags: []string{"go"}}
+				d.index.On("Search", in.ctx, query, &search.Hit{ID: "t9", CreatedAt: at(9)}, 2).Return([]search.Hit{{ID: "t8", CreatedAt: at(8)}}, nil)
+				d.tweets.On("GetVisibleTweets", in.ctx, in.viewerID, []string{"t8"}).Return([]tweet.Tweet{{ID: "t8"}}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should read more hits when the viewer cannot see some of them",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", query: tweet.SearchQuery{Text: "go"}, limit: 2},
+			output: output{tweets: []tweet.Tweet{{ID: "t8"}, {ID: "t7"}}},
+			dependencies: func(in input, d *dependencies) {
+				query := search.Query{Text: "go"}
+				d.index.On("Search", in.ctx, query, (*search.Hit)(nil), 2).Return([]search.Hit{
+					{ID: "t9", CreatedAt: at(9)},
+					{ID: "t8", CreatedAt: at(8)},
+				}, nil)
+				// t9 is from a blocked user.
+				d.tweets.On("GetVisibleTweets", in.ctx, in.viewerID, []string{"t9", "t8"}).Return([]tweet.Tweet{{ID: "t8"}}, nil)
+				d.index.On("Search", in.ctx, query, &search.Hit{ID: "t8", CreatedAt: at(8)}, 2).Return([]search.Hit{
+					{ID: "t7", CreatedAt: at(7)},
+					{ID: "t6", CreatedAt: at(6)},
+				}, nil)
+				d.tweets.On("GetVisibleTweets", in.ctx, in.viewerID, []string{"t7", "t6"}).Return([]tweet.Tweet{{ID: "t6"}, {ID: "t7"}}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDependencies(t)
+			tt.dependencies(tt.input, d)
+
+			uc := search.NewSearchUseCase(d.index, d.tweets, d.users)
+			var actual output
+			actual.tweets, actual.err = uc.SearchTweets(tt.input.ctx, tt.input.viewerID, tt.input.query, tt.input.after, tt.input.limit)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_IndexTweet(t *testing.T) {
+	ctx := twcontext.NewTestContext()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d := newDependencies(t)
+	d.index.On("Apply", ctx, []search.Event{{
+		Type:  search.TweetCreated,
+		Tweet: search.Document{ID: "t1", UserID: "u1", Content: "hola", Language: "es", CreatedAt: createdAt},
+	}}).Return(nil)
+
+	uc := search.NewSearchUseCase(d.index, d.tweets, d.users)
+	err := uc.IndexTweet(ctx, tweet.Tweet{ID: "t1", UserID: "u1", Content: "hola", Language: "es", CreatedAt: createdAt, Sensitive: true})
+	assert.NoError(t, err)
+}
+
+func Test_usecase_Reindex(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return time.Date(2024, 1, 1, 12, minutes, 0, 0, time.UTC)
+	}
+
+	type output struct {
+		indexed int
+		err     error
+	}
+
+	tests := []struct {
+		name         string
+		output       output
+		dependencies func(ctx context.Context, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if index.Reset returns error",
+			output: output{err: fmt.Errorf("failed to reset index: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.index.On("Reset", ctx).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should report progress if index.Apply returns error",
+			output: output{indexed: 0, err: fmt.Errorf("failed to index tweets: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.index.On("Reset", ctx).Return(nil)
+				d.tweets.On("GetDocuments", ctx, (*search.Hit)(nil), 1000).Return([]search.Document{{ID: "t1", CreatedAt: at(1)}}, nil)
+				d.index.On("Apply", ctx, []search.Event{{Type: search.TweetCreated, Tweet: search.Document{ID: "t1", CreatedAt: at(1)}}}).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.indexed, actual.indexed)
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should index every tweet in batches",
+			output: output{indexed: 2},
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.index.On("Reset", ctx).Return(nil)
+				first := search.Document{ID: "t1", UserID: "u1", Content: "one", CreatedAt: at(1)}
+				second := search.Document{ID: "t2", UserID: "u1", Content: "two", CreatedAt: at(2)}
+				d.tweets.On("GetDocuments", ctx, (*search.Hit)(nil), 1000).Return([]search.Document{first, second}, nil)
+				d.index.On("Apply", ctx, []search.Event{
+					{Type: search.TweetCreated, Tweet: first},
+					{Type: search.TweetCreated, Tweet: second},
+				}).Return(nil)
+				d.tweets.On("GetDocuments", ctx, &search.Hit{ID: "t2", CreatedAt: at(2)}, 1000).Return([]search.Document{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := twcontext.NewTestContext()
+			d := newDependencies(t)
+			tt.dependencies(ctx, d)
+
+			uc := search.NewSearchUseCase(d.index, d.tweets, d.users)
+			var actual output
+			actual.indexed, actual.err = uc.Reindex(ctx)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_UserDeactivated(t *testing.T) {
+	ctx := twcontext.NewTestContext()
+
+	d := newDependencies(t)
+	d.index.On("Apply", ctx, []search.Event{{Type: search.UserDeactivated, UserID: "u1"}}).Return(nil)
+
+	uc := search.NewSearchUseCase(d.index, d.tweets, d.users)
+	assert.NoError(t, uc.UserDeactivated(ctx, "u1"))
+}
+
+func Test_usecase_UserReactivated(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	doc := search.Document{ID: "t1", UserID: "u1", Content: "hola", CreatedAt: createdAt}
+
+	tests := []struct {
+		name         string
+		err          error
+		dependencies func(ctx context.Context, d *dependencies)
+	}{
+		{
+			name: "should return error if tweets.GetUserDocuments returns error",
+			err:  fmt.Errorf("failed to restore user u1 in index: %w", fmt.Errorf("failed to get tweets: %w", assert.AnError)),
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.tweets.On("GetUserDocuments", ctx, "u1", (*search.Hit)(nil), 1000).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name: "should send the tweets of the user to the index again",
+			dependencies: func(ctx context.Context, d *dependencies) {
+				d.tweets.On("GetUserDocuments", ctx, "u1", (*search.Hit)(nil), 1000).Return([]search.Document{doc}, nil)
+				d.index.On("Apply", ctx, []search.Event{{Type: search.TweetCreated, Tweet: doc}}).Return(nil)
+				d.tweets.On("GetUserDocuments", ctx, "u1", &search.Hit{ID: "t1", CreatedAt: createdAt}, 1000).Return([]search.Document{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := twcontext.NewTestContext()
+			d := newDependencies(t)
+			tt.dependencies(ctx, d)
+
+			uc := search.NewSearchUseCase(d.index, d.tweets, d.users)
+			assert.Equal(t, tt.err, uc.UserReactivated(ctx, "u1"))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package stream
+
+import "sync"
+
+// Connection is the event feed of a single client: the missed events it
+// asked to resume from, followed by live events.
+type Connection struct {
+	sub       Subscription
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newConnection(sub Subscription, missed []Event) *Connection {
+	c := &Connection{
+		sub:    sub,
+		events: make(chan Event),
+		done:   make(chan struct{}),
+	}
+	go c.pump(missed)
+
+	return c
+}
+
+// Events is closed when the connection is closed or the subscription ends;
+// Err then tells whether it ended because the client fell behind.
+func (c *Connection) Events() <-chan Event {
+	return c.events
+}
+
+func (c *Connection) Err() error {
+	return c.sub.Err()
+}
+
+func (c *Connection) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.sub.Close()
+	})
+}
+
+func (c *Connection) pump(missed []Event) {
+	defer close(c.events)
+
+	replayed := make(map[string]bool, len(missed))
+	for _, event := range missed {
+		replayed[event.ID] = true
+		if !c.send(event) {
+			return
+		}
+	}
+
+	for event := range c.sub.Events() {
+		if replayed[event.ID] {
+			continue
+		}
+		if !c.send(event) {
+			return
+		}
+	}
+}
+
+func (c *Connection) send(event Event) bool {
+	select {
+	case c.events <- event:
+		return true
+	case <-c.done:
+		return false
+	}
+}
@@ -0,0 +1,227 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// Session is a WebSocket client that subscribes to topics at will. Events of
+// every subscribed topic are merged into Events until the session is closed
+// or one of its subscriptions falls behind.
+type Session struct {
+	uc     *usecase
+	userID string
+	events chan Event
+	done   chan struct{}
+
+	mu     sync.Mutex
+	subs   map[string]Subscription
+	closed bool
+	err    error
+}
+
+func newSession(uc *usecase, userID string) *Session {
+	return &Session{
+		uc:     uc,
+		userID: userID,
+		events: make(chan Event),
+		done:   make(chan struct{}),
+		subs:   make(map[string]Subscription),
+	}
+}
+
+func (s *Session) Events() <-chan Event {
+	return s.events
+}
+
+// Done is closed when the session ends; Err then tells why.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Subscribe resolves and subscribes to the given topics and returns their
+// broker names. Either every topic is subscribed or none is.
+func (s *Session) Subscribe(ctx context.Context, names []string) ([]string, error) {
+	topics := make([]string, 0, len(names))
+	for _, name := range names {
+		topic, err := s.uc.resolveTopic(ctx, s.userID, name)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrSessionClosed
+	}
+
+	var added []string
+	for _, topic := range topics {
+		if _, ok := s.subs[topic]; !ok && !slices.Contains(added, topic) {
+			added = append(added, topic)
+		}
+	}
+	if len(s.subs)+len(added) > MaxSessionTopics {
+		return nil, ErrTooManyTopics
+	}
+
+	subs := make([]Subscription, 0, len(added))
+	for _, topic := range added {
+		sub, err := s.uc.broker.Subscribe(ctx, []string{topic})
+		if err != nil {
+			for _, sub := range subs {
+				sub.Close()
+			}
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+	for i, topic := range added {
+		s.subs[topic] = subs[i]
+		go s.forward(detachedCtx, topic, subs[i])
+	}
+
+	return topics, nil
+}
+
+// Unsubscribe stops following the given topics; unknown topics are ignored.
+func (s *Session) Unsubscribe(names []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed []string
+	for _, name := range names {
+		topic := name
+		if name == "home" {
+			topic = HomeTopic(s.userID)
+		}
+
+		sub, ok := s.subs[topic]
+		if !ok {
+			continue
+		}
+		delete(s.subs, topic)
+		sub.Close()
+		removed = append(removed, topic)
+	}
+
+	return removed
+}
+
+// Typing tells the other subscribers of a conversation that the user is
+// typing. The session must be subscribed to the conversation, and the
+// conversation is checked again in case its author blocked the user or
+// turned protected since.
+func (s *Session) Typing(ctx context.Context, topic string) error {
+	tweetID, ok := strings.CutPrefix(topic, conversationTopicPrefix)
+	if !ok {
+		return fmt.Errorf("%w: typing is only supported in conversations", ErrInvalidTopic)
+	}
+
+	s.mu.Lock()
+	_, subscribed := s.subs[topic]
+	s.mu.Unlock()
+	if !subscribed {
+		return ErrNotSubscribed
+	}
+
+	if err := s.uc.checkConversationVisible(ctx, s.userID, tweetID); err != nil {
+		return err
+	}
+
+	return s.broadcast(ctx, topic, EventTyping, TypingPayload{UserID: s.userID})
+}
+
+// SetPresence announces the user's status to the subscribers of its topic.
+func (s *Session) SetPresence(ctx context.Context, status string) error {
+	if status != PresenceOnline && status != PresenceAway {
+		return ErrInvalidStatus
+	}
+
+	return s.broadcast(ctx, UserTopic(s.userID), EventPresence, PresencePayload{UserID: s.userID, Status: status})
+}
+
+// Close ends the session and announces the user as offline.
+func (s *Session) Close(ctx context.Context) {
+	if !s.end(nil) {
+		return
+	}
+
+	if err := s.broadcast(ctx, UserTopic(s.userID), EventPresence, PresencePayload{UserID: s.userID, Status: PresenceOffline}); err != nil {
+		twcontext.Logger(ctx).WithError(err).Warn("failed to announce offline presence")
+	}
+}
+
+// end closes every subscription and records why the session ended. It
+// reports whether this call ended the session.
+func (s *Session) end(err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	s.err = err
+	close(s.done)
+
+	for topic, sub := range s.subs {
+		delete(s.subs, topic)
+		sub.Close()
+	}
+
+	return true
+}
+
+// forward skips the events the user may no longer see, see checkDelivery.
+func (s *Session) forward(ctx context.Context, topic string, sub Subscription) {
+	for event := range sub.Events() {
+		if err := s.uc.checkDelivery(ctx, s.userID, topic, event); err != nil {
+			if !errors.Is(err, ErrTopicForbidden) {
+				twcontext.Logger(ctx).WithError(err).WithField("topic", topic).Warn("failed to check event visibility, skipping it")
+			}
+			continue
+		}
+
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+
+	if err := sub.Err(); err != nil {
+		s.end(err)
+	}
+}
+
+func (s *Session) broadcast(ctx context.Context, topic string, eventType EventType, payload any) error {
+	event, err := newEvent(eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	if err := s.uc.broker.Broadcast(ctx, []string{topic}, event); err != nil {
+		return fmt.Errorf("failed to broadcast %s event: %w", eventType, err)
+	}
+
+	return nil
+}
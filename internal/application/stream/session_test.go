@@ -0,0 +1,385 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	sessionUserID = "u1"
+	otherUserID   = "5a0a4f0e-3c1e-4a51-9d39-2f6c3c9b8e11"
+	tweetID       = "9b2d3e55-1f0a-4c7e-8a65-7d1e0f6b2c44"
+)
+
+func presenceEvent(status string) stream.Event {
+	return stream.Event{
+		Type: stream.EventPresence,
+		Data: json.RawMessage(fmt.Sprintf(`{"user_id":"%s","status":"%s"}`, sessionUserID, status)),
+	}
+}
+
+// openSession opens a session for sessionUserID expecting the online
+// presence announcement.
+func openSession(t *testing.T, ctx context.Context, d *dependencies) *stream.Session {
+	d.userFinder.On("ExistsByID", ctx, sessionUserID).Return(true, nil).Once()
+	d.broker.On("Broadcast", ctx, []string{"user:u1"}, presenceEvent(stream.PresenceOnline)).Return(nil).Once()
+
+	session, err := stream.NewStreamUseCase(d.userFinder, d.tweetFinder, d.broker).OpenSession(ctx, sessionUserID)
+	assert.NoError(t, err)
+
+	return session
+}
+
+func Test_usecase_OpenSession(t *testing.T) {
+	t.Run("should return error if user does not exist", func(t *testing.T) {
+		d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+		ctx := twcontext.NewTestContext()
+		d.userFinder.On("ExistsByID", ctx, sessionUserID).Return(false, nil)
+
+		session, err := stream.NewStreamUseCase(d.userFinder, d.tweetFinder, d.broker).OpenSession(ctx, sessionUserID)
+
+		assert.Nil(t, session)
+		assert.Equal(t, user.ErrUserNotFound, err)
+	})
+
+	t.Run("should announce the user online and offline when closed", func(t *testing.T) {
+		d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+		ctx := twcontext.NewTestContext()
+		session := openSession(t, ctx, d)
+
+		d.broker.On("Broadcast", ctx, []string{"user:u1"}, presenceEvent(stream.PresenceOffline)).Return(nil).Once()
+		session.Close(ctx)
+		session.Close(ctx)
+
+		_, err := session.Subscribe(ctx, []string{"home"})
+		assert.Equal(t, stream.ErrSessionClosed, err)
+	})
+}
+
+func Test_Session_Subscribe(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		topics []string
+	}
+
+	type output struct {
+		topics []string
+		err    error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if the topic kind is unknown",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"home", "everything:now"},
+			},
+			output:       output{err: stream.ErrInvalidTopic},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should return error if the hashtag is malformed",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"hashtag:go lang"},
+			},
+			output:       output{err: stream.ErrInvalidTopic},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should not allow subscribing to another user's home",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"home:" + otherUserID},
+			},
+			output:       output{err: stream.ErrInvalidTopic},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should not allow following a user with a block in between",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"user:" + otherUserID},
+			},
+			output: output{err: stream.ErrTopicForbidden},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, otherUserID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, sessionUserID, otherUserID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should not allow following a protected user without an approved follow",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"user:" + otherUserID},
+			},
+			output: output{err: stream.ErrTopicForbidden},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, otherUserID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, sessionUserID, otherUserID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, otherUserID).Return(true, nil)
+				d.userFinder.On("IsFollowing", in.ctx, sessionUserID, otherUserID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should not allow following a conversation started by a user with a block in between",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"conversation:" + tweetID},
+			},
+			output: output{err: stream.ErrTopicForbidden},
+			dependencies: func(in input, d *dependencies) {
+				d.tweetFinder.On("GetTweetByID", in.ctx, tweetID).Return(&tweet.Tweet{ID: tweetID, UserID: otherUserID}, nil)
+				d.userFinder.On("ExistsByID", in.ctx, otherUserID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, sessionUserID, otherUserID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should not allow following the conversation of a missing tweet",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"conversation:" + tweetID},
+			},
+			output: output{err: stream.ErrTopicForbidden},
+			dependencies: func(in input, d *dependencies) {
+				d.tweetFinder.On("GetTweetByID", in.ctx, tweetID).Return(nil, tweet.ErrTweetNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should return error if broker.Subscribe returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"home"},
+			},
+			output: output{err: fmt.Errorf("failed to subscribe: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.broker.On("Subscribe", in.ctx, []string{"home:u1"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should subscribe once to each resolved topic",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				topics: []string{"home", "user:" + otherUserID, "hashtag:Go", "hashtag:go", "conversation:" + tweetID},
+			},
+			output: output{topics: []string{"home:u1", "user:" + otherUserID, "hashtag:go", "hashtag:go", "conversation:" + tweetID}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, otherUserID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, sessionUserID, otherUserID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, otherUserID).Return(true, nil)
+				d.userFinder.On("IsFollowing", in.ctx, sessionUserID, otherUserID).Return(true, nil)
+				d.tweetFinder.On("GetTweetByID", in.ctx, tweetID).Return(&tweet.Tweet{ID: tweetID, UserID: otherUserID}, nil)
+				for _, topic := range []string{"home:u1", "user:" + otherUserID, "hashtag:go", "conversation:" + tweetID} {
+					sub := mocks.NewSubscription(t)
+					sub.On("Events").Return((<-chan stream.Event)(make(chan stream.Event))).Maybe()
+					sub.On("Close").Return()
+					d.broker.On("Subscribe", in.ctx, []string{topic}).Return(sub, nil).Once()
+				}
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:  mocks.NewUserFinder(t),
+				tweetFinder: mocks.NewTweetFinder(t),
+				broker:      mocks.NewBroker(t),
+			}
+			session := openSession(t, tt.input.ctx, d)
+			tt.dependencies(tt.input, d)
+
+			var actual output
+			actual.topics, actual.err = session.Subscribe(tt.input.ctx, tt.input.topics)
+
+			d.broker.On("Broadcast", mock.Anything, []string{"user:u1"}, presenceEvent(stream.PresenceOffline)).Return(nil).Once()
+			session.Close(tt.input.ctx)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_Session_Events(t *testing.T) {
+	t.Run("should deliver events and end the session when a subscription falls behind", func(t *testing.T) {
+		d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+		ctx := twcontext.NewTestContext()
+		session := openSession(t, ctx, d)
+
+		live := make(chan stream.Event, 1)
+		live <- stream.Event{Topic: "home:u1", Type: stream.EventTweet}
+		close(live)
+		sub := mocks.NewSubscription(t)
+		sub.On("Events").Return((<-chan stream.Event)(live))
+		sub.On("Err").Return(stream.ErrSlowConsumer)
+		sub.On("Close").Return()
+		d.broker.On("Subscribe", ctx, []string{"home:u1"}).Return(sub, nil)
+
+		_, err := session.Subscribe(ctx, []string{"home"})
+		assert.NoError(t, err)
+
+		select {
+		case event := <-session.Events():
+			assert.Equal(t, stream.Event{Topic: "home:u1", Type: stream.EventTweet}, event)
+		case <-time.After(2 * time.Second):
+			t.Fatal("event was not delivered in time")
+		}
+
+		select {
+		case <-session.Done():
+			assert.Equal(t, stream.ErrSlowConsumer, session.Err())
+		case <-time.After(2 * time.Second):
+			t.Fatal("session did not end in time")
+		}
+	})
+}
+
+func Test_Session_Events_visibility(t *testing.T) {
+	t.Run("should skip the events of a user blocked after subscribing", func(t *testing.T) {
+		d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+		ctx := twcontext.NewTestContext()
+		session := openSession(t, ctx, d)
+		topic := "user:" + otherUserID
+
+		d.userFinder.On("ExistsByID", mock.Anything, otherUserID).Return(true, nil)
+		d.userFinder.On("IsProtected", mock.Anything, otherUserID).Return(false, nil)
+		d.userFinder.On("HasBlockBetween", ctx, sessionUserID, otherUserID).Return(false, nil).Once()
+
+		blocked := stream.Event{Topic: topic, Type: stream.EventTweet, Data: json.RawMessage(`{"id":"t1","user_id":"` + otherUserID + `"}`)}
+		live := make(chan stream.Event, 1)
+		live <- blocked
+		close(live)
+		sub := mocks.NewSubscription(t)
+		sub.On("Events").Return((<-chan stream.Event)(live))
+		sub.On("Err").Return(stream.ErrSlowConsumer)
+		sub.On("Close").Return()
+		d.broker.On("Subscribe", ctx, []string{topic}).Return(sub, nil)
+
+		detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+		d.userFinder.On("HasBlockBetween", detachedCtx, sessionUserID, otherUserID).Return(true, nil).Once()
+
+		_, err := session.Subscribe(ctx, []string{topic})
+		assert.NoError(t, err)
+
+		select {
+		case event := <-session.Events():
+			t.Fatalf("event of a blocked user was delivered: %v", event)
+		case <-session.Done():
+			assert.Equal(t, stream.ErrSlowConsumer, session.Err())
+		case <-time.After(2 * time.Second):
+			t.Fatal("session did not end in time")
+		}
+	})
+}
+
+func Test_Session_Typing(t *testing.T) {
+	conversation := "conversation:" + tweetID
+
+	t.Run("should only allow typing in conversations", func(t *testing.T) {
+		d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+		ctx := twcontext.NewTestContext()
+		session := openSession(t, ctx, d)
+
+		assert.ErrorIs(t, session.Typing(ctx, "home:u1"), stream.ErrInvalidTopic)
+		assert.ErrorIs(t, session.Typing(ctx, conversation), stream.ErrNotSubscribed)
+	})
+
+	t.Run("should broadcast typing to a subscribed conversation", func(t *testing.T) {
+		d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+		ctx := twcontext.NewTestContext()
+		session := openSession(t, ctx, d)
+
+		d.tweetFinder.On("GetTweetByID", ctx, tweetID).Return(&tweet.Tweet{ID: tweetID, UserID: sessionUserID}, nil)
+		sub := mocks.NewSubscription(t)
+		sub.On("Events").Return((<-chan stream.Event)(make(chan stream.Event))).Maybe()
+		d.broker.On("Subscribe", ctx, []string{conversation}).Return(sub, nil)
+		_, err := session.Subscribe(ctx, []string{conversation})
+		assert.NoError(t, err)
+
+		d.broker.On("Broadcast", ctx, []string{conversation}, stream.Event{
+			Type: stream.EventTyping,
+			Data: json.RawMessage(`{"user_id":"u1"}`),
+		}).Return(nil)
+		assert.NoError(t, session.Typing(ctx, conversation))
+
+		sub.On("Close").Return()
+		assert.Equal(t, []string{conversation}, session.Unsubscribe([]string{conversation, "home"}))
+	})
+
+	t.Run("should not broadcast typing once the author of the conversation blocked the user", func(t *testing.T) {
+		d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+		ctx := twcontext.NewTestContext()
+		session := openSession(t, ctx, d)
+
+		d.tweetFinder.On("GetTweetByID", ctx, tweetID).Return(&tweet.Tweet{ID: tweetID, UserID: otherUserID}, nil)
+		d.userFinder.On("ExistsByID", ctx, otherUserID).Return(true, nil)
+		d.userFinder.On("IsProtected", ctx, otherUserID).Return(false, nil)
+		d.userFinder.On("HasBlockBetween", ctx, sessionUserID, otherUserID).Return(false, nil).Once()
+		sub := mocks.NewSubscription(t)
+		sub.On("Events").Return((<-chan stream.Event)(make(chan stream.Event))).Maybe()
+		sub.On("Close").Return()
+		d.broker.On("Subscribe", ctx, []string{conversation}).Return(sub, nil)
+		_, err := session.Subscribe(ctx, []string{conversation})
+		assert.NoError(t, err)
+
+		d.userFinder.On("HasBlockBetween", ctx, sessionUserID, otherUserID).Return(true, nil).Once()
+		assert.ErrorIs(t, session.Typing(ctx, conversation), stream.ErrTopicForbidden)
+
+		d.broker.On("Broadcast", ctx, []string{"user:u1"}, presenceEvent(stream.PresenceOffline)).Return(nil).Once()
+		session.Close(ctx)
+	})
+}
+
+func Test_Session_SetPresence(t *testing.T) {
+	d := &dependencies{userFinder: mocks.NewUserFinder(t), tweetFinder: mocks.NewTweetFinder(t), broker: mocks.NewBroker(t)}
+	ctx := twcontext.NewTestContext()
+	session := openSession(t, ctx, d)
+
+	assert.Equal(t, stream.ErrInvalidStatus, session.SetPresence(ctx, stream.PresenceOffline))
+
+	d.broker.On("Broadcast", ctx, []string{"user:u1"}, presenceEvent(stream.PresenceAway)).Return(nil)
+	assert.NoError(t, session.SetPresence(ctx, stream.PresenceAway))
+}
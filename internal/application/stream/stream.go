@@ -0,0 +1,158 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+)
+
+var (
+	ErrInvalidEventID = errors.New("invalid event ID")
+	ErrSlowConsumer   = errors.New("connection is not keeping up with its events")
+	ErrInvalidTopic   = errors.New("invalid topic")
+	ErrTopicForbidden = errors.New("not allowed to subscribe to topic")
+	ErrTooManyTopics  = errors.New("too many topics")
+	ErrNotSubscribed  = errors.New("not subscribed to topic")
+	ErrInvalidStatus  = errors.New("invalid presence status")
+	ErrSessionClosed  = errors.New("session is closed")
+)
+
+// EventType is the kind of payload an Event carries.
+type EventType string
+
+const (
+	EventTweet        EventType = "tweet"
+	EventNotification EventType = "notification"
+	EventMessage      EventType = "message"
+	EventTyping       EventType = "typing"
+	EventPresence     EventType = "presence"
+)
+
+// Presence statuses a client can announce. PresenceOffline is announced on
+// its behalf when its last session closes.
+const (
+	PresenceOnline  = "online"
+	PresenceAway    = "away"
+	PresenceOffline = "offline"
+)
+
+// MaxSessionTopics caps the topics a single WebSocket session can follow.
+const MaxSessionTopics = 50
+
+type (
+	// Event is a message pushed to connected clients. ID is assigned by the
+	// broker when the event is published and is unique within a topic, so a
+	// client can resume after the last ID it saw. Broadcast events have no ID.
+	// Topic is set on delivery.
+	Event struct {
+		ID    string
+		Topic string
+		Type  EventType
+		Data  json.RawMessage
+	}
+
+	TweetPayload struct {
+		ID        string    `json:"id"`
+		UserID    string    `json:"user_id"`
+		Content   string    `json:"content"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	NotificationPayload struct {
+		ID        string    `json:"id"`
+		Type      string    `json:"type"`
+		ActorID   string    `json:"actor_id"`
+		TweetID   string    `json:"tweet_id,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	MessagePayload struct {
+		ID             string    `json:"id"`
+		ConversationID string    `json:"conversation_id"`
+		SenderID       string    `json:"sender_id"`
+		Content        string    `json:"content"`
+		CreatedAt      time.Time `json:"created_at"`
+	}
+
+	TypingPayload struct {
+		UserID string `json:"user_id"`
+	}
+
+	PresencePayload struct {
+		UserID string `json:"user_id"`
+		Status string `json:"status"`
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		ExistsByID(ctx context.Context, id string) (bool, error)
+		IsProtected(ctx context.Context, id string) (bool, error)
+		IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+		HasBlockBetween(ctx context.Context, userID, otherID string) (bool, error)
+		GetFollowers(ctx context.Context, id string) ([]string, error)
+	}
+
+	//go:generate mockery --name=TweetFinder --output=mocks --outpkg=mocks --filename=tweet_finder.go
+	TweetFinder interface {
+		GetTweetByID(ctx context.Context, id string) (*tweet.Tweet, error)
+	}
+
+	// Broker fans events out to every API instance with subscribers on a
+	// topic, and keeps a short history per topic for resuming.
+	//
+	//go:generate mockery --name=Broker --output=mocks --outpkg=mocks --filename=broker.go
+	Broker interface {
+		Publish(ctx context.Context, topics []string, event Event) error
+		// Broadcast delivers event to the current subscribers of topics
+		// without keeping it in the history.
+		Broadcast(ctx context.Context, topics []string, event Event) error
+		Subscribe(ctx context.Context, topics []string) (Subscription, error)
+		// History returns the retained events of topic published after
+		// afterID, oldest first.
+		History(ctx context.Context, topic, afterID string) ([]Event, error)
+	}
+
+	// Subscription delivers the events of its topics. Events is closed when
+	// the subscription is closed, or when the subscriber falls behind, in
+	// which case Err returns ErrSlowConsumer.
+	//
+	//go:generate mockery --name=Subscription --output=mocks --outpkg=mocks --filename=subscription.go
+	Subscription interface {
+		Events() <-chan Event
+		Err() error
+		Close()
+	}
+)
+
+const (
+	homeTopicPrefix         = "home:"
+	userTopicPrefix         = "user:"
+	hashtagTopicPrefix      = "hashtag:"
+	conversationTopicPrefix = "conversation:"
+)
+
+// HomeTopic carries the events shown to userID: tweets of the users it
+// follows and its notifications.
+func HomeTopic(userID string) string {
+	return homeTopicPrefix + userID
+}
+
+// UserTopic carries the tweets and presence of userID.
+func UserTopic(userID string) string {
+	return userTopicPrefix + userID
+}
+
+// HashtagTopic carries the public tweets tagged with tag.
+func HashtagTopic(tag string) string {
+	return hashtagTopicPrefix + strings.ToLower(tag)
+}
+
+// ConversationTopic carries the typing signals of the conversation started
+// by tweetID.
+func ConversationTopic(tweetID string) string {
+	return conversationTopicPrefix + tweetID
+}
@@ -0,0 +1,167 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+)
+
+// hashtagPattern matches #tag where the # is not part of a word.
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])#([\p{L}\p{N}_]{1,100})`)
+
+// resolveTopic turns a topic requested by userID into a broker topic and
+// checks that userID may follow it. "home" is a shorthand for the user's own
+// home topic.
+func (uc *usecase) resolveTopic(ctx context.Context, userID, name string) (string, error) {
+	if name == "home" || name == HomeTopic(userID) {
+		return HomeTopic(userID), nil
+	}
+
+	kind, value, found := strings.Cut(name, ":")
+	if !found || value == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTopic, name)
+	}
+
+	switch kind + ":" {
+	case userTopicPrefix:
+		if _, err := uuid.Parse(value); err != nil {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTopic, name)
+		}
+		if err := uc.checkUserVisible(ctx, userID, value); err != nil {
+			return "", err
+		}
+		return UserTopic(value), nil
+	case hashtagTopicPrefix:
+		tags := extractHashtags("#" + value)
+		if len(tags) != 1 || tags[0] != strings.ToLower(value) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTopic, name)
+		}
+		return HashtagTopic(value), nil
+	case conversationTopicPrefix:
+		if _, err := uuid.Parse(value); err != nil {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTopic, name)
+		}
+		if err := uc.checkConversationVisible(ctx, userID, value); err != nil {
+			return "", err
+		}
+		return ConversationTopic(value), nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidTopic, name)
+}
+
+// checkUserVisible applies the same rules as reading the user's tweets:
+// protected accounts are only visible to approved followers, and a block in
+// either direction hides the account.
+func (uc *usecase) checkUserVisible(ctx context.Context, viewerID, userID string) error {
+	if viewerID == userID {
+		return nil
+	}
+
+	if exists, err := uc.userFinder.ExistsByID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	} else if !exists {
+		return fmt.Errorf("%w: user not found", ErrTopicForbidden)
+	}
+
+	if blocked, err := uc.userFinder.HasBlockBetween(ctx, viewerID, userID); err != nil {
+		return fmt.Errorf("error checking block relationship: %w", err)
+	} else if blocked {
+		return fmt.Errorf("%w: user is blocked", ErrTopicForbidden)
+	}
+
+	protected, err := uc.userFinder.IsProtected(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	}
+	if !protected {
+		return nil
+	}
+
+	if following, err := uc.userFinder.IsFollowing(ctx, viewerID, userID); err != nil {
+		return fmt.Errorf("error checking follow relationship: %w", err)
+	} else if !following {
+		return fmt.Errorf("%w: account is protected", ErrTopicForbidden)
+	}
+
+	return nil
+}
+
+// checkConversationVisible applies checkUserVisible to the author of the
+// tweet that started the conversation.
+func (uc *usecase) checkConversationVisible(ctx context.Context, viewerID, tweetID string) error {
+	root, err := uc.tweetFinder.GetTweetByID(ctx, tweetID)
+	if errors.Is(err, tweet.ErrTweetNotFound) {
+		return fmt.Errorf("%w: tweet not found", ErrTopicForbidden)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get tweet: %w", err)
+	}
+
+	return uc.checkUserVisible(ctx, viewerID, root.UserID)
+}
+
+// checkDelivery applies the subscription rules again to an event about to be
+// delivered on topic, since a block, an unfollow or an account turning
+// protected after subscribing must also stop its events. Both the owner of
+// the topic and the user the event is about are checked. The home topic only
+// carries what was already addressed to the user.
+func (uc *usecase) checkDelivery(ctx context.Context, viewerID, topic string, event Event) error {
+	if topic == HomeTopic(viewerID) {
+		return nil
+	}
+
+	if tweetID, ok := strings.CutPrefix(topic, conversationTopicPrefix); ok {
+		if err := uc.checkConversationVisible(ctx, viewerID, tweetID); err != nil {
+			return err
+		}
+	}
+
+	var userIDs []string
+	if id, ok := strings.CutPrefix(topic, userTopicPrefix); ok {
+		userIDs = append(userIDs, id)
+	}
+
+	if len(event.Data) > 0 {
+		var payload struct {
+			UserID string `json:"user_id"`
+		}
+		if err := json.Unmarshal(event.Data, &payload); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+		}
+		if payload.UserID != "" && !slices.Contains(userIDs, payload.UserID) {
+			userIDs = append(userIDs, payload.UserID)
+		}
+	}
+
+	for _, id := range userIDs {
+		if err := uc.checkUserVisible(ctx, viewerID, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractHashtags returns the distinct hashtags in content, lowercased, in
+// order of appearance.
+func extractHashtags(content string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, m := range hashtagPattern.FindAllStringSubmatch(content, -1) {
+		tag := strings.ToLower(m[1])
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,179 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+)
+
+type usecase struct {
+	userFinder  UserFinder
+	tweetFinder TweetFinder
+	broker      Broker
+}
+
+func NewStreamUseCase(userFinder UserFinder, tweetFinder TweetFinder, broker Broker) *usecase {
+	return &usecase{userFinder: userFinder, tweetFinder: tweetFinder, broker: broker}
+}
+
+// PublishTweet pushes t to the home stream of every follower of its author,
+// to the author's topic and, unless the account is protected, to the topics
+// of its hashtags.
+func (uc *usecase) PublishTweet(ctx context.Context, t tweet.Tweet) error {
+	event, err := newEvent(EventTweet, TweetPayload{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Content:   t.Content,
+		CreatedAt: t.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	followers, err := uc.userFinder.GetFollowers(ctx, t.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	if len(followers) > 0 {
+		topics := make([]string, len(followers))
+		for i, followerID := range followers {
+			topics[i] = HomeTopic(followerID)
+		}
+
+		if err := uc.broker.Publish(ctx, topics, event); err != nil {
+			return fmt.Errorf("failed to publish tweet event: %w", err)
+		}
+	}
+
+	protected, err := uc.userFinder.IsProtected(ctx, t.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", t.UserID, err)
+	}
+
+	topics := []string{UserTopic(t.UserID)}
+	if !protected {
+		for _, tag := range extractHashtags(t.Content) {
+			topics = append(topics, HashtagTopic(tag))
+		}
+	}
+
+	if err := uc.broker.Broadcast(ctx, topics, event); err != nil {
+		return fmt.Errorf("failed to broadcast tweet event: %w", err)
+	}
+
+	return nil
+}
+
+// PublishNotification pushes n to the home stream of its recipient.
+func (uc *usecase) PublishNotification(ctx context.Context, n notification.Notification) error {
+	event, err := newEvent(EventNotification, NotificationPayload{
+		ID:        n.ID,
+		Type:      string(n.Type),
+		ActorID:   n.ActorID,
+		TweetID:   n.TweetID,
+		CreatedAt: n.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := uc.broker.Publish(ctx, []string{HomeTopic(n.RecipientID)}, event); err != nil {
+		return fmt.Errorf("failed to publish notification event: %w", err)
+	}
+
+	return nil
+}
+
+// PublishMessage pushes a direct message to the home stream of the given
+// participants.
+func (uc *usecase) PublishMessage(ctx context.Context, recipientIDs []string, m dm.Message) error {
+	event, err := newEvent(EventMessage, MessagePayload{
+		ID:             m.ID,
+		ConversationID: m.ConversationID,
+		SenderID:       m.SenderID,
+		Content:        m.Content,
+		CreatedAt:      m.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	topics := make([]string, len(recipientIDs))
+	for i, id := range recipientIDs {
+		topics[i] = HomeTopic(id)
+	}
+
+	if err := uc.broker.Publish(ctx, topics, event); err != nil {
+		return fmt.Errorf("failed to publish message event: %w", err)
+	}
+
+	return nil
+}
+
+// Connect subscribes userID to its home stream. When lastEventID is set, the
+// events published after it that are still retained are delivered first.
+func (uc *usecase) Connect(ctx context.Context, userID, lastEventID string) (*Connection, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	if exists, err := uc.userFinder.ExistsByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	} else if !exists {
+		return nil, user.ErrUserNotFound
+	}
+
+	// Subscribe before reading the history so that nothing published in
+	// between is lost; events present in both are delivered once.
+	topic := HomeTopic(userID)
+	sub, err := uc.broker.Subscribe(ctx, []string{topic})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	var missed []Event
+	if lastEventID != "" {
+		if missed, err = uc.broker.History(ctx, topic, lastEventID); err != nil {
+			sub.Close()
+			return nil, fmt.Errorf("failed to get missed events: %w", err)
+		}
+	}
+
+	return newConnection(sub, missed), nil
+}
+
+// OpenSession starts a WebSocket session for userID and announces it as
+// online.
+func (uc *usecase) OpenSession(ctx context.Context, userID string) (*Session, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	if exists, err := uc.userFinder.ExistsByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	} else if !exists {
+		return nil, user.ErrUserNotFound
+	}
+
+	s := newSession(uc, userID)
+	if err := s.SetPresence(ctx, PresenceOnline); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func newEvent(eventType EventType, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	return Event{Type: eventType, Data: data}, nil
+}
@@ -0,0 +1,368 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/dm"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/notification"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/stream/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	userFinder  *mocks.UserFinder
+	tweetFinder *mocks.TweetFinder
+	broker      *mocks.Broker
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func Test_usecase_PublishTweet(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		tweet tweet.Tweet
+	}
+
+	type output struct {
+		err error
+	}
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if userFinder.GetFollowers returns error",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1"},
+			},
+			output: output{err: fmt.Errorf("failed to get followers: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetFollowers", in.ctx, "u1").Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if broker.Publish returns error",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi", CreatedAt: createdAt},
+			},
+			output: output{err: fmt.Errorf("failed to publish tweet event: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetFollowers", in.ctx, "u1").Return([]string{"u2"}, nil)
+				d.broker.On("Publish", in.ctx, []string{"home:u2"}, stream.Event{
+					Type: stream.EventTweet,
+					Data: json.RawMessage(`{"id":"t1","user_id":"u1","content":"hi","created_at":"2025-01-02T03:04:05Z"}`),
+				}).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should only broadcast to the author topic if the author has no followers",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi", CreatedAt: createdAt},
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetFollowers", in.ctx, "u1").Return([]string{}, nil)
+				d.userFinder.On("IsProtected", in.ctx, "u1").Return(false, nil)
+				d.broker.On("Broadcast", in.ctx, []string{"user:u1"}, stream.Event{
+					Type: stream.EventTweet,
+					Data: json.RawMessage(`{"id":"t1","user_id":"u1","content":"hi","created_at":"2025-01-02T03:04:05Z"}`),
+				}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should publish to followers and broadcast to the author and hashtag topics",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "#Go rocks #go #Café a#b", CreatedAt: createdAt},
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				event := stream.Event{
+					Type: stream.EventTweet,
+					Data: json.RawMessage(`{"id":"t1","user_id":"u1","content":"#Go rocks #go #Café a#b","created_at":"2025-01-02T03:04:05Z"}`),
+				}
+				d.userFinder.On("GetFollowers", in.ctx, "u1").Return([]string{"u2", "u3"}, nil)
+				d.broker.On("Publish", in.ctx, []string{"home:u2", "home:u3"}, event).Return(nil)
+				d.userFinder.On("IsProtected", in.ctx, "u1").Return(false, nil)
+				d.broker.On("Broadcast", in.ctx, []string{"user:u1", "hashtag:go", "hashtag:café"}, event).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should not broadcast hashtags of protected accounts",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "#go", CreatedAt: createdAt},
+			},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetFollowers", in.ctx, "u1").Return([]string{}, nil)
+				d.userFinder.On("IsProtected", in.ctx, "u1").Return(true, nil)
+				d.broker.On("Broadcast", in.ctx, []string{"user:u1"}, mock.Anything).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:  mocks.NewUserFinder(t),
+				tweetFinder: mocks.NewTweetFinder(t),
+				broker:      mocks.NewBroker(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := stream.NewStreamUseCase(d.userFinder, d.tweetFinder, d.broker)
+			var actual output
+			actual.err = uc.PublishTweet(tt.input.ctx, tt.input.tweet)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_PublishNotification(t *testing.T) {
+	d := &dependencies{
+		userFinder:  mocks.NewUserFinder(t),
+		tweetFinder: mocks.NewTweetFinder(t),
+		broker:      mocks.NewBroker(t),
+	}
+	ctx := twcontext.NewTestContext()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	d.broker.On("Publish", ctx, []string{"home:u2"}, stream.Event{
+		Type: stream.EventNotification,
+		Data: json.RawMessage(`{"id":"n1","type":"follow","actor_id":"u1","created_at":"2025-01-02T03:04:05Z"}`),
+	}).Return(nil)
+
+	uc := stream.NewStreamUseCase(d.userFinder, d.tweetFinder, d.broker)
+	err := uc.PublishNotification(ctx, notification.Notification{
+		ID:          "n1",
+		RecipientID: "u2",
+		ActorID:     "u1",
+		Type:        notification.TypeFollow,
+		CreatedAt:   createdAt,
+	})
+
+	assert.NoError(t, err)
+}
+
+func Test_usecase_PublishMessage(t *testing.T) {
+	d := &dependencies{
+		userFinder:  mocks.NewUserFinder(t),
+		tweetFinder: mocks.NewTweetFinder(t),
+		broker:      mocks.NewBroker(t),
+	}
+	ctx := twcontext.NewTestContext()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	d.broker.On("Publish", ctx, []string{"home:u1", "home:u2"}, stream.Event{
+		Type: stream.EventMessage,
+		Data: json.RawMessage(`{"id":"m1","conversation_id":"c1","sender_id":"u1","content":"hi","created_at":"2025-01-02T03:04:05Z"}`),
+	}).Return(nil)
+
+	uc := stream.NewStreamUseCase(d.userFinder, d.tweetFinder, d.broker)
+	err := uc.PublishMessage(ctx, []string{"u1", "u2"}, dm.Message{
+		ID:             "m1",
+		ConversationID: "c1",
+		SenderID:       "u1",
+		Content:        "hi",
+		CreatedAt:      createdAt,
+	})
+
+	assert.NoError(t, err)
+}
+
+func Test_usecase_Connect(t *testing.T) {
+	type input struct {
+		ctx         context.Context
+		userID      string
+		lastEventID string
+	}
+
+	type output struct {
+		events []stream.Event
+		err    error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies, sub *mocks.Subscription)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if userID is empty",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies, sub *mocks.Subscription) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies, sub *mocks.Subscription) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if broker.Subscribe returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+			},
+			output: output{err: fmt.Errorf("failed to subscribe: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies, sub *mocks.Subscription) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.broker.On("Subscribe", in.ctx, []string{"home:u1"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should close the subscription if the last event ID is invalid",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				userID:      "u1",
+				lastEventID: "bogus",
+			},
+			output: output{err: stream.ErrInvalidEventID},
+			dependencies: func(in input, d *dependencies, sub *mocks.Subscription) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.broker.On("Subscribe", in.ctx, []string{"home:u1"}).Return(sub, nil)
+				d.broker.On("History", in.ctx, "home:u1", in.lastEventID).Return(nil, stream.ErrInvalidEventID)
+				sub.On("Close").Return()
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name: "should deliver live events",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+			},
+			output: output{events: []stream.Event{{ID: "1-0"}, {ID: "2-0"}}},
+			dependencies: func(in input, d *dependencies, sub *mocks.Subscription) {
+				live := make(chan stream.Event, 2)
+				live <- stream.Event{ID: "1-0"}
+				live <- stream.Event{ID: "2-0"}
+				close(live)
+
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.broker.On("Subscribe", in.ctx, []string{"home:u1"}).Return(sub, nil)
+				sub.On("Events").Return((<-chan stream.Event)(live))
+				sub.On("Close").Return()
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should replay missed events first and deliver each event once",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				userID:      "u1",
+				lastEventID: "1-0",
+			},
+			output: output{events: []stream.Event{{ID: "2-0"}, {ID: "3-0"}, {ID: "4-0"}}},
+			dependencies: func(in input, d *dependencies, sub *mocks.Subscription) {
+				live := make(chan stream.Event, 2)
+				live <- stream.Event{ID: "3-0"}
+				live <- stream.Event{ID: "4-0"}
+				close(live)
+
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.broker.On("Subscribe", in.ctx, []string{"home:u1"}).Return(sub, nil)
+				d.broker.On("History", in.ctx, "home:u1", in.lastEventID).Return([]stream.Event{{ID: "2-0"}, {ID: "3-0"}}, nil)
+				sub.On("Events").Return((<-chan stream.Event)(live))
+				sub.On("Close").Return()
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:  mocks.NewUserFinder(t),
+				tweetFinder: mocks.NewTweetFinder(t),
+				broker:      mocks.NewBroker(t),
+			}
+			sub := mocks.NewSubscription(t)
+			tt.dependencies(tt.input, d, sub)
+
+			uc := stream.NewStreamUseCase(d.userFinder, d.tweetFinder, d.broker)
+			var actual output
+			conn, err := uc.Connect(tt.input.ctx, tt.input.userID, tt.input.lastEventID)
+			actual.err = err
+			if conn != nil {
+				timeout := time.After(2 * time.Second)
+			loop:
+				for {
+					select {
+					case event, ok := <-conn.Events():
+						if !ok {
+							break loop
+						}
+						actual.events = append(actual.events, event)
+					case <-timeout:
+						t.Error("events were not delivered in time")
+						break loop
+					}
+				}
+				conn.Close()
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package tweet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/tweettext"
+)
+
+func (uc *usecase) CreateDraft(ctx context.Context, draft *Draft) error {
+	if err := uc.drafts.CreateDraft(ctx, draft, MaxDrafts); err != nil {
+		if errors.Is(err, ErrTooManyDrafts) {
+			return err
+		}
+		return fmt.Errorf("failed to create draft: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *usecase) GetDrafts(ctx context.Context, userID string) ([]Draft, error) {
+	drafts, err := uc.drafts.GetDrafts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drafts: %w", err)
+	}
+
+	if len(drafts) == 0 {
+		return []Draft{}, nil
+	}
+
+	return drafts, nil
+}
+
+func (uc *usecase) GetDraft(ctx context.Context, userID, id string) (*Draft, error) {
+	draft, err := uc.drafts.GetDraft(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, ErrDraftNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get draft: %w", err)
+	}
+
+	return draft, nil
+}
+
+func (uc *usecase) UpdateDraft(ctx context.Context, draft *Draft) error {
+	if err := uc.drafts.UpdateDraft(ctx, draft); err != nil {
+		if errors.Is(err, ErrDraftNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update draft: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *usecase) DeleteDraft(ctx context.Context, userID, id string) error {
+	if err := uc.drafts.DeleteDraft(ctx, userID, id); err != nil {
+		if errors.Is(err, ErrDraftNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+
+	return nil
+}
+
+// PublishDraft publishes every part of the draft as a tweet, in order, and
+// deletes the draft. Nothing is published unless every part is valid.
+func (uc *usecase) PublishDraft(ctx context.Context, userID, id string) ([]Tweet, error) {
+	draft, err := uc.GetDraft(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets := make([]*Tweet, len(draft.Parts))
+	for i, part := range draft.Parts {
+		if err := ValidateContent(part); err != nil {
+			return nil, fmt.Errorf("part %d: %w", i+1, err)
+		}
+		tweets[i] = &Tweet{UserID: userID, Content: part, Links: linksOf(part)}
+	}
+
+	if exist, err := uc.userFinder.ExistsByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to check user ID: %w", err)
+	} else if !exist {
+		return nil, user.ErrUserNotFound
+	}
+
+	if err := uc.drafts.PublishDraft(ctx, draft, tweets); err != nil {
+		if errors.Is(err, ErrDraftNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to publish draft: %w", err)
+	}
+
+	published := make([]Tweet, len(tweets))
+	for i, t := range tweets {
+		published[i] = *t
+	}
+	uc.tweetsCreatedAsync(ctx, userID, published...)
+
+	return published, nil
+}
+
+// PurgeExpiredDrafts deletes the drafts not updated within DraftTTL.
+func (uc *usecase) PurgeExpiredDrafts(ctx context.Context) (int, error) {
+	purged, err := uc.drafts.PurgeDraftsUpdatedBefore(ctx, time.Now().Add(-DraftTTL))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge drafts: %w", err)
+	}
+
+	return purged, nil
+}
+
+// ValidateContent applies the length rule of a new tweet. Content over
+// MaxLength fails with a *LengthError.
+func ValidateContent(content string) error {
+	if content == "" {
+		return ErrInvalidContent
+	}
+	if length := tweettext.Length(content); length > MaxLength {
+		return &LengthError{Length: length}
+	}
+
+	return nil
+}
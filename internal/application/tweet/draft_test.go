@@ -0,0 +1,351 @@
+package tweet_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_CreateDraft(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		draft *tweet.Draft
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if drafts.CreateDraft returns error",
+			input:  input{ctx: twcontext.NewTestContext(), draft: &tweet.Draft{UserID: "u1", Parts: []string{"hi"}}},
+			output: output{err: fmt.Errorf("failed to create draft: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.drafts.On("CreateDraft", in.ctx, in.draft, tweet.MaxDrafts).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should return error if the user has too many drafts",
+			input:  input{ctx: twcontext.NewTestContext(), draft: &tweet.Draft{UserID: "u1", Parts: []string{"hi"}}},
+			output: output{err: tweet.ErrTooManyDrafts},
+			dependencies: func(in input, d *dependencies) {
+				d.drafts.On("CreateDraft", in.ctx, in.draft, tweet.MaxDrafts).Return(tweet.ErrTooManyDrafts)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should create draft successfully",
+			input:  input{ctx: twcontext.NewTestContext(), draft: &tweet.Draft{UserID: "u1", Parts: []string{"hi"}}},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.drafts.On("CreateDraft", in.ctx, in.draft, tweet.MaxDrafts).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.err = uc.CreateDraft(tt.input.ctx, tt.input.draft)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_PublishDraft(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		id     string
+	}
+
+	type output struct {
+		tweets []tweet.Tweet
+		err    error
+	}
+
+	thread := &tweet.Draft{ID: "d1", UserID: "u1", Parts: []string{"first", "second"}}
+	toPublish := []*tweet.Tweet{{UserID: "u1", Content: "first"}, {UserID: "u1", Content: "second"}}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies, wg *sync.WaitGroup)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return not found if the draft does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "d1"},
+			output: output{err: tweet.ErrDraftNotFound},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.drafts.On("GetDraft", in.ctx, in.userID, in.id).Return(nil, tweet.ErrDraftNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if a part is longer than a tweet",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "d1"},
+			output: output{err: tweet.ErrInvalidContent},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				draft := &tweet.Draft{ID: "d1", UserID: "u1", Parts: []string{"ok", strings.Repeat("a", tweet.MaxLength+1)}}
+				d.drafts.On("GetDraft", in.ctx, in.userID, in.id).Return(draft, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.True(t, errors.Is(actual.err, expected.err))
+				assert.Equal(t, "part 2: invalid tweet content: length 281 exceeds 280", actual.err.Error())
+			},
+		},
+		{
+			name:   "should return error if a part is empty",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "d1"},
+			output: output{err: tweet.ErrInvalidContent},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				draft := &tweet.Draft{ID: "d1", UserID: "u1", Parts: []string{""}}
+				d.drafts.On("GetDraft", in.ctx, in.userID, in.id).Return(draft, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.True(t, errors.Is(actual.err, expected.err))
+			},
+		},
+		{
+			name:   "should accept a part of exactly the max length in multibyte characters",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "d1"},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				draft := &tweet.Draft{ID: "d1", UserID: "u1", Parts: []string{strings.Repeat("ñ", tweet.MaxLength)}}
+				d.drafts.On("GetDraft", in.ctx, in.userID, in.id).Return(draft, nil)
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return not found if the draft was deleted while publishing",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "d1"},
+			output: output{err: tweet.ErrDraftNotFound},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.drafts.On("GetDraft", in.ctx, in.userID, in.id).Return(thread, nil)
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.drafts.On("PublishDraft", in.ctx, thread, toPublish).Return(tweet.ErrDraftNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if drafts.PublishDraft returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "d1"},
+			output: output{err: fmt.Errorf("failed to publish draft: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.drafts.On("GetDraft", in.ctx, in.userID, in.id).Return(thread, nil)
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.drafts.On("PublishDraft", in.ctx, thread, toPublish).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should publish every part of a thread",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "d1"},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", UserID: "u1", Content: "first"}, {ID: "t2", UserID: "u1", Content: "second"}}},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.drafts.On("GetDraft", in.ctx, in.userID, in.id).Return(thread, nil)
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.drafts.On("PublishDraft", in.ctx, thread, toPublish).Return(nil).Run(func(args mock.Arguments) {
+					tweets := args.Get(2).([]*tweet.Tweet)
+					tweets[0].ID = "t1"
+					tweets[1].ID = "t2"
+				})
+
+				ctx := twcontext.NewDetachedWithRequestID(in.ctx)
+				wg.Add(11)
+				d.userFinder.On("GetFollowers", ctx, in.userID).Return(nil, nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.cache.On("InvalidateTimeline", ctx, in.userID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.lists.On("GetListIDsByMember", ctx, in.userID).Return(nil, nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.notifier.On("NotifyTweet", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.publisher.On("PublishTweet", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.webhooks.On("DispatchTweetCreated", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.searcher.On("IndexTweet", ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			var wg sync.WaitGroup
+			tt.dependencies(tt.input, d, &wg)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.tweets, actual.err = uc.PublishDraft(tt.input.ctx, tt.input.userID, tt.input.id)
+
+			// Wait for the goroutines started after publishing
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("timeout waiting for goroutines")
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_PurgeExpiredDrafts(t *testing.T) {
+	type input struct {
+		ctx context.Context
+	}
+
+	type output struct {
+		purged int
+		err    error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if drafts.PurgeDraftsUpdatedBefore returns error",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{err: fmt.Errorf("failed to purge drafts: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.drafts.On("PurgeDraftsUpdatedBefore", in.ctx, mock.Anything).Return(0, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should purge drafts not updated within the TTL",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{purged: 2},
+			dependencies: func(in input, d *dependencies) {
+				d.drafts.On("PurgeDraftsUpdatedBefore", in.ctx, mock.MatchedBy(func(before time.Time) bool {
+					return time.Since(before) >= tweet.DraftTTL && time.Since(before) < tweet.DraftTTL+time.Minute
+				})).Return(2, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.purged, actual.err = uc.PurgeExpiredDrafts(tt.input.ctx)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -31,3 +31,12 @@ func (uc *usecase) invalidateFollowersTimelinesAsync(ctx context.Context, userID
 		}()
 	}
 }
+
+// invalidateOwnTimelineAsync invalidates the timeline cache of the author, so
+// that a new tweet shows up in their timeline when it includes their own
+// tweets.
+func (uc *usecase) invalidateOwnTimelineAsync(ctx context.Context, userID string) {
+	if err := uc.cache.InvalidateTimeline(ctx, userID); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("user_id", userID).Error("failed to invalidate own timeline")
+	}
+}
@@ -0,0 +1,96 @@
+package tweet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// GetListTimeline returns the timeline of a list viewerID can see, merged
+// like the home timeline but from the tweets of the list members. The
+// cached timeline is shared by every reader of the list, so the members
+// viewerID cannot see are skipped after reading it.
+func (uc *usecase) GetListTimeline(ctx context.Context, viewerID, listID string, limit, offset int) ([]Tweet, error) {
+	if _, err := uc.lists.GetList(ctx, viewerID, listID); err != nil {
+		return nil, err
+	}
+
+	tweets, err := uc.timeline(ctx, timelineSource{
+		getCached: func(ctx context.Context) ([]Tweet, error) {
+			return uc.cache.GetListTimeline(ctx, listID)
+		},
+		setCached: func(ctx context.Context, tweets []Tweet) error {
+			return uc.cache.SetListTimeline(ctx, listID, tweets)
+		},
+		authorIDs: func(ctx context.Context) ([]string, error) {
+			memberIDs, err := uc.lists.GetMemberIDs(ctx, listID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get list members: %w", err)
+			}
+			return memberIDs, nil
+		},
+	}, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets, err = uc.withoutHiddenAuthors(ctx, viewerID, tweets)
+	if err != nil || len(tweets) == 0 {
+		return tweets, err
+	}
+
+	return uc.forReader(ctx, viewerID, tweets)
+}
+
+// withoutHiddenAuthors drops the tweets of the users viewerID cannot see.
+func (uc *usecase) withoutHiddenAuthors(ctx context.Context, viewerID string, tweets []Tweet) ([]Tweet, error) {
+	var authorIDs []string
+	for _, t := range tweets {
+		if t.UserID != viewerID && !slices.Contains(authorIDs, t.UserID) {
+			authorIDs = append(authorIDs, t.UserID)
+		}
+	}
+	if len(authorIDs) == 0 {
+		return tweets, nil
+	}
+
+	hidden, err := uc.userFinder.GetHiddenAmong(ctx, viewerID, authorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error checking visibility of list members: %w", err)
+	}
+	if len(hidden) == 0 {
+		return tweets, nil
+	}
+
+	visible := make([]Tweet, 0, len(tweets))
+	for _, t := range tweets {
+		if !slices.Contains(hidden, t.UserID) {
+			visible = append(visible, t)
+		}
+	}
+
+	return visible, nil
+}
+
+// invalidateListTimelinesAsync invalidates the cached timelines of the lists
+// userID is a member of, like invalidateFollowersTimelinesAsync does for
+// the home timelines of the followers.
+func (uc *usecase) invalidateListTimelinesAsync(ctx context.Context, userID string) {
+	logger := twcontext.Logger(ctx)
+
+	listIDs, err := uc.lists.GetListIDsByMember(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get lists of user")
+		return
+	}
+
+	for _, listID := range listIDs {
+		go func() {
+			if err := uc.cache.InvalidateListTimeline(ctx, listID); err != nil {
+				logger.WithError(err).WithField("list_id", listID).Error("failed to invalidate list timeline")
+			}
+		}()
+	}
+}
@@ -0,0 +1,164 @@
+package tweet_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_usecase_GetListTimeline(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		viewerID string
+		listID   string
+		limit    int
+		offset   int
+	}
+
+	type output struct {
+		err    error
+		tweets []tweet.Tweet
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if the viewer cannot see the list",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", listID: "l1", limit: 10},
+			output: output{err: list.ErrListNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.lists.On("GetList", in.ctx, in.viewerID, in.listID).Return(nil, list.ErrListNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
+		{
+			name:   "should return cached tweets without the members hidden from the viewer",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", listID: "l1", limit: 10},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", UserID: "u2", Poll: &poll.Poll{TweetID: "t1"}}, {ID: "t3", UserID: "u1"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.lists.On("GetList", in.ctx, in.viewerID, in.listID).Return(&list.List{ID: "l1", OwnerID: "u9"}, nil)
+				d.cache.On("GetListTimeline", in.ctx, in.listID).Return([]tweet.Tweet{
+					{ID: "t1", UserID: "u2"},
+					{ID: "t2", UserID: "u3"},
+					{ID: "t3", UserID: "u1"},
+				}, nil)
+				d.userFinder.On("GetHiddenAmong", in.ctx, in.viewerID, []string{"u2", "u3"}).Return([]string{"u3"}, nil)
+				d.polls.On("GetPolls", in.ctx, in.viewerID, []string{"t1", "t3"}).Return(map[string]poll.Poll{"t1": {TweetID: "t1"}}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return empty slice if every member is hidden from the viewer",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", listID: "l1", limit: 10},
+			output: output{tweets: []tweet.Tweet{}},
+			dependencies: func(in input, d *dependencies) {
+				d.lists.On("GetList", in.ctx, in.viewerID, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.cache.On("GetListTimeline", in.ctx, in.listID).Return([]tweet.Tweet{{ID: "t1", UserID: "u2"}}, nil)
+				d.userFinder.On("GetHiddenAmong", in.ctx, in.viewerID, []string{"u2"}).Return([]string{"u2"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if lists.GetMemberIDs returns error",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", listID: "l1", limit: 10},
+			output: output{err: fmt.Errorf("failed to get list members: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.lists.On("GetList", in.ctx, in.viewerID, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.cache.On("GetListTimeline", in.ctx, in.listID).Return(nil, assert.AnError)
+				d.lists.On("GetMemberIDs", in.ctx, in.listID).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should return empty slice if the list has no members",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", listID: "l1", limit: 10},
+			output: output{tweets: []tweet.Tweet{}},
+			dependencies: func(in input, d *dependencies) {
+				d.lists.On("GetList", in.ctx, in.viewerID, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.cache.On("GetListTimeline", in.ctx, in.listID).Return(nil, assert.AnError)
+				d.lists.On("GetMemberIDs", in.ctx, in.listID).Return([]string{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should merge the tweets of the members and set the list cache",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", listID: "l1", limit: 10, offset: 0},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", UserID: "u2"}, {ID: "t2", UserID: "u3"}}},
+			dependencies: func(in input, d *dependencies) {
+				tweets := []tweet.Tweet{{ID: "t1", UserID: "u2"}, {ID: "t2", UserID: "u3"}}
+				d.lists.On("GetList", in.ctx, in.viewerID, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.cache.On("GetListTimeline", in.ctx, in.listID).Return(nil, assert.AnError)
+				d.lists.On("GetMemberIDs", in.ctx, in.listID).Return([]string{"u2", "u3"}, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{"u2", "u3"}, in.limit, in.offset).Return(tweets, nil)
+				d.cache.On("SetListTimeline", in.ctx, in.listID, tweets).Return(nil)
+				d.userFinder.On("GetHiddenAmong", in.ctx, in.viewerID, []string{"u2", "u3"}).Return([]string{}, nil)
+				d.polls.On("GetPolls", in.ctx, in.viewerID, []string{"t1", "t2"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if userFinder.GetHiddenAmong returns error",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", listID: "l1", limit: 10},
+			output: output{err: fmt.Errorf("error checking visibility of list members: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.lists.On("GetList", in.ctx, in.viewerID, in.listID).Return(&list.List{ID: "l1", OwnerID: "u1"}, nil)
+				d.cache.On("GetListTimeline", in.ctx, in.listID).Return([]tweet.Tweet{{ID: "t1", UserID: "u2"}}, nil)
+				d.userFinder.On("GetHiddenAmong", in.ctx, in.viewerID, []string{"u2"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.tweets, actual.err = uc.GetListTimeline(tt.input.ctx, tt.input.viewerID, tt.input.listID, tt.input.limit, tt.input.offset)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
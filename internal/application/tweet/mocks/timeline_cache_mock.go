@@ -14,21 +14,21 @@ type TimelineCache struct {
 	mock.Mock
 }
 
-// GetTimeline provides a mock function with given fields: ctx, userID
-func (_m *TimelineCache) GetTimeline(ctx context.Context, userID string) ([]tweet.Tweet, error) {
-	ret := _m.Called(ctx, userID)
+// GetListTimeline provides a mock function with given fields: ctx, listID
+func (_m *TimelineCache) GetListTimeline(ctx context.Context, listID string) ([]tweet.Tweet, error) {
+	ret := _m.Called(ctx, listID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTimeline")
+		panic("no return value specified for GetListTimeline")
 	}
 
 	var r0 []tweet.Tweet
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) ([]tweet.Tweet, error)); ok {
-		return rf(ctx, userID)
+		return rf(ctx, listID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string) []tweet.Tweet); ok {
-		r0 = rf(ctx, userID)
+		r0 = rf(ctx, listID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]tweet.Tweet)
@@ -36,7 +36,37 @@ func (_m *TimelineCache) GetTimeline(ctx context.Context, userID string) ([]twee
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userID)
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimeline provides a mock function with given fields: ctx, userID, includeOwn
+func (_m *TimelineCache) GetTimeline(ctx context.Context, userID string, includeOwn bool) ([]tweet.Tweet, error) {
+	ret := _m.Called(ctx, userID, includeOwn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimeline")
+	}
+
+	var r0 []tweet.Tweet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) ([]tweet.Tweet, error)); ok {
+		return rf(ctx, userID, includeOwn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) []tweet.Tweet); ok {
+		r0 = rf(ctx, userID, includeOwn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tweet.Tweet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, userID, includeOwn)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,6 +74,24 @@ func (_m *TimelineCache) GetTimeline(ctx context.Context, userID string) ([]twee
 	return r0, r1
 }
 
+// InvalidateListTimeline provides a mock function with given fields: ctx, listID
+func (_m *TimelineCache) InvalidateListTimeline(ctx context.Context, listID string) error {
+	ret := _m.Called(ctx, listID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateListTimeline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InvalidateTimeline provides a mock function with given fields: ctx, userID
 func (_m *TimelineCache) InvalidateTimeline(ctx context.Context, userID string) error {
 	ret := _m.Called(ctx, userID)
@@ -62,17 +110,35 @@ func (_m *TimelineCache) InvalidateTimeline(ctx context.Context, userID string)
 	return r0
 }
 
-// SetTimeline provides a mock function with given fields: ctx, userID, tweets
-func (_m *TimelineCache) SetTimeline(ctx context.Context, userID string, tweets []tweet.Tweet) error {
-	ret := _m.Called(ctx, userID, tweets)
+// SetListTimeline provides a mock function with given fields: ctx, listID, tweets
+func (_m *TimelineCache) SetListTimeline(ctx context.Context, listID string, tweets []tweet.Tweet) error {
+	ret := _m.Called(ctx, listID, tweets)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetTimeline")
+		panic("no return value specified for SetListTimeline")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, []tweet.Tweet) error); ok {
-		r0 = rf(ctx, userID, tweets)
+		r0 = rf(ctx, listID, tweets)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTimeline provides a mock function with given fields: ctx, userID, includeOwn, tweets
+func (_m *TimelineCache) SetTimeline(ctx context.Context, userID string, includeOwn bool, tweets []tweet.Tweet) error {
+	ret := _m.Called(ctx, userID, includeOwn, tweets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimeline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, []tweet.Tweet) error); ok {
+		r0 = rf(ctx, userID, includeOwn, tweets)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -14,6 +14,36 @@ type TweetReader struct {
 	mock.Mock
 }
 
+// GetTweetByID provides a mock function with given fields: ctx, id
+func (_m *TweetReader) GetTweetByID(ctx context.Context, id string) (*tweet.Tweet, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTweetByID")
+	}
+
+	var r0 *tweet.Tweet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*tweet.Tweet, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *tweet.Tweet); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tweet.Tweet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTweetsByUserIDs provides a mock function with given fields: ctx, userIDs, limit, offset
 func (_m *TweetReader) GetTweetsByUserIDs(ctx context.Context, userIDs []string, limit int, offset int) ([]tweet.Tweet, error) {
 	ret := _m.Called(ctx, userIDs, limit, offset)
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	user "github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -101,6 +102,176 @@ func (_m *UserFinder) GetFollowers(ctx context.Context, id string) ([]string, er
 	return r0, r1
 }
 
+// GetHiddenAmong provides a mock function with given fields: ctx, viewerID, ids
+func (_m *UserFinder) GetHiddenAmong(ctx context.Context, viewerID string, ids []string) ([]string, error) {
+	ret := _m.Called(ctx, viewerID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHiddenAmong")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return rf(ctx, viewerID, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, viewerID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, viewerID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSensitiveMediaSetting provides a mock function with given fields: ctx, id
+func (_m *UserFinder) GetSensitiveMediaSetting(ctx context.Context, id string) (user.SensitiveMedia, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSensitiveMediaSetting")
+	}
+
+	var r0 user.SensitiveMedia
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.SensitiveMedia, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.SensitiveMedia); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(user.SensitiveMedia)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimelineOwnTweetsSetting provides a mock function with given fields: ctx, id
+func (_m *UserFinder) GetTimelineOwnTweetsSetting(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimelineOwnTweetsSetting")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasBlockBetween provides a mock function with given fields: ctx, userID, otherID
+func (_m *UserFinder) HasBlockBetween(ctx context.Context, userID string, otherID string) (bool, error) {
+	ret := _m.Called(ctx, userID, otherID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasBlockBetween")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, otherID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, otherID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, otherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsFollowing provides a mock function with given fields: ctx, followerID, followeeID
+func (_m *UserFinder) IsFollowing(ctx context.Context, followerID string, followeeID string) (bool, error) {
+	ret := _m.Called(ctx, followerID, followeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsFollowing")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, followerID, followeeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, followerID, followeeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, followerID, followeeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsProtected provides a mock function with given fields: ctx, id
+func (_m *UserFinder) IsProtected(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsProtected")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserFinder creates a new instance of UserFinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserFinder(t interface {
@@ -0,0 +1,63 @@
+package tweet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PinTweet pins one of the user's own tweets, replacing the previous one.
+func (uc *usecase) PinTweet(ctx context.Context, userID, tweetID string) error {
+	t, err := uc.tweetReader.GetTweetByID(ctx, tweetID)
+	if err != nil {
+		if errors.Is(err, ErrTweetNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get tweet: %w", err)
+	}
+
+	if t.UserID != userID {
+		return ErrCannotPinTweet
+	}
+
+	if err := uc.pins.SetPinnedTweet(ctx, userID, tweetID); err != nil {
+		return fmt.Errorf("failed to pin tweet: %w", err)
+	}
+
+	return nil
+}
+
+// UnpinTweet is a no-op if tweetID is not the pinned tweet of the user.
+func (uc *usecase) UnpinTweet(ctx context.Context, userID, tweetID string) error {
+	if err := uc.pins.UnsetPinnedTweet(ctx, userID, tweetID); err != nil {
+		return fmt.Errorf("failed to unpin tweet: %w", err)
+	}
+
+	return nil
+}
+
+// withPinnedTweet removes the pinned tweet of authorID from its
+// chronological position in every page and, on the first one, puts it on
+// top of tweets.
+func (uc *usecase) withPinnedTweet(ctx context.Context, authorID string, tweets []Tweet, firstPage bool) ([]Tweet, error) {
+	pinned, err := uc.pins.GetPinnedTweet(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving pinned tweet: %w", err)
+	}
+	if pinned == nil {
+		return tweets, nil
+	}
+
+	result := make([]Tweet, 0, len(tweets)+1)
+	if firstPage {
+		pinned.Pinned = true
+		result = append(result, *pinned)
+	}
+	for _, t := range tweets {
+		if t.ID != pinned.ID {
+			result = append(result, t)
+		}
+	}
+
+	return result, nil
+}
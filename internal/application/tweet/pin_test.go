@@ -0,0 +1,187 @@
+package tweet_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_usecase_PinTweet(t *testing.T) {
+	type input struct {
+		ctx     context.Context
+		userID  string
+		tweetID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return not found if the tweet does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: tweet.ErrTweetNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.tweetReader.On("GetTweetByID", in.ctx, in.tweetID).Return(nil, tweet.ErrTweetNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if tweetReader.GetTweetByID returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: fmt.Errorf("failed to get tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.tweetReader.On("GetTweetByID", in.ctx, in.tweetID).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should reject pinning another user's tweet",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: tweet.ErrCannotPinTweet},
+			dependencies: func(in input, d *dependencies) {
+				d.tweetReader.On("GetTweetByID", in.ctx, in.tweetID).Return(&tweet.Tweet{ID: "t1", UserID: "u2"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if pins.SetPinnedTweet returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: fmt.Errorf("failed to pin tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.tweetReader.On("GetTweetByID", in.ctx, in.tweetID).Return(&tweet.Tweet{ID: "t1", UserID: "u1"}, nil)
+				d.pins.On("SetPinnedTweet", in.ctx, in.userID, in.tweetID).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should pin own tweet successfully",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.tweetReader.On("GetTweetByID", in.ctx, in.tweetID).Return(&tweet.Tweet{ID: "t1", UserID: "u1"}, nil)
+				d.pins.On("SetPinnedTweet", in.ctx, in.userID, in.tweetID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.err = uc.PinTweet(tt.input.ctx, tt.input.userID, tt.input.tweetID)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_UnpinTweet(t *testing.T) {
+	type input struct {
+		ctx     context.Context
+		userID  string
+		tweetID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if pins.UnsetPinnedTweet returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: fmt.Errorf("failed to unpin tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.pins.On("UnsetPinnedTweet", in.ctx, in.userID, in.tweetID).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should unpin tweet successfully",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", tweetID: "t1"},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.pins.On("UnsetPinnedTweet", in.ctx, in.userID, in.tweetID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.err = uc.UnpinTweet(tt.input.ctx, tt.input.userID, tt.input.tweetID)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package tweet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+const (
+	publishBatchSize = 50
+	// publishLease is how long a claimed scheduled tweet stays hidden from
+	// other workers. A tweet whose publication failed is retried after it.
+	publishLease = 5 * time.Minute
+	// maxPublishAttempts is how many times a scheduled tweet is tried
+	// before it is marked as failed.
+	maxPublishAttempts = 5
+)
+
+func (uc *usecase) ScheduleTweet(ctx context.Context, scheduled *ScheduledTweet) error {
+	if err := ValidateContent(scheduled.Content); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !scheduled.PublishAt.After(now) || scheduled.PublishAt.After(now.Add(MaxScheduleAhead)) {
+		return ErrInvalidPublishAt
+	}
+
+	if exist, err := uc.userFinder.ExistsByID(ctx, scheduled.UserID); err != nil {
+		return fmt.Errorf("failed to check user ID: %w", err)
+	} else if !exist {
+		return user.ErrUserNotFound
+	}
+
+	if err := uc.scheduled.CreateScheduledTweet(ctx, scheduled); err != nil {
+		return fmt.Errorf("failed to create scheduled tweet: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *usecase) GetScheduledTweets(ctx context.Context, userID string) ([]ScheduledTweet, error) {
+	scheduled, err := uc.scheduled.GetScheduledTweets(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled tweets: %w", err)
+	}
+
+	if len(scheduled) == 0 {
+		return []ScheduledTweet{}, nil
+	}
+
+	return scheduled, nil
+}
+
+func (uc *usecase) CancelScheduledTweet(ctx context.Context, userID, id string) error {
+	if err := uc.scheduled.DeleteScheduledTweet(ctx, userID, id); err != nil {
+		if errors.Is(err, ErrScheduledTweetNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete scheduled tweet: %w", err)
+	}
+
+	return nil
+}
+
+// PublishDueTweets publishes the scheduled tweets that are due through
+// CreateTweet and returns how many were published. The tweet reuses the ID
+// of the scheduled tweet, so a retry after a crash cannot publish it twice.
+func (uc *usecase) PublishDueTweets(ctx context.Context) (int, error) {
+	due, err := uc.scheduled.ClaimDueScheduledTweets(ctx, time.Now(), publishLease, publishBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim scheduled tweets: %w", err)
+	}
+
+	published := 0
+	for _, scheduled := range due {
+		logger := twcontext.Logger(ctx).WithField("scheduled_tweet_id", scheduled.ID)
+
+		err := uc.CreateTweet(ctx, &Tweet{
+			ID:      scheduled.ID,
+			UserID:  scheduled.UserID,
+			Content: scheduled.Content,
+		})
+		switch {
+		case err == nil:
+			published++
+		case errors.Is(err, ErrTweetExists):
+			logger.Info("scheduled tweet was already published")
+		case errors.Is(err, user.ErrUserNotFound):
+			// The user was deactivated after the claim. The tweet stays
+			// pending until a reactivation; a purge deletes it in cascade.
+			logger.Info("skipping scheduled tweet of deactivated user")
+			continue
+		default:
+			if scheduled.Attempts+1 >= maxPublishAttempts {
+				logger.WithError(err).Error("failed to publish scheduled tweet, giving up")
+			} else {
+				logger.WithError(err).Error("failed to publish scheduled tweet")
+			}
+			if err := uc.scheduled.RecordFailedPublish(ctx, scheduled.ID, maxPublishAttempts); err != nil {
+				return published, fmt.Errorf("failed to record failed publication: %w", err)
+			}
+			continue
+		}
+
+		if err := uc.scheduled.RemoveScheduledTweet(ctx, scheduled.ID); err != nil {
+			return published, fmt.Errorf("failed to remove scheduled tweet: %w", err)
+		}
+	}
+
+	return published, nil
+}
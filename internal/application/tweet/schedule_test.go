@@ -0,0 +1,406 @@
+package tweet_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_ScheduleTweet(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		scheduled *tweet.ScheduledTweet
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if content is too long",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				scheduled: &tweet.ScheduledTweet{UserID: "u1", Content: strings.Repeat("a", tweet.MaxLength+1), PublishAt: time.Now().Add(time.Hour)},
+			},
+			output:       output{err: &tweet.LengthError{Length: tweet.MaxLength + 1}},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if publish_at is in the past",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				scheduled: &tweet.ScheduledTweet{UserID: "u1", Content: "hi", PublishAt: time.Now().Add(-time.Minute)},
+			},
+			output:       output{err: tweet.ErrInvalidPublishAt},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if publish_at is too far ahead",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				scheduled: &tweet.ScheduledTweet{UserID: "u1", Content: "hi", PublishAt: time.Now().Add(tweet.MaxScheduleAhead + time.Hour)},
+			},
+			output:       output{err: tweet.ErrInvalidPublishAt},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				scheduled: &tweet.ScheduledTweet{UserID: "u1", Content: "hi", PublishAt: time.Now().Add(time.Hour)},
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.scheduled.UserID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if scheduled.CreateScheduledTweet returns error",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				scheduled: &tweet.ScheduledTweet{UserID: "u1", Content: "hi", PublishAt: time.Now().Add(time.Hour)},
+			},
+			output: output{err: fmt.Errorf("failed to create scheduled tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.scheduled.UserID).Return(true, nil)
+				d.scheduled.On("CreateScheduledTweet", in.ctx, in.scheduled).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should schedule tweet successfully",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				scheduled: &tweet.ScheduledTweet{UserID: "u1", Content: "hi", PublishAt: time.Now().Add(time.Hour)},
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.scheduled.UserID).Return(true, nil)
+				d.scheduled.On("CreateScheduledTweet", in.ctx, in.scheduled).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.err = uc.ScheduleTweet(tt.input.ctx, tt.input.scheduled)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_CancelScheduledTweet(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		id     string
+	}
+
+	type output struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return not found if the scheduled tweet does not exist",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "s1"},
+			output: output{err: tweet.ErrScheduledTweetNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.scheduled.On("DeleteScheduledTweet", in.ctx, in.userID, in.id).Return(tweet.ErrScheduledTweetNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if scheduled.DeleteScheduledTweet returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "s1"},
+			output: output{err: fmt.Errorf("failed to delete scheduled tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.scheduled.On("DeleteScheduledTweet", in.ctx, in.userID, in.id).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should cancel scheduled tweet successfully",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "s1"},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.scheduled.On("DeleteScheduledTweet", in.ctx, in.userID, in.id).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.err = uc.CancelScheduledTweet(tt.input.ctx, tt.input.userID, tt.input.id)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_PublishDueTweets(t *testing.T) {
+	type input struct {
+		ctx context.Context
+	}
+
+	type output struct {
+		published int
+		err       error
+	}
+
+	due := tweet.ScheduledTweet{ID: "s1", UserID: "u1", Content: "hi", PublishAt: time.Now().Add(-time.Second)}
+	published := &tweet.Tweet{ID: due.ID, UserID: due.UserID, Content: due.Content}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies, wg *sync.WaitGroup)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if scheduled.ClaimDueScheduledTweets returns error",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{err: fmt.Errorf("failed to claim scheduled tweets: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.scheduled.On("ClaimDueScheduledTweets", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should publish due tweets through CreateTweet and remove them",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{published: 1},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.scheduled.On("ClaimDueScheduledTweets", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]tweet.ScheduledTweet{due}, nil)
+				d.userFinder.On("ExistsByID", in.ctx, due.UserID).Return(true, nil)
+				d.tweetsCreator.On("CreateTweet", in.ctx, published).Return(nil)
+				d.scheduled.On("RemoveScheduledTweet", in.ctx, due.ID).Return(nil)
+
+				ctx := twcontext.NewDetachedWithRequestID(in.ctx)
+				wg.Add(7)
+				d.userFinder.On("GetFollowers", ctx, due.UserID).Return(nil, nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.cache.On("InvalidateTimeline", ctx, due.UserID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.lists.On("GetListIDsByMember", ctx, due.UserID).Return(nil, nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.notifier.On("NotifyTweet", ctx, *published).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.publisher.On("PublishTweet", ctx, *published).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.webhooks.On("DispatchTweetCreated", ctx, *published).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.searcher.On("IndexTweet", ctx, *published).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should remove tweets that were already published",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{published: 0},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.scheduled.On("ClaimDueScheduledTweets", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]tweet.ScheduledTweet{due}, nil)
+				d.userFinder.On("ExistsByID", in.ctx, due.UserID).Return(true, nil)
+				d.tweetsCreator.On("CreateTweet", in.ctx, published).Return(tweet.ErrTweetExists)
+				d.scheduled.On("RemoveScheduledTweet", in.ctx, due.ID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should keep tweets of deactivated users pending",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{published: 0},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.scheduled.On("ClaimDueScheduledTweets", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]tweet.ScheduledTweet{due}, nil)
+				d.userFinder.On("ExistsByID", in.ctx, due.UserID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should record failed publications for a bounded retry",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{published: 0},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.scheduled.On("ClaimDueScheduledTweets", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]tweet.ScheduledTweet{due}, nil)
+				d.userFinder.On("ExistsByID", in.ctx, due.UserID).Return(true, nil)
+				d.tweetsCreator.On("CreateTweet", in.ctx, published).Return(assert.AnError)
+				d.scheduled.On("RecordFailedPublish", in.ctx, due.ID, 5).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if scheduled.RecordFailedPublish returns error",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{err: fmt.Errorf("failed to record failed publication: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.scheduled.On("ClaimDueScheduledTweets", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]tweet.ScheduledTweet{due}, nil)
+				d.userFinder.On("ExistsByID", in.ctx, due.UserID).Return(true, nil)
+				d.tweetsCreator.On("CreateTweet", in.ctx, published).Return(assert.AnError)
+				d.scheduled.On("RecordFailedPublish", in.ctx, due.ID, 5).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should return error if scheduled.RemoveScheduledTweet returns error",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{err: fmt.Errorf("failed to remove scheduled tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies, wg *sync.WaitGroup) {
+				d.scheduled.On("ClaimDueScheduledTweets", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]tweet.ScheduledTweet{due}, nil)
+				d.userFinder.On("ExistsByID", in.ctx, due.UserID).Return(true, nil)
+				d.tweetsCreator.On("CreateTweet", in.ctx, published).Return(tweet.ErrTweetExists)
+				d.scheduled.On("RemoveScheduledTweet", in.ctx, due.ID).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			var wg sync.WaitGroup
+			tt.dependencies(tt.input, d, &wg)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.published, actual.err = uc.PublishDueTweets(tt.input.ctx)
+
+			// Wait for the goroutines started by CreateTweet
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("timeout waiting for goroutines")
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package tweet
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/cursor"
+)
+
+// hashtagPattern matches a whole #tag search term.
+var hashtagPattern = regexp.MustCompile(`^#[\p{L}\p{N}_]{1,100}$`)
+
+// SearchTweets returns a page of the tweets matching q, newest first, as
+// seen by viewerID. cursor is the NextCursor of the previous page, or empty
+// for the first one.
+func (uc *usecase) SearchTweets(ctx context.Context, viewerID, q, cursor string, limit int) (*SearchPage, error) {
+	if limit <= 0 || limit > MaxSearchLimit {
+		limit = DefaultSearchLimit
+	}
+
+	query, err := ParseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *SearchCursor
+	if cursor != "" {
+		if after, err = decodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read one extra tweet to know whether there is a next page.
+	tweets, err := uc.searcher.SearchTweets(ctx, viewerID, *query, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("error searching tweets: %w", err)
+	}
+
+	page := &SearchPage{Tweets: []Tweet{}}
+	if len(tweets) > limit {
+		tweets = tweets[:limit]
+		last := tweets[limit-1]
+		page.NextCursor = encodeCursor(SearchCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	if len(tweets) == 0 {
+		return page, nil
+	}
+
+	// The cursor is taken before applying the sensitive media setting, so a
+	// page may hold fewer tweets than limit and still have a next one.
+	page.Tweets, err = uc.forReader(ctx, viewerID, tweets)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// ParseQuery splits q into the words to match and the filters:
+//
+//   - from:username, the author.
+//   - #tag, a hashtag, matched as a whole word.
+//   - since:YYYY-MM-DD and until:YYYY-MM-DD, in UTC, until excluded.
+//   - lang:xx, one of Languages.
+//
+// Everything else is kept in Text: words, "quoted phrases", or between
+// words and -word to exclude one. At least one word, author or hashtag is
+// required.
+func ParseQuery(q string) (*SearchQuery, error) {
+	if len(q) > MaxQueryLength {
+		return nil, ErrInvalidQuery
+	}
+
+	query := &SearchQuery{}
+	var text []string
+	for _, term := range splitTerms(q) {
+		key, value, found := strings.Cut(term, ":")
+		switch key = strings.ToLower(key); {
+		case found && key == "from":
+			value = strings.TrimPrefix(value, "@")
+			if value == "" || query.From != "" {
+				return nil, ErrInvalidQuery
+			}
+			query.From = value
+		case found && (key == "since" || key == "until"):
+			day, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				return nil, ErrInvalidQuery
+			}
+			if key == "since" {
+				query.Since = &day
+			} else {
+				query.Until = &day
+			}
+		case found && key == "lang":
+			value = strings.ToLower(value)
+			if !slices.Contains(Languages, value) {
+				return nil, ErrUnsupportedLanguage
+			}
+			query.Language = value
+		case hashtagPattern.MatchString(term):
+			tag := strings.ToLower(term[1:])
+			if !slices.Contains(query.Hashtags, tag) {
+				query.Hashtags = append(query.Hashtags, tag)
+			}
+		default:
+			text = append(text, term)
+		}
+	}
+	query.Text = strings.Join(text, " ")
+
+	if query.Text == "" && query.From == "" && len(query.Hashtags) == 0 {
+		return nil, ErrInvalidQuery
+	}
+	if query.Since != nil && query.Until != nil && !query.Since.Before(*query.Until) {
+		return nil, ErrInvalidQuery
+	}
+
+	return query, nil
+}
+
+// splitTerms splits q on spaces, except the ones between double quotes.
+func splitTerms(q string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range q {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if unicode.IsSpace(r) && !quoted {
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+			continue
+		}
+		term.WriteRune(r)
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+
+	return terms
+}
+
+func encodeCursor(c SearchCursor) string {
+	return cursor.Encode(c.CreatedAt, c.ID)
+}
+
+func decodeCursor(s string) (*SearchCursor, error) {
+	createdAt, id, err := cursor.Decode(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &SearchCursor{CreatedAt: createdAt, ID: id}, nil
+}
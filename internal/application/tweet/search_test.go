@@ -0,0 +1,190 @@
+package tweet_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet/mocks"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func searchCursorFor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d|%s", createdAt.UnixNano(), id)))
+}
+
+func Test_ParseQuery(t *testing.T) {
+	day := func(s string) *time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return &d
+	}
+
+	tests := []struct {
+		name  string
+		q     string
+		query *tweet.SearchQuery
+		err   error
+	}{
+		{name: "should keep words and phrases as text", q: ` go  "error handling" or -java `, query: &tweet.SearchQuery{Text: `go "error handling" or -java`}},
+		{name: "should parse the author", q: "from:@Gopher release", query: &tweet.SearchQuery{Text: "release", From: "Gopher"}},
+		{name: "should parse hashtags once, lowercased", q: "#Go #go #café", query: &tweet.SearchQuery{Hashtags: []string{"go", "café"}}},
+		{name: "should keep a lone # as text", q: "# c#", query: &tweet.SearchQuery{Text: "# c#"}},
+		{name: "should parse the date range", q: "go since:2024-01-01 until:2024-02-01", query: &tweet.SearchQuery{Text: "go", Since: day("2024-01-01"), Until: day("2024-02-01")}},
+		{name: "should parse the language", q: "corriendo LANG:ES", query: &tweet.SearchQuery{Text: "corriendo", Language: "es"}},
+		{name: "should return error if the query is empty", q: "  ", err: tweet.ErrInvalidQuery},
+		{name: "should return error if there are only filters", q: "lang:en since:2024-01-01", err: tweet.ErrInvalidQuery},
+		{name: "should return error if the query is too long", q: strings.Repeat("a", tweet.MaxQueryLength+1), err: tweet.ErrInvalidQuery},
+		{name: "should return error if the date is malformed", q: "go since:yesterday", err: tweet.ErrInvalidQuery},
+		{name: "should return error if the range is empty", q: "go since:2024-02-01 until:2024-02-01", err: tweet.ErrInvalidQuery},
+		{name: "should return error if there are two authors", q: "from:a from:b", err: tweet.ErrInvalidQuery},
+		{name: "should return error if the language is not supported", q: "go lang:xx", err: tweet.ErrUnsupportedLanguage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := tweet.ParseQuery(tt.q)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.query, query)
+		})
+	}
+}
+
+func Test_usecase_SearchTweets(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return time.Date(2024, 1, 1, 12, minutes, 0, 0, time.UTC)
+	}
+
+	type input struct {
+		ctx    context.Context
+		userID string
+		q      string
+		cursor string
+		limit  int
+	}
+
+	type output struct {
+		page *tweet.SearchPage
+		err  error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if the query is invalid",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1", q: ""},
+			output:       output{err: tweet.ErrInvalidQuery},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:         "should return error if the cursor is malformed",
+			input:        input{ctx: twcontext.NewTestContext(), userID: "u1", q: "go", cursor: "not a cursor"},
+			output:       output{err: tweet.ErrInvalidCursor},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if searcher.SearchTweets returns error",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", q: "go"},
+			output: output{err: fmt.Errorf("error searching tweets: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.searcher.On("SearchTweets", in.ctx, in.userID, tweet.SearchQuery{Text: "go"}, (*tweet.SearchCursor)(nil), tweet.DefaultSearchLimit+1).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should return an empty page without cursor",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", q: "go", limit: tweet.MaxSearchLimit + 1},
+			output: output{page: &tweet.SearchPage{Tweets: []tweet.Tweet{}}},
+			dependencies: func(in input, d *dependencies) {
+				d.searcher.On("SearchTweets", in.ctx, in.userID, tweet.SearchQuery{Text: "go"}, (*tweet.SearchCursor)(nil), tweet.DefaultSearchLimit+1).Return([]tweet.Tweet{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:  "should return a page after the cursor with the next cursor",
+			input: input{ctx: twcontext.NewTestContext(), userID: "u1", q: "#go from:ana", cursor: searchCursorFor(at(9), "t9"), limit: 2},
+			output: output{page: &tweet.SearchPage{
+				Tweets:     []tweet.Tweet{{ID: "t8", CreatedAt: at(8)}, {ID: "t7", CreatedAt: at(7)}},
+				NextCursor: searchCursorFor(at(7), "t7"),
+			}},
+			dependencies: func(in input, d *dependencies) {
+				query := tweet.SearchQuery{From: "ana", Hashtags: []string{"go"}}
+				d.searcher.On("SearchTweets", in.ctx, in.userID, query, &tweet.SearchCursor{CreatedAt: at(9), ID: "t9"}, 3).Return([]tweet.Tweet{
+					{ID: "t8", CreatedAt: at(8)},
+					{ID: "t7", CreatedAt: at(7)},
+					{ID: "t6", CreatedAt: at(6)},
+				}, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t8", "t7"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:  "should apply the sensitive media setting of the reader",
+			input: input{ctx: twcontext.NewTestContext(), userID: "u1", q: "go", limit: 1},
+			output: output{page: &tweet.SearchPage{
+				Tweets:     []tweet.Tweet{},
+				NextCursor: searchCursorFor(at(8), "t8"),
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.searcher.On("SearchTweets", in.ctx, in.userID, tweet.SearchQuery{Text: "go"}, (*tweet.SearchCursor)(nil), 2).Return([]tweet.Tweet{
+					{ID: "t8", UserID: "u2", Sensitive: true, CreatedAt: at(8)},
+					{ID: "t7", UserID: "u2", CreatedAt: at(7)},
+				}, nil)
+				d.userFinder.On("GetSensitiveMediaSetting", in.ctx, in.userID).Return(user.SensitiveMediaHide, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.page, actual.err = uc.SearchTweets(tt.input.ctx, tt.input.userID, tt.input.q, tt.input.cursor, tt.input.limit)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -2,23 +2,170 @@ package tweet
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/list"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/tweettext"
+)
+
+var (
+	ErrScheduledTweetNotFound = errors.New("scheduled tweet not found")
+	ErrInvalidPublishAt       = errors.New("invalid publish_at")
+	ErrTweetExists            = errors.New("tweet already exists")
+	ErrDraftNotFound          = errors.New("draft not found")
+	ErrTooManyDrafts          = errors.New("too many drafts")
+	ErrInvalidContent         = errors.New("invalid tweet content")
+	ErrTweetNotFound          = errors.New("tweet not found")
+	ErrCannotPinTweet         = errors.New("cannot pin another user's tweet")
+	ErrInvalidMedia           = errors.New("invalid media")
+	ErrUnsupportedLanguage    = errors.New("unsupported language")
+	ErrInvalidQuery           = errors.New("invalid search query")
+	ErrInvalidCursor          = errors.New("invalid cursor")
+)
+
+const (
+	// MaxLength is the length limit of a tweet, as counted by
+	// tweettext.Length.
+	MaxLength = tweettext.MaxLength
+	// MaxScheduleAhead is how far in the future a tweet can be scheduled.
+	MaxScheduleAhead = 365 * 24 * time.Hour
+	// MaxDrafts caps the drafts a user can keep.
+	MaxDrafts = 100
+	// DraftTTL is how long a draft is kept after its last update.
+	DraftTTL = 30 * 24 * time.Hour
+
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	// MaxQueryLength caps the search query, in bytes.
+	MaxQueryLength = 500
 )
 
+// Languages are the ISO 639-1 codes a tweet can be tagged with. Search
+// stems the words of tagged tweets for their language.
+var Languages = []string{"de", "en", "es", "fr", "it", "pt"}
+
 type (
 	Tweet struct {
+		ID      string
+		UserID  string
+		Content string
+		// Media references media uploaded by the author, in order.
+		Media []Attachment
+		// Links are the links found in Content, in order.
+		Links []Link
+		// Sensitive is set by the author. See IsSensitive.
+		Sensitive bool
+		// Language is the optional language of Content, one of Languages.
+		Language  string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		// Pinned marks the pinned tweet at the top of a profile timeline.
+		Pinned bool
+		// Blurred marks a sensitive tweet that the reader asked to blur.
+		Blurred bool
+		// Poll is the optional poll of the tweet, as seen by the reader.
+		Poll *poll.Poll
+	}
+
+	// Attachment is a media attached to a tweet. Only MediaID is needed to
+	// create a tweet; the rest is read from the media.
+	Attachment struct {
+		MediaID   string
+		AltText   string
+		Sensitive bool
+	}
+
+	// Link is a link of a tweet. Preview is set on reads once the page has
+	// been fetched.
+	Link struct {
+		link.Entity
+		Preview *link.Preview
+	}
+
+	// ScheduledTweet is a tweet waiting to be published at PublishAt. Once
+	// published, the tweet keeps the ID of the scheduled tweet.
+	ScheduledTweet struct {
 		ID        string
 		UserID    string
 		Content   string
+		PublishAt time.Time
+		CreatedAt time.Time
+		// Attempts counts the failed publications. FailedAt is set when
+		// the worker gave up on the tweet.
+		Attempts int
+		FailedAt *time.Time
+	}
+
+	// Draft is an unpublished tweet, or a thread when it has several parts.
+	// Parts are only checked against MaxLength when the draft is published.
+	Draft struct {
+		ID        string
+		UserID    string
+		Parts     []string
 		CreatedAt time.Time
 		UpdatedAt time.Time
 	}
 
+	// SearchQuery is a parsed search. Text keeps the words, quoted phrases,
+	// "or" and negated words as typed; the rest are filters. See
+	// ParseQuery.
+	SearchQuery struct {
+		Text string
+		// From is the username of the author.
+		From     string
+		Hashtags []string
+		// Since and Until bound the creation date, Until excluded.
+		Since *time.Time
+		Until *time.Time
+		// Language restricts the search to tweets in that language and
+		// matches the words by their stem.
+		Language string
+	}
+
+	SearchPage struct {
+		Tweets     []Tweet
+		NextCursor string
+	}
+
+	// SearchCursor points at the last tweet of a page. The next page starts
+	// strictly after it in (created_at, id) descending order.
+	SearchCursor struct {
+		CreatedAt time.Time
+		ID        string
+	}
+
 	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
 	UserFinder interface {
 		ExistsByID(ctx context.Context, id string) (bool, error)
+		IsProtected(ctx context.Context, id string) (bool, error)
+		IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+		HasBlockBetween(ctx context.Context, userID, otherID string) (bool, error)
 		GetFollowers(ctx context.Context, id string) ([]string, error)
 		GetFollowees(ctx context.Context, userID string) ([]string, error)
+		GetSensitiveMediaSetting(ctx context.Context, id string) (user.SensitiveMedia, error)
+		GetTimelineOwnTweetsSetting(ctx context.Context, id string) (bool, error)
+		// GetHiddenAmong returns the users among ids whose tweets viewerID
+		// cannot see: deactivated accounts, the ones with a block with
+		// viewerID in either direction and the protected accounts viewerID
+		// does not follow.
+		GetHiddenAmong(ctx context.Context, viewerID string, ids []string) ([]string, error)
+	}
+
+	// ListReader gives access to the lists whose timelines are served here.
+	//
+	//go:generate mockery --name=ListReader --output=mocks --outpkg=mocks --filename=list_reader.go
+	ListReader interface {
+		// GetList fails with list.ErrListNotFound if viewerID cannot see
+		// the list.
+		GetList(ctx context.Context, viewerID, id string) (*list.List, error)
+		GetMemberIDs(ctx context.Context, listID string) ([]string, error)
+		GetListIDsByMember(ctx context.Context, userID string) ([]string, error)
 	}
 
 	//go:generate mockery --name=TweetCreator --output=mocks --outpkg=mocks --filename=tweet_creator.go
@@ -29,12 +176,189 @@ type (
 	//go:generate mockery --name=TweetReader --output=mocks --outpkg=mocks --filename=tweet_reader.go
 	TweetReader interface {
 		GetTweetsByUserIDs(ctx context.Context, userIDs []string, limit, offset int) ([]Tweet, error)
+		GetTweetByID(ctx context.Context, id string) (*Tweet, error)
+	}
+
+	// TweetSearcher finds the tweets matching a query, newest first and
+	// strictly after the given cursor. It skips deleted tweets, tweets of
+	// deactivated users, of users with a block with viewerID in either
+	// direction and of protected accounts viewerID does not follow. It is
+	// told about every new tweet to keep its index up to date.
+	//
+	//go:generate mockery --name=TweetSearcher --output=mocks --outpkg=mocks --filename=tweet_searcher.go
+	TweetSearcher interface {
+		SearchTweets(ctx context.Context, viewerID string, query SearchQuery, after *SearchCursor, limit int) ([]Tweet, error)
+		IndexTweet(ctx context.Context, tweet Tweet) error
+	}
+
+	// PollReader returns the polls of a page of tweets, by tweet ID, as
+	// seen by viewerID.
+	//
+	//go:generate mockery --name=PollReader --output=mocks --outpkg=mocks --filename=poll_reader.go
+	PollReader interface {
+		GetPolls(ctx context.Context, viewerID string, tweetIDs []string) (map[string]poll.Poll, error)
+	}
+
+	// MediaFinder returns the uploaded media with the given IDs, skipping
+	// the ones that do not exist.
+	//
+	//go:generate mockery --name=MediaFinder --output=mocks --outpkg=mocks --filename=media_finder.go
+	MediaFinder interface {
+		GetMediaByIDs(ctx context.Context, ids []string) ([]media.Media, error)
+	}
+
+	// LinkPreviewer fetches the previews of the links of new tweets in the
+	// background.
+	//
+	//go:generate mockery --name=LinkPreviewer --output=mocks --outpkg=mocks --filename=link_previewer.go
+	LinkPreviewer interface {
+		RequestPreviews(ctx context.Context, urls []string) error
+		// GetPreviews returns the previews fetched so far, by URL.
+		GetPreviews(ctx context.Context, urls []string) (map[string]link.Preview, error)
+	}
+
+	// PinRepository stores the pinned tweet of each user.
+	//
+	//go:generate mockery --name=PinRepository --output=mocks --outpkg=mocks --filename=pin_repository.go
+	PinRepository interface {
+		// GetPinnedTweet returns nil if the user has no pinned tweet.
+		GetPinnedTweet(ctx context.Context, userID string) (*Tweet, error)
+		SetPinnedTweet(ctx context.Context, userID, tweetID string) error
+		// UnsetPinnedTweet unpins tweetID if it is the pinned tweet of the
+		// user.
+		UnsetPinnedTweet(ctx context.Context, userID, tweetID string) error
+	}
+
+	// Notifier is told about every new tweet so that mentioned users can be
+	// notified.
+	//
+	//go:generate mockery --name=Notifier --output=mocks --outpkg=mocks --filename=notifier.go
+	Notifier interface {
+		NotifyTweet(ctx context.Context, tweet Tweet) error
+	}
+
+	// EventPublisher pushes every new tweet to the followers connected to
+	// the live stream.
+	//
+	//go:generate mockery --name=EventPublisher --output=mocks --outpkg=mocks --filename=event_publisher.go
+	EventPublisher interface {
+		PublishTweet(ctx context.Context, tweet Tweet) error
+	}
+
+	// WebhookDispatcher enqueues the tweet.created webhook of every new
+	// tweet.
+	//
+	//go:generate mockery --name=WebhookDispatcher --output=mocks --outpkg=mocks --filename=webhook_dispatcher.go
+	WebhookDispatcher interface {
+		DispatchTweetCreated(ctx context.Context, tweet Tweet) error
+	}
+
+	//go:generate mockery --name=ScheduledTweetRepository --output=mocks --outpkg=mocks --filename=scheduled_tweet_repository.go
+	ScheduledTweetRepository interface {
+		CreateScheduledTweet(ctx context.Context, scheduled *ScheduledTweet) error
+		GetScheduledTweets(ctx context.Context, userID string) ([]ScheduledTweet, error)
+		// DeleteScheduledTweet fails with ErrScheduledTweetNotFound if the
+		// tweet does not exist or is being published.
+		DeleteScheduledTweet(ctx context.Context, userID, id string) error
+		// ClaimDueScheduledTweets hides the tweets due at now from other
+		// claims for the lease and returns them. It skips failed tweets and
+		// the tweets of deactivated users.
+		ClaimDueScheduledTweets(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]ScheduledTweet, error)
+		RemoveScheduledTweet(ctx context.Context, id string) error
+		// RecordFailedPublish counts a failed publication. Once the tweet
+		// failed maxAttempts times it is marked as failed and released, so
+		// it is no longer claimed and its author can delete it.
+		RecordFailedPublish(ctx context.Context, id string, maxAttempts int) error
+	}
+
+	//go:generate mockery --name=DraftRepository --output=mocks --outpkg=mocks --filename=draft_repository.go
+	DraftRepository interface {
+		// CreateDraft fails with ErrTooManyDrafts if the user already has
+		// limit drafts. Concurrent creations of the same user are counted
+		// one after the other.
+		CreateDraft(ctx context.Context, draft *Draft, limit int) error
+		GetDrafts(ctx context.Context, userID string) ([]Draft, error)
+		GetDraft(ctx context.Context, userID, id string) (*Draft, error)
+		UpdateDraft(ctx context.Context, draft *Draft) error
+		DeleteDraft(ctx context.Context, userID, id string) error
+		// PublishDraft creates the tweets and deletes the draft in a single
+		// transaction. It fails with ErrDraftNotFound if the draft is gone.
+		PublishDraft(ctx context.Context, draft *Draft, tweets []*Tweet) error
+		PurgeDraftsUpdatedBefore(ctx context.Context, before time.Time) (int, error)
 	}
 
 	//go:generate mockery --name=TimelineCache --output=mocks --outpkg=mocks --filename=timeline_cache_mock.go
 	TimelineCache interface {
+		// InvalidateTimeline drops the home timeline of userID with and
+		// without its own tweets, which are cached apart.
 		InvalidateTimeline(ctx context.Context, userID string) error
-		GetTimeline(ctx context.Context, userID string) ([]Tweet, error)
-		SetTimeline(ctx context.Context, userID string, tweets []Tweet) error
+		GetTimeline(ctx context.Context, userID string, includeOwn bool) ([]Tweet, error)
+		SetTimeline(ctx context.Context, userID string, includeOwn bool, tweets []Tweet) error
+		// The list timelines are cached apart from the home timelines and
+		// shared by every reader of the list.
+		InvalidateListTimeline(ctx context.Context, listID string) error
+		GetListTimeline(ctx context.Context, listID string) ([]Tweet, error)
+		SetListTimeline(ctx context.Context, listID string, tweets []Tweet) error
 	}
 )
+
+// LengthError is returned for content longer than MaxLength. It matches
+// ErrInvalidContent with errors.Is.
+type LengthError struct {
+	Length int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%s: length %d exceeds %d", ErrInvalidContent, e.Length, MaxLength)
+}
+
+func (e *LengthError) Unwrap() error {
+	return ErrInvalidContent
+}
+
+// Remaining is how much the content goes over MaxLength, as a negative
+// number.
+func (e *LengthError) Remaining() int {
+	return MaxLength - e.Length
+}
+
+// IsSensitive tells whether the tweet or any of its media was marked as
+// sensitive.
+func (t *Tweet) IsSensitive() bool {
+	if t.Sensitive {
+		return true
+	}
+	for _, a := range t.Media {
+		if a.Sensitive {
+			return true
+		}
+	}
+
+	return false
+}
+
+// linksOf returns the links found in content.
+func linksOf(content string) []Link {
+	var links []Link
+	for _, e := range link.Extract(content) {
+		links = append(links, Link{Entity: e})
+	}
+
+	return links
+}
+
+// urlsOf returns the distinct URLs linked by the tweets.
+func urlsOf(tweets []Tweet) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, t := range tweets {
+		for _, l := range t.Links {
+			if !seen[l.URL] {
+				seen[l.URL] = true
+				urls = append(urls, l.URL)
+			}
+		}
+	}
+
+	return urls
+}
@@ -3,7 +3,10 @@ package tweet
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
 	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
 )
@@ -13,38 +16,214 @@ type usecase struct {
 	tweetReader   TweetReader
 	tweetsCreator TweetCreator
 	cache         TimelineCache
+	notifier      Notifier
+	publisher     EventPublisher
+	webhooks      WebhookDispatcher
+	scheduled     ScheduledTweetRepository
+	drafts        DraftRepository
+	pins          PinRepository
+	polls         PollReader
+	media         MediaFinder
+	links         LinkPreviewer
+	searcher      TweetSearcher
+	lists         ListReader
 }
 
-func NewTweetUseCase(userFinder UserFinder, tweetReader TweetReader, tweetsCreator TweetCreator, cache TimelineCache) *usecase {
+func NewTweetUseCase(userFinder UserFinder, tweetReader TweetReader, tweetsCreator TweetCreator, cache TimelineCache, notifier Notifier, publisher EventPublisher, webhooks WebhookDispatcher, scheduled ScheduledTweetRepository, drafts DraftRepository, pins PinRepository, polls PollReader, media MediaFinder, links LinkPreviewer, searcher TweetSearcher, lists ListReader) *usecase {
 	return &usecase{
 		userFinder:    userFinder,
 		tweetReader:   tweetReader,
 		tweetsCreator: tweetsCreator,
 		cache:         cache,
+		notifier:      notifier,
+		publisher:     publisher,
+		webhooks:      webhooks,
+		scheduled:     scheduled,
+		drafts:        drafts,
+		pins:          pins,
+		polls:         polls,
+		media:         media,
+		links:         links,
+		searcher:      searcher,
+		lists:         lists,
 	}
 }
 
+// CreateTweet creates the tweet along with its media, links and poll, if
+// any.
 func (uc *usecase) CreateTweet(ctx context.Context, tweet *Tweet) error {
+	if err := ValidateContent(tweet.Content); err != nil {
+		return err
+	}
+	if tweet.Language != "" && !slices.Contains(Languages, tweet.Language) {
+		return ErrUnsupportedLanguage
+	}
+	tweet.Links = linksOf(tweet.Content)
+
+	if tweet.Poll != nil {
+		if err := tweet.Poll.Validate(); err != nil {
+			return err
+		}
+		tweet.Poll.AuthorID = tweet.UserID
+		tweet.Poll.EndsAt = time.Now().Add(tweet.Poll.Duration)
+	}
+
 	if exist, err := uc.userFinder.ExistsByID(ctx, tweet.UserID); err != nil {
 		return fmt.Errorf("failed to check user ID: %w", err)
 	} else if !exist {
 		return user.ErrUserNotFound
 	}
 
+	if err := uc.attachMedia(ctx, tweet); err != nil {
+		return err
+	}
+
 	if err := uc.tweetsCreator.CreateTweet(ctx, tweet); err != nil {
 		return fmt.Errorf("failed to create tweet: %w", err)
 	}
 
-	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
-	go uc.invalidateFollowersTimelinesAsync(detachedCtx, tweet.UserID)
+	uc.tweetsCreatedAsync(ctx, tweet.UserID, *tweet)
 
 	return nil
 }
 
-func (uc *usecase) GetTimeline(ctx context.Context, userID string, limit, offset int) ([]Tweet, error) {
+// attachMedia makes sure that every media exists and was uploaded by the
+// author, and copies their alt text and sensitive flag to the tweet.
+func (uc *usecase) attachMedia(ctx context.Context, tweet *Tweet) error {
+	if len(tweet.Media) == 0 {
+		return nil
+	}
+	if len(tweet.Media) > media.MaxPerTweet {
+		return ErrInvalidMedia
+	}
+
+	ids := make([]string, len(tweet.Media))
+	for i, a := range tweet.Media {
+		ids[i] = a.MediaID
+	}
+
+	found, err := uc.media.GetMediaByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get media: %w", err)
+	}
+
+	byID := make(map[string]media.Media, len(found))
+	for _, m := range found {
+		byID[m.ID] = m
+	}
+	for i, a := range tweet.Media {
+		m, ok := byID[a.MediaID]
+		if !ok || m.UserID != tweet.UserID {
+			return ErrInvalidMedia
+		}
+		tweet.Media[i].AltText = m.AltText
+		tweet.Media[i].Sensitive = m.Sensitive
+	}
+
+	return nil
+}
+
+// tweetsCreatedAsync starts the side effects of new tweets of userID in the
+// background.
+func (uc *usecase) tweetsCreatedAsync(ctx context.Context, userID string, tweets ...Tweet) {
+	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+	go uc.invalidateFollowersTimelinesAsync(detachedCtx, userID)
+	go uc.invalidateOwnTimelineAsync(detachedCtx, userID)
+	go uc.invalidateListTimelinesAsync(detachedCtx, userID)
+	for _, t := range tweets {
+		go uc.notifyTweetAsync(detachedCtx, t)
+		go uc.publishTweetAsync(detachedCtx, t)
+		go uc.dispatchTweetAsync(detachedCtx, t)
+		go uc.indexTweetAsync(detachedCtx, t)
+		if len(t.Links) > 0 {
+			go uc.requestPreviewsAsync(detachedCtx, t)
+		}
+	}
+}
+
+func (uc *usecase) notifyTweetAsync(ctx context.Context, tweet Tweet) {
+	if err := uc.notifier.NotifyTweet(ctx, tweet); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("tweet_id", tweet.ID).Error("failed to notify tweet")
+	}
+}
+
+func (uc *usecase) publishTweetAsync(ctx context.Context, tweet Tweet) {
+	if err := uc.publisher.PublishTweet(ctx, tweet); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("tweet_id", tweet.ID).Error("failed to publish tweet")
+	}
+}
+
+func (uc *usecase) dispatchTweetAsync(ctx context.Context, tweet Tweet) {
+	if err := uc.webhooks.DispatchTweetCreated(ctx, tweet); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("tweet_id", tweet.ID).Error("failed to dispatch tweet webhooks")
+	}
+}
+
+func (uc *usecase) indexTweetAsync(ctx context.Context, tweet Tweet) {
+	if err := uc.searcher.IndexTweet(ctx, tweet); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("tweet_id", tweet.ID).Error("failed to index tweet")
+	}
+}
+
+func (uc *usecase) requestPreviewsAsync(ctx context.Context, tweet Tweet) {
+	if err := uc.links.RequestPreviews(ctx, urlsOf([]Tweet{tweet})); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("tweet_id", tweet.ID).Error("failed to request link previews")
+	}
+}
+
+// GetTimeline returns the home timeline of userID. includeOwn overrides the
+// TimelineOwnTweets setting of the user when it is not nil.
+func (uc *usecase) GetTimeline(ctx context.Context, userID string, includeOwn *bool, limit, offset int) ([]Tweet, error) {
+	var withOwn bool
+	if includeOwn != nil {
+		withOwn = *includeOwn
+	} else {
+		setting, err := uc.userFinder.GetTimelineOwnTweetsSetting(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving timeline setting: %w", err)
+		}
+		withOwn = setting
+	}
+
+	tweets, err := uc.timeline(ctx, timelineSource{
+		getCached: func(ctx context.Context) ([]Tweet, error) {
+			return uc.cache.GetTimeline(ctx, userID, withOwn)
+		},
+		setCached: func(ctx context.Context, tweets []Tweet) error {
+			return uc.cache.SetTimeline(ctx, userID, withOwn, tweets)
+		},
+		authorIDs: func(ctx context.Context) ([]string, error) {
+			followeeIDs, err := uc.userFinder.GetFollowees(ctx, userID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get followees: %w", err)
+			}
+			if withOwn {
+				followeeIDs = append(followeeIDs, userID)
+			}
+			return followeeIDs, nil
+		},
+	}, limit, offset)
+	if err != nil || len(tweets) == 0 {
+		return tweets, err
+	}
+
+	return uc.forReader(ctx, userID, tweets)
+}
+
+// timelineSource is where a timeline is cached and whose tweets it merges.
+type timelineSource struct {
+	getCached func(ctx context.Context) ([]Tweet, error)
+	setCached func(ctx context.Context, tweets []Tweet) error
+	authorIDs func(ctx context.Context) ([]string, error)
+}
+
+// timeline returns the cached tweets of a timeline or, on a miss, merges
+// the latest tweets of its authors and caches them. The home and list
+// timelines share it.
+func (uc *usecase) timeline(ctx context.Context, source timelineSource, limit, offset int) ([]Tweet, error) {
 	logger := twcontext.Logger(ctx)
 
-	tweets, err := uc.cache.GetTimeline(ctx, userID)
+	tweets, err := source.getCached(ctx)
 	if err != nil {
 		logger.WithError(err).Warn("failed to get timeline from cache")
 	} else {
@@ -55,15 +234,15 @@ func (uc *usecase) GetTimeline(ctx context.Context, userID string, limit, offset
 		return []Tweet{}, nil
 	}
 
-	followeeIDs, err := uc.userFinder.GetFollowees(ctx, userID)
+	authorIDs, err := source.authorIDs(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get followees: %w", err)
+		return nil, err
 	}
-	if len(followeeIDs) == 0 {
+	if len(authorIDs) == 0 {
 		return []Tweet{}, nil
 	}
 
-	tweets, err = uc.tweetReader.GetTweetsByUserIDs(ctx, followeeIDs, limit, offset)
+	tweets, err = uc.tweetReader.GetTweetsByUserIDs(ctx, authorIDs, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving timeline from Cassandra: %w", err)
 	}
@@ -72,9 +251,170 @@ func (uc *usecase) GetTimeline(ctx context.Context, userID string, limit, offset
 		return []Tweet{}, nil
 	}
 
-	if err := uc.cache.SetTimeline(ctx, userID, tweets); err != nil {
+	if err := source.setCached(ctx, tweets); err != nil {
 		logger.WithError(err).Error("Failed to set timeline cache")
 	}
 
 	return tweets, nil
 }
+
+// forReader applies the sensitive media setting of the timeline owner and
+// attaches the link previews and polls. The cached timeline is shared by
+// every setting, so this runs after reading it.
+func (uc *usecase) forReader(ctx context.Context, userID string, tweets []Tweet) ([]Tweet, error) {
+	tweets, err := uc.withSensitiveMediaSetting(ctx, userID, tweets)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets, err = uc.withPreviews(ctx, tweets)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.withPolls(ctx, userID, tweets)
+}
+
+// withSensitiveMediaSetting hides or blurs the sensitive tweets of other
+// users, as chosen by userID. The setting is only read when needed.
+func (uc *usecase) withSensitiveMediaSetting(ctx context.Context, userID string, tweets []Tweet) ([]Tweet, error) {
+	sensitive := false
+	for i := range tweets {
+		if tweets[i].UserID != userID && tweets[i].IsSensitive() {
+			sensitive = true
+			break
+		}
+	}
+	if !sensitive {
+		return tweets, nil
+	}
+
+	setting, err := uc.userFinder.GetSensitiveMediaSetting(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving sensitive media setting: %w", err)
+	}
+	if setting == user.SensitiveMediaShow {
+		return tweets, nil
+	}
+
+	result := make([]Tweet, 0, len(tweets))
+	for _, t := range tweets {
+		if t.UserID != userID && t.IsSensitive() {
+			if setting == user.SensitiveMediaHide {
+				continue
+			}
+			t.Blurred = true
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// GetUserTweets returns the tweets posted by authorID as seen by viewerID.
+// A block in either direction hides them, and tweets of protected accounts
+// are only visible to the author and to approved followers. The first page starts with the pinned tweet, if any,
+// and no page repeats it in its chronological position.
+func (uc *usecase) GetUserTweets(ctx context.Context, viewerID, authorID string, limit, offset int) ([]Tweet, error) {
+	if exist, err := uc.userFinder.ExistsByID(ctx, authorID); err != nil {
+		return nil, fmt.Errorf("failed to check user ID: %w", err)
+	} else if !exist {
+		return nil, user.ErrUserNotFound
+	}
+
+	if viewerID != authorID {
+		blocked, err := uc.userFinder.HasBlockBetween(ctx, viewerID, authorID)
+		if err != nil {
+			return nil, fmt.Errorf("error checking block relationship: %w", err)
+		}
+		if blocked {
+			return nil, user.ErrUserBlocked
+		}
+
+		protected, err := uc.userFinder.IsProtected(ctx, authorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check user ID: %w", err)
+		}
+
+		if protected {
+			approved, err := uc.userFinder.IsFollowing(ctx, viewerID, authorID)
+			if err != nil {
+				return nil, fmt.Errorf("error checking follow relationship: %w", err)
+			}
+			if !approved {
+				return nil, user.ErrProtectedAccount
+			}
+		}
+	}
+
+	tweets, err := uc.tweetReader.GetTweetsByUserIDs(ctx, []string{authorID}, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user tweets: %w", err)
+	}
+
+	tweets, err = uc.withPinnedTweet(ctx, authorID, tweets, offset == 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tweets) == 0 {
+		return []Tweet{}, nil
+	}
+
+	tweets, err = uc.withPreviews(ctx, tweets)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.withPolls(ctx, viewerID, tweets)
+}
+
+// withPreviews attaches the previews fetched so far to the links of the
+// tweets. Like polls, they are read on every request, since they are
+// fetched after the tweet is created.
+func (uc *usecase) withPreviews(ctx context.Context, tweets []Tweet) ([]Tweet, error) {
+	urls := urlsOf(tweets)
+	if len(urls) == 0 {
+		return tweets, nil
+	}
+
+	previews, err := uc.links.GetPreviews(ctx, urls)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving link previews: %w", err)
+	}
+
+	for i := range tweets {
+		links := make([]Link, len(tweets[i].Links))
+		for j, l := range tweets[i].Links {
+			if p, ok := previews[l.URL]; ok {
+				l.Preview = &p
+			}
+			links[j] = l
+		}
+		tweets[i].Links = links
+	}
+
+	return tweets, nil
+}
+
+// withPolls attaches the polls of the tweets as seen by viewerID. Polls are
+// read on every request, since the cached timeline would hold stale tallies.
+func (uc *usecase) withPolls(ctx context.Context, viewerID string, tweets []Tweet) ([]Tweet, error) {
+	ids := make([]string, len(tweets))
+	for i, t := range tweets {
+		ids[i] = t.ID
+	}
+
+	polls, err := uc.polls.GetPolls(ctx, viewerID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving polls: %w", err)
+	}
+
+	for i := range tweets {
+		if p, ok := polls[tweets[i].ID]; ok {
+			tweets[i].Poll = &p
+		}
+	}
+
+	return tweets, nil
+}
@@ -3,14 +3,19 @@ package tweet_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/link"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/media"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/poll"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet/mocks"
 	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
 	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/tweettext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -20,12 +25,27 @@ type dependencies struct {
 	tweetReader   *mocks.TweetReader
 	tweetsCreator *mocks.TweetCreator
 	cache         *mocks.TimelineCache
+	notifier      *mocks.Notifier
+	publisher     *mocks.EventPublisher
+	webhooks      *mocks.WebhookDispatcher
+	scheduled     *mocks.ScheduledTweetRepository
+	drafts        *mocks.DraftRepository
+	pins          *mocks.PinRepository
+	polls         *mocks.PollReader
+	media         *mocks.MediaFinder
+	links         *mocks.LinkPreviewer
+	searcher      *mocks.TweetSearcher
+	lists         *mocks.ListReader
 }
 
 func init() {
 	twcontext.NewLogger()
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func Test_usecase_CreateTweet(t *testing.T) {
 	type input struct {
 		ctx   context.Context
@@ -44,11 +64,83 @@ func Test_usecase_CreateTweet(t *testing.T) {
 		assert       func(t *testing.T, expected, actual output)
 	}{
 		{
-			name: "should return error if userFinder.ExistsByID returns error",
+			name: "should return error if content is empty",
 			input: input{
 				ctx:   twcontext.NewTestContext(),
 				tweet: &tweet.Tweet{UserID: "u1"},
 			},
+			output:       output{err: tweet.ErrInvalidContent},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if content is too long",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: strings.Repeat("a", tweet.MaxLength-tweettext.URLLength) + " https://a.co"},
+			},
+			output:       output{err: &tweet.LengthError{Length: tweet.MaxLength + 1}},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+				assert.ErrorIs(t, actual.err, tweet.ErrInvalidContent)
+			},
+		},
+		{
+			name: "should return error if the language is not supported",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi", Language: "xx"},
+			},
+			output:       output{err: tweet.ErrUnsupportedLanguage},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if content is too long by weight",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: strings.Repeat("日", tweet.MaxLength/2) + "a"},
+			},
+			output:       output{err: &tweet.LengthError{Length: tweet.MaxLength + 1}},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should create tweet with links counted as URLLength and request their previews",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{
+					UserID:  "u1",
+					Content: strings.Repeat("a", tweet.MaxLength-tweettext.URLLength-1) + " https://Example.com/" + strings.Repeat("b", 100),
+					Links: []tweet.Link{{Entity: link.Entity{
+						URL:   "https://example.com/" + strings.Repeat("b", 100),
+						Start: tweet.MaxLength - tweettext.URLLength,
+						End:   tweet.MaxLength - tweettext.URLLength + 120,
+					}}},
+				},
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.tweet.UserID).Return(true, nil)
+				d.tweetsCreator.On("CreateTweet", in.ctx, in.tweet).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if userFinder.ExistsByID returns error",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi"},
+			},
 			output: output{err: fmt.Errorf("failed to check user ID: %w", assert.AnError)},
 			dependencies: func(in input, d *dependencies) {
 				d.userFinder.On("ExistsByID", in.ctx, in.tweet.UserID).Return(false, assert.AnError)
@@ -57,11 +149,23 @@ func Test_usecase_CreateTweet(t *testing.T) {
 				assert.Equal(t, expected.err.Error(), actual.err.Error())
 			},
 		},
+		{
+			name: "should return error if poll is invalid",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi", Poll: &poll.Poll{Options: []poll.Option{{Text: "yes"}}, Duration: time.Hour}},
+			},
+			output:       output{err: poll.ErrInvalidPoll},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, expected.err)
+			},
+		},
 		{
 			name: "should return error if user does not exist",
 			input: input{
 				ctx:   twcontext.NewTestContext(),
-				tweet: &tweet.Tweet{UserID: "u1"},
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi"},
 			},
 			output: output{err: user.ErrUserNotFound},
 			dependencies: func(in input, d *dependencies) {
@@ -71,11 +175,79 @@ func Test_usecase_CreateTweet(t *testing.T) {
 				assert.Equal(t, expected, actual)
 			},
 		},
+		{
+			name: "should return error if the tweet has too many media",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi", Media: []tweet.Attachment{{MediaID: "m1"}, {MediaID: "m2"}, {MediaID: "m3"}, {MediaID: "m4"}, {MediaID: "m5"}}},
+			},
+			output: output{err: tweet.ErrInvalidMedia},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.tweet.UserID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if media.GetMediaByIDs returns error",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi", Media: []tweet.Attachment{{MediaID: "m1"}}},
+			},
+			output: output{err: fmt.Errorf("failed to get media: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.tweet.UserID).Return(true, nil)
+				d.media.On("GetMediaByIDs", in.ctx, []string{"m1"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if a media does not exist or belongs to another user",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi", Media: []tweet.Attachment{{MediaID: "m1"}, {MediaID: "m2"}, {MediaID: "m3"}}},
+			},
+			output: output{err: tweet.ErrInvalidMedia},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.tweet.UserID).Return(true, nil)
+				d.media.On("GetMediaByIDs", in.ctx, []string{"m1", "m2", "m3"}).Return([]media.Media{
+					{ID: "m1", UserID: "u1"},
+					{ID: "m2", UserID: "u2"},
+				}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should create tweet with media uploaded by the author",
+			input: input{
+				ctx:   twcontext.NewTestContext(),
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi", Media: []tweet.Attachment{{MediaID: "m1"}, {MediaID: "m2"}}},
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.tweet.UserID).Return(true, nil)
+				d.media.On("GetMediaByIDs", in.ctx, []string{"m1", "m2"}).Return([]media.Media{
+					{ID: "m2", UserID: "u1", Sensitive: true},
+					{ID: "m1", UserID: "u1", AltText: "A cat"},
+				}, nil)
+				d.tweetsCreator.On("CreateTweet", in.ctx, mock.MatchedBy(func(t *tweet.Tweet) bool {
+					return t.Media[0].AltText == "A cat" && t.Media[1].Sensitive && t.IsSensitive()
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
 		{
 			name: "should return error if tweetsCreator.CreateTweet returns error",
 			input: input{
 				ctx:   twcontext.NewTestContext(),
-				tweet: &tweet.Tweet{UserID: "u1"},
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi"},
 			},
 			output: output{err: fmt.Errorf("failed to create tweet: %w", assert.AnError)},
 			dependencies: func(in input, d *dependencies) {
@@ -90,7 +262,7 @@ func Test_usecase_CreateTweet(t *testing.T) {
 			name: "should create tweet successfully",
 			input: input{
 				ctx:   twcontext.NewTestContext(),
-				tweet: &tweet.Tweet{UserID: "u1"},
+				tweet: &tweet.Tweet{UserID: "u1", Content: "hi"},
 			},
 			output: output{err: nil},
 			dependencies: func(in input, d *dependencies) {
@@ -109,11 +281,22 @@ func Test_usecase_CreateTweet(t *testing.T) {
 				tweetReader:   mocks.NewTweetReader(t),
 				tweetsCreator: mocks.NewTweetCreator(t),
 				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
 			}
 
 			// Synchronize with the goroutine
 			var wg sync.WaitGroup
-			if tt.name == "should create tweet successfully" {
+			if tt.output.err == nil {
 				followers := []string{"f1", "f2"}
 				ctx := twcontext.NewDetachedWithRequestID(tt.input.ctx)
 				d.userFinder.On("GetFollowers", ctx, tt.input.tweet.UserID).Return(followers, nil)
@@ -123,10 +306,45 @@ func Test_usecase_CreateTweet(t *testing.T) {
 						wg.Done()
 					})
 				}
+				wg.Add(1)
+				d.cache.On("InvalidateTimeline", ctx, tt.input.tweet.UserID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.lists.On("GetListIDsByMember", ctx, tt.input.tweet.UserID).Return([]string{"l1"}, nil)
+				wg.Add(1)
+				d.cache.On("InvalidateListTimeline", ctx, "l1").Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				wg.Add(1)
+				d.notifier.On("NotifyTweet", ctx, *tt.input.tweet).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				wg.Add(1)
+				d.publisher.On("PublishTweet", ctx, *tt.input.tweet).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				wg.Add(1)
+				d.webhooks.On("DispatchTweetCreated", ctx, *tt.input.tweet).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				wg.Add(1)
+				d.searcher.On("IndexTweet", ctx, *tt.input.tweet).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				if len(tt.input.tweet.Links) > 0 {
+					urls := make([]string, len(tt.input.tweet.Links))
+					for i, l := range tt.input.tweet.Links {
+						urls[i] = l.URL
+					}
+					wg.Add(1)
+					d.links.On("RequestPreviews", ctx, urls).Return(nil).Run(func(args mock.Arguments) {
+						wg.Done()
+					})
+				}
 			}
 			tt.dependencies(tt.input, d)
 
-			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache)
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
 			var actual output
 			actual.err = uc.CreateTweet(tt.input.ctx, tt.input.tweet)
 
@@ -151,10 +369,11 @@ func Test_usecase_CreateTweet(t *testing.T) {
 
 func Test_usecase_GetTimeline(t *testing.T) {
 	type input struct {
-		ctx    context.Context
-		userID string
-		limit  int
-		offset int
+		ctx        context.Context
+		userID     string
+		includeOwn *bool
+		limit      int
+		offset     int
 	}
 
 	type output struct {
@@ -177,9 +396,11 @@ func Test_usecase_GetTimeline(t *testing.T) {
 				limit:  10,
 				offset: 0,
 			},
-			output: output{tweets: []tweet.Tweet{{ID: "t1"}, {ID: "t2"}}, err: nil},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", Poll: &poll.Poll{TweetID: "t1", TotalVotes: 3}}, {ID: "t2"}}, err: nil},
 			dependencies: func(in input, d *dependencies) {
-				d.cache.On("GetTimeline", in.ctx, in.userID).Return([]tweet.Tweet{{ID: "t1"}, {ID: "t2"}}, nil)
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{{ID: "t1"}, {ID: "t2"}}, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1", "t2"}).Return(map[string]poll.Poll{"t1": {TweetID: "t1", TotalVotes: 3}}, nil)
 			},
 			assert: func(t *testing.T, expected, actual output) {
 				assert.Equal(t, expected, actual)
@@ -195,7 +416,8 @@ func Test_usecase_GetTimeline(t *testing.T) {
 			},
 			output: output{tweets: []tweet.Tweet{}, err: nil},
 			dependencies: func(in input, d *dependencies) {
-				d.cache.On("GetTimeline", in.ctx, in.userID).Return([]tweet.Tweet{}, nil)
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{}, nil)
 			},
 			assert: func(t *testing.T, expected, actual output) {
 				assert.Equal(t, expected, actual)
@@ -211,7 +433,8 @@ func Test_usecase_GetTimeline(t *testing.T) {
 			},
 			output: output{tweets: nil, err: fmt.Errorf("failed to get followees: %w", assert.AnError)},
 			dependencies: func(in input, d *dependencies) {
-				d.cache.On("GetTimeline", in.ctx, in.userID).Return(nil, assert.AnError)
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return(nil, assert.AnError)
 				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return(nil, assert.AnError)
 			},
 			assert: func(t *testing.T, expected, actual output) {
@@ -228,7 +451,8 @@ func Test_usecase_GetTimeline(t *testing.T) {
 			},
 			output: output{tweets: []tweet.Tweet{}, err: nil},
 			dependencies: func(in input, d *dependencies) {
-				d.cache.On("GetTimeline", in.ctx, in.userID).Return(nil, assert.AnError)
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return(nil, assert.AnError)
 				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return([]string{}, nil)
 			},
 			assert: func(t *testing.T, expected, actual output) {
@@ -245,7 +469,8 @@ func Test_usecase_GetTimeline(t *testing.T) {
 			},
 			output: output{tweets: nil, err: fmt.Errorf("error retrieving timeline from Cassandra: %w", assert.AnError)},
 			dependencies: func(in input, d *dependencies) {
-				d.cache.On("GetTimeline", in.ctx, in.userID).Return(nil, assert.AnError)
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return(nil, assert.AnError)
 				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return([]string{"f1", "f2"}, nil)
 				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{"f1", "f2"}, in.limit, in.offset).Return(nil, assert.AnError)
 			},
@@ -263,7 +488,8 @@ func Test_usecase_GetTimeline(t *testing.T) {
 			},
 			output: output{tweets: []tweet.Tweet{}, err: nil},
 			dependencies: func(in input, d *dependencies) {
-				d.cache.On("GetTimeline", in.ctx, in.userID).Return(nil, assert.AnError)
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return(nil, assert.AnError)
 				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return([]string{"f1", "f2"}, nil)
 				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{"f1", "f2"}, in.limit, in.offset).Return([]tweet.Tweet{}, nil)
 			},
@@ -281,15 +507,469 @@ func Test_usecase_GetTimeline(t *testing.T) {
 			},
 			output: output{tweets: []tweet.Tweet{{ID: "t1"}, {ID: "t2"}}, err: nil},
 			dependencies: func(in input, d *dependencies) {
-				d.cache.On("GetTimeline", in.ctx, in.userID).Return(nil, assert.AnError)
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return(nil, assert.AnError)
 				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return([]string{"f1", "f2"}, nil)
 				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{"f1", "f2"}, in.limit, in.offset).Return([]tweet.Tweet{{ID: "t1"}, {ID: "t2"}}, nil)
-				d.cache.On("SetTimeline", in.ctx, in.userID, []tweet.Tweet{{ID: "t1"}, {ID: "t2"}}).Return(nil)
+				d.cache.On("SetTimeline", in.ctx, in.userID, false, []tweet.Tweet{{ID: "t1"}, {ID: "t2"}}).Return(nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1", "t2"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if polls.GetPolls returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{err: fmt.Errorf("error retrieving polls: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{{ID: "t1"}}, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should hide sensitive tweets of others if the setting is hide",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", UserID: "u1", Sensitive: true}, {ID: "t3", UserID: "u2"}}, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{
+					{ID: "t1", UserID: "u1", Sensitive: true},
+					{ID: "t2", UserID: "u2", Media: []tweet.Attachment{{MediaID: "m1", Sensitive: true}}},
+					{ID: "t3", UserID: "u2"},
+				}, nil)
+				d.userFinder.On("GetSensitiveMediaSetting", in.ctx, in.userID).Return(user.SensitiveMediaHide, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1", "t3"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should blur sensitive tweets of others if the setting is blur",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", UserID: "u1", Sensitive: true}, {ID: "t2", UserID: "u2", Sensitive: true, Blurred: true}}, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{
+					{ID: "t1", UserID: "u1", Sensitive: true},
+					{ID: "t2", UserID: "u2", Sensitive: true},
+				}, nil)
+				d.userFinder.On("GetSensitiveMediaSetting", in.ctx, in.userID).Return(user.SensitiveMediaBlur, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1", "t2"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should attach the fetched link previews",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{tweets: []tweet.Tweet{
+				{ID: "t1", Links: []tweet.Link{
+					{Entity: link.Entity{URL: "https://a.com/"}, Preview: &link.Preview{URL: "https://a.com/", Title: "A"}},
+					{Entity: link.Entity{URL: "https://b.com/"}},
+				}},
+				{ID: "t2", Links: []tweet.Link{
+					{Entity: link.Entity{URL: "https://a.com/"}, Preview: &link.Preview{URL: "https://a.com/", Title: "A"}},
+				}},
+			}, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{
+					{ID: "t1", Links: []tweet.Link{{Entity: link.Entity{URL: "https://a.com/"}}, {Entity: link.Entity{URL: "https://b.com/"}}}},
+					{ID: "t2", Links: []tweet.Link{{Entity: link.Entity{URL: "https://a.com/"}}}},
+				}, nil)
+				d.links.On("GetPreviews", in.ctx, []string{"https://a.com/", "https://b.com/"}).
+					Return(map[string]link.Preview{"https://a.com/": {URL: "https://a.com/", Title: "A"}}, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1", "t2"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if links.GetPreviews returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{err: fmt.Errorf("error retrieving link previews: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{{ID: "t1", Links: []tweet.Link{{Entity: link.Entity{URL: "https://a.com/"}}}}}, nil)
+				d.links.On("GetPreviews", in.ctx, []string{"https://a.com/"}).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if userFinder.GetSensitiveMediaSetting returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{err: fmt.Errorf("error retrieving sensitive media setting: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return([]tweet.Tweet{{ID: "t1", UserID: "u2", Sensitive: true}}, nil)
+				d.userFinder.On("GetSensitiveMediaSetting", in.ctx, in.userID).Return(user.SensitiveMedia(""), assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should include own tweets if the user setting is on",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t2", UserID: "u1"}, {ID: "t1", UserID: "f1"}}, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(true, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, true).Return(nil, assert.AnError)
+				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return([]string{"f1"}, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{"f1", "u1"}, in.limit, in.offset).Return([]tweet.Tweet{{ID: "t2", UserID: "u1"}, {ID: "t1", UserID: "f1"}}, nil)
+				d.cache.On("SetTimeline", in.ctx, in.userID, true, []tweet.Tweet{{ID: "t2", UserID: "u1"}, {ID: "t1", UserID: "f1"}}).Return(nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t2", "t1"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should include own tweets even without followees if the user setting is on",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", UserID: "u1"}}, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(true, nil)
+				d.cache.On("GetTimeline", in.ctx, in.userID, true).Return(nil, assert.AnError)
+				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return([]string{}, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{"u1"}, in.limit, in.offset).Return([]tweet.Tweet{{ID: "t1", UserID: "u1"}}, nil)
+				d.cache.On("SetTimeline", in.ctx, in.userID, true, []tweet.Tweet{{ID: "t1", UserID: "u1"}}).Return(nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should include own tweets if the request overrides the user setting",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				userID:     "u1",
+				includeOwn: boolPtr(true),
+				limit:      10,
+				offset:     0,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t2", UserID: "u1"}}, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.cache.On("GetTimeline", in.ctx, in.userID, true).Return([]tweet.Tweet{{ID: "t2", UserID: "u1"}}, nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t2"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should exclude own tweets if the request overrides the user setting",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				userID:     "u1",
+				includeOwn: boolPtr(false),
+				limit:      10,
+				offset:     0,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t1", UserID: "f1"}}, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.cache.On("GetTimeline", in.ctx, in.userID, false).Return(nil, assert.AnError)
+				d.userFinder.On("GetFollowees", in.ctx, in.userID).Return([]string{"f1"}, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{"f1"}, in.limit, in.offset).Return([]tweet.Tweet{{ID: "t1", UserID: "f1"}}, nil)
+				d.cache.On("SetTimeline", in.ctx, in.userID, false, []tweet.Tweet{{ID: "t1", UserID: "f1"}}).Return(nil)
+				d.polls.On("GetPolls", in.ctx, in.userID, []string{"t1"}).Return(map[string]poll.Poll{}, nil)
 			},
 			assert: func(t *testing.T, expected, actual output) {
 				assert.Equal(t, expected, actual)
 			},
 		},
+		{
+			name: "should return error if userFinder.GetTimelineOwnTweetsSetting returns error",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				limit:  10,
+				offset: 0,
+			},
+			output: output{err: fmt.Errorf("error retrieving timeline setting: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("GetTimelineOwnTweetsSetting", in.ctx, in.userID).Return(false, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				tweetReader:   mocks.NewTweetReader(t),
+				tweetsCreator: mocks.NewTweetCreator(t),
+				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
+			var actual output
+			actual.tweets, actual.err = uc.GetTimeline(tt.input.ctx, tt.input.userID, tt.input.includeOwn, tt.input.limit, tt.input.offset)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_GetUserTweets(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		viewerID string
+		authorID string
+		limit    int
+		offset   int
+	}
+
+	type output struct {
+		err    error
+		tweets []tweet.Tweet
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if author does not exist",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if there is a block between viewer and author",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+			},
+			output: output{err: user.ErrUserBlocked},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.viewerID, in.authorID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if author is protected and viewer is not an approved follower",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+			},
+			output: output{err: user.ErrProtectedAccount},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.viewerID, in.authorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("IsFollowing", in.ctx, in.viewerID, in.authorID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return tweets if author is protected and viewer is an approved follower",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t1"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.viewerID, in.authorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("IsFollowing", in.ctx, in.viewerID, in.authorID).Return(true, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{in.authorID}, in.limit, in.offset).Return([]tweet.Tweet{{ID: "t1"}}, nil)
+				d.pins.On("GetPinnedTweet", in.ctx, in.authorID).Return(nil, nil)
+				d.polls.On("GetPolls", in.ctx, in.viewerID, []string{"t1"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return own tweets without visibility checks",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u1",
+				limit:    10,
+			},
+			output: output{tweets: []tweet.Tweet{}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{in.authorID}, in.limit, in.offset).Return(nil, nil)
+				d.pins.On("GetPinnedTweet", in.ctx, in.authorID).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return the pinned tweet first on the first page",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t2", Pinned: true}, {ID: "t3"}, {ID: "t1"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.viewerID, in.authorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, in.authorID).Return(false, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{in.authorID}, in.limit, in.offset).Return([]tweet.Tweet{{ID: "t3"}, {ID: "t2"}, {ID: "t1"}}, nil)
+				d.pins.On("GetPinnedTweet", in.ctx, in.authorID).Return(&tweet.Tweet{ID: "t2"}, nil)
+				d.polls.On("GetPolls", in.ctx, in.viewerID, []string{"t2", "t3", "t1"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should not repeat the pinned tweet after the first page",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+				offset:   10,
+			},
+			output: output{tweets: []tweet.Tweet{{ID: "t1"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.viewerID, in.authorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, in.authorID).Return(false, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{in.authorID}, in.limit, in.offset).Return([]tweet.Tweet{{ID: "t2"}, {ID: "t1"}}, nil)
+				d.pins.On("GetPinnedTweet", in.ctx, in.authorID).Return(&tweet.Tweet{ID: "t2"}, nil)
+				d.polls.On("GetPolls", in.ctx, in.viewerID, []string{"t1"}).Return(map[string]poll.Poll{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if pins.GetPinnedTweet returns error",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+			},
+			output: output{err: fmt.Errorf("error retrieving pinned tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.viewerID, in.authorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, in.authorID).Return(false, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{in.authorID}, in.limit, in.offset).Return(nil, nil)
+				d.pins.On("GetPinnedTweet", in.ctx, in.authorID).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if tweetReader.GetTweetsByUserIDs returns error",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				viewerID: "u1",
+				authorID: "u2",
+				limit:    10,
+			},
+			output: output{err: fmt.Errorf("error retrieving user tweets: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.authorID).Return(true, nil)
+				d.userFinder.On("HasBlockBetween", in.ctx, in.viewerID, in.authorID).Return(false, nil)
+				d.userFinder.On("IsProtected", in.ctx, in.authorID).Return(false, nil)
+				d.tweetReader.On("GetTweetsByUserIDs", in.ctx, []string{in.authorID}, in.limit, in.offset).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -298,12 +978,23 @@ func Test_usecase_GetTimeline(t *testing.T) {
 				tweetReader:   mocks.NewTweetReader(t),
 				tweetsCreator: mocks.NewTweetCreator(t),
 				cache:         mocks.NewTimelineCache(t),
+				notifier:      mocks.NewNotifier(t),
+				publisher:     mocks.NewEventPublisher(t),
+				webhooks:      mocks.NewWebhookDispatcher(t),
+				scheduled:     mocks.NewScheduledTweetRepository(t),
+				drafts:        mocks.NewDraftRepository(t),
+				pins:          mocks.NewPinRepository(t),
+				polls:         mocks.NewPollReader(t),
+				media:         mocks.NewMediaFinder(t),
+				links:         mocks.NewLinkPreviewer(t),
+				searcher:      mocks.NewTweetSearcher(t),
+				lists:         mocks.NewListReader(t),
 			}
 			tt.dependencies(tt.input, d)
 
-			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache)
+			uc := tweet.NewTweetUseCase(d.userFinder, d.tweetReader, d.tweetsCreator, d.cache, d.notifier, d.publisher, d.webhooks, d.scheduled, d.drafts, d.pins, d.polls, d.media, d.links, d.searcher, d.lists)
 			var actual output
-			actual.tweets, actual.err = uc.GetTimeline(tt.input.ctx, tt.input.userID, tt.input.limit, tt.input.offset)
+			actual.tweets, actual.err = uc.GetUserTweets(tt.input.ctx, tt.input.viewerID, tt.input.authorID, tt.input.limit, tt.input.offset)
 			tt.assert(t, tt.output, actual)
 		})
 	}
@@ -0,0 +1,132 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// purgeBatchSize bounds how many expired accounts a single purge run deletes.
+const purgeBatchSize = 100
+
+// DeactivateUser hides the user, their profile and their tweets. The account
+// can be reactivated within DeactivationGracePeriod; after that it is
+// permanently deleted by PurgeDeactivatedUsers.
+func (uc *userUseCase) DeactivateUser(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrInvalidInput
+	}
+
+	if exists, err := uc.finder.ExistsByID(ctx, id); err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", id, err)
+	} else if !exists {
+		return ErrUserNotFound
+	}
+
+	followers, err := uc.finder.GetFollowers(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	if err := uc.creator.DeactivateUser(ctx, id); err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+	go uc.invalidateTimelines(detachedCtx, append(followers, id))
+	go uc.searchUserDeactivatedAsync(detachedCtx, id)
+
+	return nil
+}
+
+func (uc *userUseCase) ReactivateUser(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrInvalidInput
+	}
+
+	deactivatedAt, err := uc.finder.GetDeactivatedAt(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", id, err)
+	}
+
+	if deactivatedAt.IsZero() {
+		return ErrUserNotDeactivated
+	}
+
+	if time.Since(deactivatedAt) > DeactivationGracePeriod {
+		return ErrReactivationExpired
+	}
+
+	followers, err := uc.finder.GetFollowers(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	if err := uc.creator.ReactivateUser(ctx, id); err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+	go uc.invalidateTimelines(detachedCtx, followers)
+	go uc.searchUserReactivatedAsync(detachedCtx, id)
+
+	return nil
+}
+
+// PurgeDeactivatedUsers permanently deletes accounts deactivated for longer
+// than DeactivationGracePeriod. Follows and tweets are removed by the
+// database cascade and from the search index; the user's cached timeline
+// is purged and their followers' cached timelines are invalidated. It
+// returns the number of deleted accounts.
+func (uc *userUseCase) PurgeDeactivatedUsers(ctx context.Context) (int, error) {
+	logger := twcontext.Logger(ctx)
+
+	ids, err := uc.finder.GetDeactivatedBefore(ctx, time.Now().Add(-DeactivationGracePeriod), purgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deactivated users: %w", err)
+	}
+
+	deleted := 0
+	for _, id := range ids {
+		followers, err := uc.finder.GetFollowers(ctx, id)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to get followers of user %s: %w", id, err)
+		}
+
+		if err := uc.creator.DeleteUser(ctx, id); err != nil {
+			return deleted, fmt.Errorf("failed to delete user %s: %w", id, err)
+		}
+		deleted++
+
+		// The index can be rebuilt, so a failure does not stop the purge.
+		if err := uc.search.UserDeleted(ctx, id); err != nil {
+			logger.WithError(err).WithField("user_id", id).Error("failed to remove user from search index")
+		}
+		uc.invalidateTimelines(ctx, append(followers, id))
+		logger.WithField("user_id", id).Info("deactivated user permanently deleted")
+	}
+
+	return deleted, nil
+}
+
+func (uc *userUseCase) invalidateTimelines(ctx context.Context, userIDs []string) {
+	for _, userID := range userIDs {
+		uc.invalidateTimelineAsync(ctx, userID)
+	}
+}
+
+// The search index can be rebuilt, so a failure is only logged, like in
+// PurgeDeactivatedUsers.
+func (uc *userUseCase) searchUserDeactivatedAsync(ctx context.Context, id string) {
+	if err := uc.search.UserDeactivated(ctx, id); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("user_id", id).Error("failed to remove user from search index")
+	}
+}
+
+func (uc *userUseCase) searchUserReactivatedAsync(ctx context.Context, id string) {
+	if err := uc.search.UserReactivated(ctx, id); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("user_id", id).Error("failed to restore user in search index")
+	}
+}
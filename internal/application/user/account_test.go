@@ -0,0 +1,392 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_userUseCase_DeactivateUser(t *testing.T) {
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	type output struct {
+		err error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+		search   *mocks.SearchIndexer
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		invalidated  []string
+		searched     bool
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if id is empty",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if user does not exist or is already deactivated",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.id).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if creator.DeactivateUser returns error",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output: output{err: fmt.Errorf("failed to deactivate user: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.id).Return(true, nil)
+				d.finder.On("GetFollowers", in.ctx, in.id).Return([]string{"u2"}, nil)
+				d.creator.On("DeactivateUser", in.ctx, in.id).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should deactivate user, invalidate own and followers' timelines and remove their tweets from search",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output:      output{err: nil},
+			searched:    true,
+			invalidated: []string{"u2", "u3", "u1"},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.id).Return(true, nil)
+				d.finder.On("GetFollowers", in.ctx, in.id).Return([]string{"u2", "u3"}, nil)
+				d.creator.On("DeactivateUser", in.ctx, in.id).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+				search:   mocks.NewSearchIndexer(t),
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(len(tt.invalidated))
+			for _, userID := range tt.invalidated {
+				d.cache.On("InvalidateTimeline", twcontext.NewDetachedWithRequestID(tt.input.ctx), userID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			}
+			if tt.searched {
+				wg.Add(1)
+				d.search.On("UserDeactivated", twcontext.NewDetachedWithRequestID(tt.input.ctx), tt.input.id).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), d.search)
+			var actual output
+			actual.err = uc.DeactivateUser(tt.input.ctx, tt.input.id)
+
+			// Wait for the goroutines to finish
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Error("goroutine did not finish in time")
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_ReactivateUser(t *testing.T) {
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	type output struct {
+		err error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+		search   *mocks.SearchIndexer
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		invalidated  []string
+		searched     bool
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output: output{err: fmt.Errorf("failed to check user with ID u1: %w", user.ErrUserNotFound)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedAt", in.ctx, in.id).Return(time.Time{}, user.ErrUserNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.ErrorIs(t, actual.err, user.ErrUserNotFound)
+			},
+		},
+		{
+			name: "should return error if user is active",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output: output{err: user.ErrUserNotDeactivated},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedAt", in.ctx, in.id).Return(time.Time{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if the grace period has expired",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output: output{err: user.ErrReactivationExpired},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedAt", in.ctx, in.id).Return(time.Now().Add(-user.DeactivationGracePeriod-time.Hour), nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should reactivate user, invalidate followers' timelines and restore their tweets in search",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output:      output{err: nil},
+			searched:    true,
+			invalidated: []string{"u2"},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedAt", in.ctx, in.id).Return(time.Now().Add(-24*time.Hour), nil)
+				d.finder.On("GetFollowers", in.ctx, in.id).Return([]string{"u2"}, nil)
+				d.creator.On("ReactivateUser", in.ctx, in.id).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+				search:   mocks.NewSearchIndexer(t),
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(len(tt.invalidated))
+			for _, userID := range tt.invalidated {
+				d.cache.On("InvalidateTimeline", twcontext.NewDetachedWithRequestID(tt.input.ctx), userID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			}
+			if tt.searched {
+				wg.Add(1)
+				d.search.On("UserReactivated", twcontext.NewDetachedWithRequestID(tt.input.ctx), tt.input.id).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), d.search)
+			var actual output
+			actual.err = uc.ReactivateUser(tt.input.ctx, tt.input.id)
+
+			// Wait for the goroutines to finish
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Error("goroutine did not finish in time")
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_PurgeDeactivatedUsers(t *testing.T) {
+	type input struct {
+		ctx context.Context
+	}
+
+	type output struct {
+		deleted int
+		err     error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+		search   *mocks.SearchIndexer
+	}
+
+	before := mock.AnythingOfType("time.Time")
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if finder.GetDeactivatedBefore returns error",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{err: fmt.Errorf("failed to get deactivated users: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedBefore", in.ctx, before, 100).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should do nothing if no account expired",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{deleted: 0},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedBefore", in.ctx, before, 100).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should stop and report progress if creator.DeleteUser returns error",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{deleted: 1, err: fmt.Errorf("failed to delete user u2: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedBefore", in.ctx, before, 100).Return([]string{"u1", "u2"}, nil)
+				d.finder.On("GetFollowers", in.ctx, "u1").Return(nil, nil)
+				d.creator.On("DeleteUser", in.ctx, "u1").Return(nil)
+				d.search.On("UserDeleted", in.ctx, "u1").Return(nil)
+				d.cache.On("InvalidateTimeline", in.ctx, "u1").Return(nil)
+				d.finder.On("GetFollowers", in.ctx, "u2").Return(nil, nil)
+				d.creator.On("DeleteUser", in.ctx, "u2").Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should delete expired accounts even if the search index fails and purge cached timelines",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{deleted: 1},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("GetDeactivatedBefore", in.ctx, before, 100).Return([]string{"u1"}, nil)
+				d.finder.On("GetFollowers", in.ctx, "u1").Return([]string{"u2", "u3"}, nil)
+				d.creator.On("DeleteUser", in.ctx, "u1").Return(nil)
+				d.search.On("UserDeleted", in.ctx, "u1").Return(assert.AnError)
+				d.cache.On("InvalidateTimeline", in.ctx, "u1").Return(nil)
+				d.cache.On("InvalidateTimeline", in.ctx, "u2").Return(nil)
+				d.cache.On("InvalidateTimeline", in.ctx, "u3").Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+				search:   mocks.NewSearchIndexer(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), d.search)
+			var actual output
+			actual.deleted, actual.err = uc.PurgeDeactivatedUsers(tt.input.ctx)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
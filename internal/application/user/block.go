@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// BlockUser makes blockerID block blockedID. Follows and pending follow
+// requests between both users are removed, so both timelines are invalidated.
+func (uc *userUseCase) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	if blockerID == "" || blockedID == "" {
+		return ErrInvalidInput
+	}
+
+	if blockerID == blockedID {
+		return ErrCannotBlockSelf
+	}
+
+	if err := uc.checkUsersExist(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	blocking, err := uc.finder.IsBlocking(ctx, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("error checking block relationship: %w", err)
+	}
+	if blocking {
+		return ErrAlreadyBlocked
+	}
+
+	if err := uc.creator.BlockUser(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("error blocking user: %w", err)
+	}
+
+	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+	go uc.invalidateTimelineAsync(detachedCtx, blockerID)
+	go uc.invalidateTimelineAsync(detachedCtx, blockedID)
+
+	return nil
+}
+
+func (uc *userUseCase) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	if blockerID == "" || blockedID == "" {
+		return ErrInvalidInput
+	}
+
+	if blockerID == blockedID {
+		return ErrCannotBlockSelf
+	}
+
+	if err := uc.checkUsersExist(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	blocking, err := uc.finder.IsBlocking(ctx, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("error checking block relationship: %w", err)
+	}
+	if !blocking {
+		return ErrNotBlocked
+	}
+
+	if err := uc.creator.UnblockUser(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("error unblocking user: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *userUseCase) checkUsersExist(ctx context.Context, userID, otherID string) error {
+	if exists, err := uc.finder.ExistsByID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	} else if !exists {
+		return ErrUserNotFound
+	}
+
+	if exists, err := uc.finder.ExistsByID(ctx, otherID); err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", otherID, err)
+	} else if !exists {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_userUseCase_BlockUser(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		blockerID string
+		blockedID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if blockerID equals blockedID",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				blockerID: "u1",
+				blockedID: "u1",
+			},
+			output:       output{err: user.ErrCannotBlockSelf},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if blocked user does not exist",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				blockerID: "u1",
+				blockedID: "u2",
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.blockerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.blockedID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if already blocked",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				blockerID: "u1",
+				blockedID: "u2",
+			},
+			output: output{err: user.ErrAlreadyBlocked},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.blockerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.blockedID).Return(true, nil)
+				d.finder.On("IsBlocking", in.ctx, in.blockerID, in.blockedID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if creator.BlockUser returns error",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				blockerID: "u1",
+				blockedID: "u2",
+			},
+			output: output{err: fmt.Errorf("error blocking user: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.blockerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.blockedID).Return(true, nil)
+				d.finder.On("IsBlocking", in.ctx, in.blockerID, in.blockedID).Return(false, nil)
+				d.creator.On("BlockUser", in.ctx, in.blockerID, in.blockedID).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should block user successfully",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				blockerID: "u1",
+				blockedID: "u2",
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.blockerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.blockedID).Return(true, nil)
+				d.finder.On("IsBlocking", in.ctx, in.blockerID, in.blockedID).Return(false, nil)
+				d.creator.On("BlockUser", in.ctx, in.blockerID, in.blockedID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+
+			// Synchronize with the goroutines
+			var wg sync.WaitGroup
+			if tt.name == "should block user successfully" {
+				ctx := twcontext.NewDetachedWithRequestID(tt.input.ctx)
+				wg.Add(2)
+				for _, userID := range []string{tt.input.blockerID, tt.input.blockedID} {
+					d.cache.On("InvalidateTimeline", ctx, userID).Return(nil).Run(func(args mock.Arguments) {
+						wg.Done()
+					})
+				}
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.err = uc.BlockUser(tt.input.ctx, tt.input.blockerID, tt.input.blockedID)
+
+			// Wait for the goroutines to finish
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Error("goroutine did not finish in time")
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_UnblockUser(t *testing.T) {
+	type input struct {
+		ctx       context.Context
+		blockerID string
+		blockedID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if not blocked",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				blockerID: "u1",
+				blockedID: "u2",
+			},
+			output: output{err: user.ErrNotBlocked},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.blockerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.blockedID).Return(true, nil)
+				d.finder.On("IsBlocking", in.ctx, in.blockerID, in.blockedID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should unblock user successfully",
+			input: input{
+				ctx:       twcontext.NewTestContext(),
+				blockerID: "u1",
+				blockedID: "u2",
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.blockerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.blockedID).Return(true, nil)
+				d.finder.On("IsBlocking", in.ctx, in.blockerID, in.blockedID).Return(true, nil)
+				d.creator.On("UnblockUser", in.ctx, in.blockerID, in.blockedID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.err = uc.UnblockUser(tt.input.ctx, tt.input.blockerID, tt.input.blockedID)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// FollowUsers follows every user in followeeIDs on behalf of followerID and
+// reports the outcome for each of them. Existence and follow checks are done
+// with one query each instead of one query per followee, and the follower's
+// timeline is invalidated once at the end.
+func (uc *userUseCase) FollowUsers(ctx context.Context, followerID string, followeeIDs []string) ([]FollowResult, error) {
+	followeeIDs = uniqueIDs(followeeIDs)
+	if followerID == "" || len(followeeIDs) == 0 {
+		return nil, ErrInvalidInput
+	}
+	if len(followeeIDs) > MaxFollowBatchSize {
+		return nil, ErrFollowBatchTooLarge
+	}
+
+	if followerExists, err := uc.finder.ExistsByID(ctx, followerID); err != nil {
+		return nil, fmt.Errorf("failed to check follower with ID %s: %w", followerID, err)
+	} else if !followerExists {
+		return nil, ErrUserNotFound
+	}
+
+	users, err := uc.finder.FindByIDs(ctx, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check followees: %w", err)
+	}
+
+	protected := make(map[string]bool, len(users))
+	for _, u := range users {
+		protected[u.ID] = u.Protected
+	}
+
+	following, err := uc.finder.GetFollowingAmong(ctx, followerID, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error checking follow relationships: %w", err)
+	}
+	alreadyFollowing := toSet(following)
+
+	blockedIDs, err := uc.finder.GetBlockedAmong(ctx, followerID, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error checking block relationships: %w", err)
+	}
+	blocked := toSet(blockedIDs)
+
+	requestedIDs, err := uc.finder.GetFollowRequestsAmong(ctx, followerID, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error checking follow requests: %w", err)
+	}
+	alreadyRequested := toSet(requestedIDs)
+
+	results := make([]FollowResult, len(followeeIDs))
+	var toFollow, toRequest []string
+	for i, followeeID := range followeeIDs {
+		isProtected, exists := protected[followeeID]
+
+		var status FollowStatus
+		switch {
+		case followeeID == followerID:
+			status = FollowStatusSelf
+		case !exists:
+			status = FollowStatusNotFound
+		case alreadyFollowing[followeeID]:
+			status = FollowStatusAlreadyFollowing
+		case alreadyRequested[followeeID]:
+			status = FollowStatusAlreadyRequested
+		case blocked[followeeID]:
+			status = FollowStatusBlocked
+		case isProtected:
+			status = FollowStatusPending
+			toRequest = append(toRequest, followeeID)
+		default:
+			status = FollowStatusFollowing
+			toFollow = append(toFollow, followeeID)
+		}
+
+		results[i] = FollowResult{FolloweeID: followeeID, Status: status}
+	}
+
+	if len(toRequest) > 0 {
+		if err := uc.creator.CreateFollowRequests(ctx, followerID, toRequest); err != nil {
+			return nil, fmt.Errorf("error creating follow requests: %w", err)
+		}
+	}
+
+	if len(toFollow) > 0 {
+		if err := uc.creator.FollowUsers(ctx, followerID, toFollow); err != nil {
+			return nil, fmt.Errorf("error following users: %w", err)
+		}
+
+		go uc.invalidateTimelineAsync(twcontext.NewDetachedWithRequestID(ctx), followerID)
+	}
+
+	if len(toFollow) > 0 || len(toRequest) > 0 {
+		go uc.notifyFollowsAsync(twcontext.NewDetachedWithRequestID(ctx), followerID, toFollow, toRequest)
+	}
+
+	return results, nil
+}
+
+// UnfollowUsers is the batch counterpart of UnfollowUser: pending follow
+// requests to the users that are not followed yet are withdrawn.
+func (uc *userUseCase) UnfollowUsers(ctx context.Context, followerID string, followeeIDs []string) ([]FollowResult, error) {
+	followeeIDs = uniqueIDs(followeeIDs)
+	if followerID == "" || len(followeeIDs) == 0 {
+		return nil, ErrInvalidInput
+	}
+	if len(followeeIDs) > MaxFollowBatchSize {
+		return nil, ErrFollowBatchTooLarge
+	}
+
+	if followerExists, err := uc.finder.ExistsByID(ctx, followerID); err != nil {
+		return nil, fmt.Errorf("failed to check follower with ID %s: %w", followerID, err)
+	} else if !followerExists {
+		return nil, ErrUserNotFound
+	}
+
+	users, err := uc.finder.FindByIDs(ctx, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check followees: %w", err)
+	}
+
+	existing := make(map[string]bool, len(users))
+	for _, u := range users {
+		existing[u.ID] = true
+	}
+
+	following, err := uc.finder.GetFollowingAmong(ctx, followerID, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error checking follow relationships: %w", err)
+	}
+	isFollowing := toSet(following)
+
+	requestedIDs, err := uc.finder.GetFollowRequestsAmong(ctx, followerID, followeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error checking follow requests: %w", err)
+	}
+	requested := toSet(requestedIDs)
+
+	results := make([]FollowResult, len(followeeIDs))
+	var toUnfollow, toWithdraw []string
+	for i, followeeID := range followeeIDs {
+		var status FollowStatus
+		switch {
+		case followeeID == followerID:
+			status = FollowStatusSelf
+		case !existing[followeeID]:
+			status = FollowStatusNotFound
+		case isFollowing[followeeID]:
+			status = FollowStatusUnfollowed
+			toUnfollow = append(toUnfollow, followeeID)
+		case requested[followeeID]:
+			status = FollowStatusWithdrawn
+			toWithdraw = append(toWithdraw, followeeID)
+		default:
+			status = FollowStatusNotFollowing
+		}
+
+		results[i] = FollowResult{FolloweeID: followeeID, Status: status}
+	}
+
+	if len(toWithdraw) > 0 {
+		if err := uc.creator.DeleteFollowRequests(ctx, followerID, toWithdraw); err != nil {
+			return nil, fmt.Errorf("error withdrawing follow requests: %w", err)
+		}
+	}
+
+	if len(toUnfollow) > 0 {
+		if err := uc.creator.UnfollowUsers(ctx, followerID, toUnfollow); err != nil {
+			return nil, fmt.Errorf("error unfollowing users: %w", err)
+		}
+
+		go uc.invalidateTimelineAsync(twcontext.NewDetachedWithRequestID(ctx), followerID)
+	}
+
+	return results, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	return unique
+}
+
+func toSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+
+	return set
+}
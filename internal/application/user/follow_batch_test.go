@@ -0,0 +1,399 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_userUseCase_FollowUsers(t *testing.T) {
+	type input struct {
+		ctx         context.Context
+		followerID  string
+		followeeIDs []string
+	}
+
+	type output struct {
+		results []user.FollowResult
+		err     error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		invalidates  bool
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if followeeIDs is empty",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{},
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if batch exceeds the maximum size",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeIDs: func() []string {
+					ids := make([]string, user.MaxFollowBatchSize+1)
+					for i := range ids {
+						ids[i] = fmt.Sprintf("u%d", i)
+					}
+					return ids
+				}(),
+			},
+			output:       output{err: user.ErrFollowBatchTooLarge},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if follower does not exist",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2"},
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if finder.FindByIDs returns error",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2"},
+			},
+			output: output{err: fmt.Errorf("failed to check followees: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("FindByIDs", in.ctx, in.followeeIDs).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if creator.FollowUsers returns error",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2"},
+			},
+			output: output{err: fmt.Errorf("error following users: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("FindByIDs", in.ctx, in.followeeIDs).Return([]user.User{{ID: "f2"}}, nil)
+				d.finder.On("GetFollowingAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{}, nil)
+				d.finder.On("GetBlockedAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{}, nil)
+				d.finder.On("GetFollowRequestsAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{}, nil)
+				d.creator.On("FollowUsers", in.ctx, in.followerID, []string{"f2"}).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should not write nor invalidate if nothing to follow",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2", "f1"},
+			},
+			output: output{results: []user.FollowResult{
+				{FolloweeID: "f2", Status: user.FollowStatusAlreadyFollowing},
+				{FolloweeID: "f1", Status: user.FollowStatusSelf},
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("FindByIDs", in.ctx, in.followeeIDs).Return([]user.User{{ID: "f1"}, {ID: "f2"}}, nil)
+				d.finder.On("GetFollowingAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{"f2"}, nil)
+				d.finder.On("GetBlockedAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{}, nil)
+				d.finder.On("GetFollowRequestsAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should follow users and report per-item results",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2", "f3", "f2", "f4", "f5", "f6", "f7", "f1"},
+			},
+			output: output{results: []user.FollowResult{
+				{FolloweeID: "f2", Status: user.FollowStatusFollowing},
+				{FolloweeID: "f3", Status: user.FollowStatusAlreadyFollowing},
+				{FolloweeID: "f4", Status: user.FollowStatusNotFound},
+				{FolloweeID: "f5", Status: user.FollowStatusPending},
+				{FolloweeID: "f6", Status: user.FollowStatusBlocked},
+				{FolloweeID: "f7", Status: user.FollowStatusAlreadyRequested},
+				{FolloweeID: "f1", Status: user.FollowStatusSelf},
+			}},
+			invalidates: true,
+			dependencies: func(in input, d *dependencies) {
+				unique := []string{"f2", "f3", "f4", "f5", "f6", "f7", "f1"}
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("FindByIDs", in.ctx, unique).Return([]user.User{{ID: "f1"}, {ID: "f2"}, {ID: "f3"}, {ID: "f5", Protected: true}, {ID: "f6"}, {ID: "f7", Protected: true}}, nil)
+				d.finder.On("GetFollowingAmong", in.ctx, in.followerID, unique).Return([]string{"f3"}, nil)
+				d.finder.On("GetBlockedAmong", in.ctx, in.followerID, unique).Return([]string{"f6"}, nil)
+				d.finder.On("GetFollowRequestsAmong", in.ctx, in.followerID, unique).Return([]string{"f7"}, nil)
+				d.creator.On("CreateFollowRequests", in.ctx, in.followerID, []string{"f5"}).Return(nil)
+				d.creator.On("FollowUsers", in.ctx, in.followerID, []string{"f2"}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+
+			var wg sync.WaitGroup
+			var done chan struct{}
+			// Synchronize with the goroutines
+			if tt.invalidates {
+				detachedCtx := twcontext.NewDetachedWithRequestID(tt.input.ctx)
+				wg.Add(4)
+				d.cache.On("InvalidateTimeline", detachedCtx, tt.input.followerID).Return(nil).Once().Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.notifier.On("NotifyFollow", detachedCtx, tt.input.followerID, "f2").Return(nil).Once().Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.webhooks.On("DispatchUserFollowed", detachedCtx, tt.input.followerID, "f2").Return(nil).Once().Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.notifier.On("NotifyFollowRequest", detachedCtx, tt.input.followerID, "f5").Return(nil).Once().Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				done = make(chan struct{})
+				go func() {
+					wg.Wait()
+					close(done)
+				}()
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.results, actual.err = uc.FollowUsers(tt.input.ctx, tt.input.followerID, tt.input.followeeIDs)
+
+			// Wait for the goroutines to finish
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(2 * time.Second):
+					t.Error("goroutine did not finish in time")
+				}
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_UnfollowUsers(t *testing.T) {
+	type input struct {
+		ctx         context.Context
+		followerID  string
+		followeeIDs []string
+	}
+
+	type output struct {
+		results []user.FollowResult
+		err     error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		invalidates  bool
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if followerID is empty",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "",
+				followeeIDs: []string{"f2"},
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if batch exceeds the maximum size",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeIDs: func() []string {
+					ids := make([]string, user.MaxFollowBatchSize+1)
+					for i := range ids {
+						ids[i] = fmt.Sprintf("u%d", i)
+					}
+					return ids
+				}(),
+			},
+			output:       output{err: user.ErrFollowBatchTooLarge},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if creator.UnfollowUsers returns error",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2"},
+			},
+			output: output{err: fmt.Errorf("error unfollowing users: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("FindByIDs", in.ctx, in.followeeIDs).Return([]user.User{{ID: "f2"}}, nil)
+				d.finder.On("GetFollowingAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{"f2"}, nil)
+				d.finder.On("GetFollowRequestsAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{}, nil)
+				d.creator.On("UnfollowUsers", in.ctx, in.followerID, []string{"f2"}).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should withdraw pending requests without invalidating the timeline",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2"},
+			},
+			output: output{results: []user.FollowResult{
+				{FolloweeID: "f2", Status: user.FollowStatusWithdrawn},
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("FindByIDs", in.ctx, in.followeeIDs).Return([]user.User{{ID: "f2", Protected: true}}, nil)
+				d.finder.On("GetFollowingAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{}, nil)
+				d.finder.On("GetFollowRequestsAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{"f2"}, nil)
+				d.creator.On("DeleteFollowRequests", in.ctx, in.followerID, []string{"f2"}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should unfollow users and report per-item results",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				followerID:  "f1",
+				followeeIDs: []string{"f2", "f3", "f4", "f5"},
+			},
+			output: output{results: []user.FollowResult{
+				{FolloweeID: "f2", Status: user.FollowStatusUnfollowed},
+				{FolloweeID: "f3", Status: user.FollowStatusNotFollowing},
+				{FolloweeID: "f4", Status: user.FollowStatusNotFound},
+				{FolloweeID: "f5", Status: user.FollowStatusWithdrawn},
+			}},
+			invalidates: true,
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("FindByIDs", in.ctx, in.followeeIDs).Return([]user.User{{ID: "f2"}, {ID: "f3"}, {ID: "f5", Protected: true}}, nil)
+				d.finder.On("GetFollowingAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{"f2"}, nil)
+				d.finder.On("GetFollowRequestsAmong", in.ctx, in.followerID, in.followeeIDs).Return([]string{"f5"}, nil)
+				d.creator.On("DeleteFollowRequests", in.ctx, in.followerID, []string{"f5"}).Return(nil)
+				d.creator.On("UnfollowUsers", in.ctx, in.followerID, []string{"f2"}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+
+			var done chan struct{}
+			// Synchronize with the goroutine
+			if tt.invalidates {
+				done = make(chan struct{})
+				d.cache.On("InvalidateTimeline", twcontext.NewDetachedWithRequestID(tt.input.ctx), tt.input.followerID).Return(nil).Once().Run(func(args mock.Arguments) {
+					close(done)
+				})
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.results, actual.err = uc.UnfollowUsers(tt.input.ctx, tt.input.followerID, tt.input.followeeIDs)
+
+			// Wait for the goroutine to finish
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(2 * time.Second):
+					t.Error("goroutine did not finish in time")
+				}
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
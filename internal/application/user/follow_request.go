@@ -0,0 +1,110 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+func (uc *userUseCase) requestFollow(ctx context.Context, requesterID, targetID string) (FollowStatus, error) {
+	pending, err := uc.finder.HasFollowRequest(ctx, requesterID, targetID)
+	if err != nil {
+		return "", fmt.Errorf("error checking follow request: %w", err)
+	}
+	if pending {
+		return "", ErrFollowRequestPending
+	}
+
+	if err := uc.creator.CreateFollowRequest(ctx, requesterID, targetID); err != nil {
+		return "", fmt.Errorf("error creating follow request: %w", err)
+	}
+
+	go uc.notifyFollowsAsync(twcontext.NewDetachedWithRequestID(ctx), requesterID, nil, []string{targetID})
+
+	return FollowStatusPending, nil
+}
+
+func (uc *userUseCase) GetFollowRequests(ctx context.Context, targetID string) ([]FollowRequest, error) {
+	if targetID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if exists, err := uc.finder.ExistsByID(ctx, targetID); err != nil {
+		return nil, fmt.Errorf("failed to check user with ID %s: %w", targetID, err)
+	} else if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	requests, err := uc.finder.GetFollowRequests(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// ApproveFollowRequest turns a pending request into a follow and invalidates
+// the requester's timeline so the target's tweets show up on the next read.
+// The target's user.followed webhooks fire on approval.
+func (uc *userUseCase) ApproveFollowRequest(ctx context.Context, targetID, requesterID string) error {
+	if err := uc.checkFollowRequest(ctx, targetID, requesterID); err != nil {
+		return err
+	}
+
+	if err := uc.creator.ApproveFollowRequest(ctx, requesterID, targetID); err != nil {
+		return fmt.Errorf("error approving follow request: %w", err)
+	}
+
+	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+	go uc.invalidateTimelineAsync(detachedCtx, requesterID)
+	go uc.dispatchFollow(detachedCtx, requesterID, targetID)
+
+	return nil
+}
+
+func (uc *userUseCase) RejectFollowRequest(ctx context.Context, targetID, requesterID string) error {
+	if err := uc.checkFollowRequest(ctx, targetID, requesterID); err != nil {
+		return err
+	}
+
+	if err := uc.creator.DeleteFollowRequest(ctx, requesterID, targetID); err != nil {
+		return fmt.Errorf("error rejecting follow request: %w", err)
+	}
+
+	return nil
+}
+
+// withdrawFollowRequest deletes the pending follow request of requesterID,
+// which has nothing to invalidate since no follow was created.
+func (uc *userUseCase) withdrawFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	pending, err := uc.finder.HasFollowRequest(ctx, requesterID, targetID)
+	if err != nil {
+		return fmt.Errorf("error checking follow request: %w", err)
+	}
+	if !pending {
+		return ErrNotFollowing
+	}
+
+	if err := uc.creator.DeleteFollowRequest(ctx, requesterID, targetID); err != nil {
+		return fmt.Errorf("error withdrawing follow request: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *userUseCase) checkFollowRequest(ctx context.Context, targetID, requesterID string) error {
+	if targetID == "" || requesterID == "" {
+		return ErrInvalidInput
+	}
+
+	pending, err := uc.finder.HasFollowRequest(ctx, requesterID, targetID)
+	if err != nil {
+		return fmt.Errorf("error checking follow request: %w", err)
+	}
+	if !pending {
+		return ErrFollowRequestNotFound
+	}
+
+	return nil
+}
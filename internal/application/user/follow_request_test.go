@@ -0,0 +1,346 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_userUseCase_GetFollowRequests(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		targetID string
+	}
+
+	type output struct {
+		requests []user.FollowRequest
+		err      error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if targetID is empty",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				targetID: "",
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				targetID: "u1",
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.targetID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if finder.GetFollowRequests returns error",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				targetID: "u1",
+			},
+			output: output{err: fmt.Errorf("failed to get follow requests: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.targetID).Return(true, nil)
+				d.finder.On("GetFollowRequests", in.ctx, in.targetID).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return pending follow requests",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				targetID: "u1",
+			},
+			output: output{requests: []user.FollowRequest{{RequesterID: "u2", TargetID: "u1"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.targetID).Return(true, nil)
+				d.finder.On("GetFollowRequests", in.ctx, in.targetID).Return([]user.FollowRequest{{RequesterID: "u2", TargetID: "u1"}}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.requests, actual.err = uc.GetFollowRequests(tt.input.ctx, tt.input.targetID)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_ApproveFollowRequest(t *testing.T) {
+	type input struct {
+		ctx         context.Context
+		targetID    string
+		requesterID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if requesterID is empty",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				targetID:    "u1",
+				requesterID: "",
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if follow request does not exist",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				targetID:    "u1",
+				requesterID: "u2",
+			},
+			output: output{err: user.ErrFollowRequestNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("HasFollowRequest", in.ctx, in.requesterID, in.targetID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if creator.ApproveFollowRequest returns error",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				targetID:    "u1",
+				requesterID: "u2",
+			},
+			output: output{err: fmt.Errorf("error approving follow request: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("HasFollowRequest", in.ctx, in.requesterID, in.targetID).Return(true, nil)
+				d.creator.On("ApproveFollowRequest", in.ctx, in.requesterID, in.targetID).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should approve follow request successfully",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				targetID:    "u1",
+				requesterID: "u2",
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("HasFollowRequest", in.ctx, in.requesterID, in.targetID).Return(true, nil)
+				d.creator.On("ApproveFollowRequest", in.ctx, in.requesterID, in.targetID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+
+			var wg sync.WaitGroup
+			var done chan struct{}
+			// Synchronize with the goroutines
+			if tt.name == "should approve follow request successfully" {
+				detachedCtx := twcontext.NewDetachedWithRequestID(tt.input.ctx)
+				wg.Add(2)
+				d.cache.On("InvalidateTimeline", detachedCtx, tt.input.requesterID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.webhooks.On("DispatchUserFollowed", detachedCtx, tt.input.requesterID, tt.input.targetID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				done = make(chan struct{})
+				go func() {
+					wg.Wait()
+					close(done)
+				}()
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.err = uc.ApproveFollowRequest(tt.input.ctx, tt.input.targetID, tt.input.requesterID)
+
+			// Wait for the goroutines to finish
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(2 * time.Second):
+					t.Error("goroutine did not finish in time")
+				}
+			}
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_RejectFollowRequest(t *testing.T) {
+	type input struct {
+		ctx         context.Context
+		targetID    string
+		requesterID string
+	}
+
+	type output struct {
+		err error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if finder.HasFollowRequest returns error",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				targetID:    "u1",
+				requesterID: "u2",
+			},
+			output: output{err: fmt.Errorf("error checking follow request: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("HasFollowRequest", in.ctx, in.requesterID, in.targetID).Return(false, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if follow request does not exist",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				targetID:    "u1",
+				requesterID: "u2",
+			},
+			output: output{err: user.ErrFollowRequestNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("HasFollowRequest", in.ctx, in.requesterID, in.targetID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should reject follow request successfully",
+			input: input{
+				ctx:         twcontext.NewTestContext(),
+				targetID:    "u1",
+				requesterID: "u2",
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("HasFollowRequest", in.ctx, in.requesterID, in.targetID).Return(true, nil)
+				d.creator.On("DeleteFollowRequest", in.ctx, in.requesterID, in.targetID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.err = uc.RejectFollowRequest(tt.input.ctx, tt.input.targetID, tt.input.requesterID)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
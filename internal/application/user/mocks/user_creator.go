@@ -14,6 +14,96 @@ type UserCreator struct {
 	mock.Mock
 }
 
+// ApproveFollowRequest provides a mock function with given fields: ctx, requesterID, targetID
+func (_m *UserCreator) ApproveFollowRequest(ctx context.Context, requesterID string, targetID string) error {
+	ret := _m.Called(ctx, requesterID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveFollowRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, requesterID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlockUser provides a mock function with given fields: ctx, blockerID, blockedID
+func (_m *UserCreator) BlockUser(ctx context.Context, blockerID string, blockedID string) error {
+	ret := _m.Called(ctx, blockerID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, blockerID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangeUsername provides a mock function with given fields: ctx, id, oldUsername, newUsername
+func (_m *UserCreator) ChangeUsername(ctx context.Context, id string, oldUsername string, newUsername string) error {
+	ret := _m.Called(ctx, id, oldUsername, newUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUsername")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, id, oldUsername, newUsername)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateFollowRequest provides a mock function with given fields: ctx, requesterID, targetID
+func (_m *UserCreator) CreateFollowRequest(ctx context.Context, requesterID string, targetID string) error {
+	ret := _m.Called(ctx, requesterID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFollowRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, requesterID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateFollowRequests provides a mock function with given fields: ctx, requesterID, targetIDs
+func (_m *UserCreator) CreateFollowRequests(ctx context.Context, requesterID string, targetIDs []string) error {
+	ret := _m.Called(ctx, requesterID, targetIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFollowRequests")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, requesterID, targetIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateUser provides a mock function with given fields: ctx, _a1
 func (_m *UserCreator) CreateUser(ctx context.Context, _a1 *user.User) error {
 	ret := _m.Called(ctx, _a1)
@@ -32,6 +122,78 @@ func (_m *UserCreator) CreateUser(ctx context.Context, _a1 *user.User) error {
 	return r0
 }
 
+// DeactivateUser provides a mock function with given fields: ctx, id
+func (_m *UserCreator) DeactivateUser(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFollowRequest provides a mock function with given fields: ctx, requesterID, targetID
+func (_m *UserCreator) DeleteFollowRequest(ctx context.Context, requesterID string, targetID string) error {
+	ret := _m.Called(ctx, requesterID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFollowRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, requesterID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFollowRequests provides a mock function with given fields: ctx, requesterID, targetIDs
+func (_m *UserCreator) DeleteFollowRequests(ctx context.Context, requesterID string, targetIDs []string) error {
+	ret := _m.Called(ctx, requesterID, targetIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFollowRequests")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, requesterID, targetIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUser provides a mock function with given fields: ctx, id
+func (_m *UserCreator) DeleteUser(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FollowUser provides a mock function with given fields: ctx, followerID, followeeID
 func (_m *UserCreator) FollowUser(ctx context.Context, followerID string, followeeID string) error {
 	ret := _m.Called(ctx, followerID, followeeID)
@@ -50,6 +212,60 @@ func (_m *UserCreator) FollowUser(ctx context.Context, followerID string, follow
 	return r0
 }
 
+// FollowUsers provides a mock function with given fields: ctx, followerID, followeeIDs
+func (_m *UserCreator) FollowUsers(ctx context.Context, followerID string, followeeIDs []string) error {
+	ret := _m.Called(ctx, followerID, followeeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, followerID, followeeIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReactivateUser provides a mock function with given fields: ctx, id
+func (_m *UserCreator) ReactivateUser(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactivateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnblockUser provides a mock function with given fields: ctx, blockerID, blockedID
+func (_m *UserCreator) UnblockUser(ctx context.Context, blockerID string, blockedID string) error {
+	ret := _m.Called(ctx, blockerID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, blockerID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnfollowUser provides a mock function with given fields: ctx, followerID, followeeID
 func (_m *UserCreator) UnfollowUser(ctx context.Context, followerID string, followeeID string) error {
 	ret := _m.Called(ctx, followerID, followeeID)
@@ -68,6 +284,42 @@ func (_m *UserCreator) UnfollowUser(ctx context.Context, followerID string, foll
 	return r0
 }
 
+// UnfollowUsers provides a mock function with given fields: ctx, followerID, followeeIDs
+func (_m *UserCreator) UnfollowUsers(ctx context.Context, followerID string, followeeIDs []string) error {
+	ret := _m.Called(ctx, followerID, followeeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnfollowUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, followerID, followeeIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, id, update
+func (_m *UserCreator) UpdateUser(ctx context.Context, id string, update user.UserUpdate) error {
+	ret := _m.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.UserUpdate) error); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserCreator creates a new instance of UserCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserCreator(t interface {
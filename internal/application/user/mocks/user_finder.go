@@ -4,7 +4,9 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
+	user "github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -69,6 +71,504 @@ func (_m *UserFinder) ExistsByUsername(ctx context.Context, username string) (bo
 	return r0, r1
 }
 
+// ExistsByUsernameSkeleton provides a mock function with given fields: ctx, skeleton
+func (_m *UserFinder) ExistsByUsernameSkeleton(ctx context.Context, skeleton string) (bool, error) {
+	ret := _m.Called(ctx, skeleton)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByUsernameSkeleton")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, skeleton)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, skeleton)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, skeleton)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *UserFinder) FindByID(ctx context.Context, id string) (*user.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*user.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *user.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByIDs provides a mock function with given fields: ctx, ids
+func (_m *UserFinder) FindByIDs(ctx context.Context, ids []string) ([]user.User, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDs")
+	}
+
+	var r0 []user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]user.User, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []user.User); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByPreviousUsername provides a mock function with given fields: ctx, username, since
+func (_m *UserFinder) FindByPreviousUsername(ctx context.Context, username string, since time.Time) (*user.User, error) {
+	ret := _m.Called(ctx, username, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPreviousUsername")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*user.User, error)); ok {
+		return rf(ctx, username, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *user.User); ok {
+		r0 = rf(ctx, username, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, username, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByUsername provides a mock function with given fields: ctx, username
+func (_m *UserFinder) FindByUsername(ctx context.Context, username string) (*user.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUsername")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*user.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *user.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockedAmong provides a mock function with given fields: ctx, userID, otherIDs
+func (_m *UserFinder) GetBlockedAmong(ctx context.Context, userID string, otherIDs []string) ([]string, error) {
+	ret := _m.Called(ctx, userID, otherIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockedAmong")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return rf(ctx, userID, otherIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, userID, otherIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, otherIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeactivatedAt provides a mock function with given fields: ctx, id
+func (_m *UserFinder) GetDeactivatedAt(ctx context.Context, id string) (time.Time, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeactivatedAt")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeactivatedBefore provides a mock function with given fields: ctx, before, limit
+func (_m *UserFinder) GetDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeactivatedBefore")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]string, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []string); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowRequests provides a mock function with given fields: ctx, targetID
+func (_m *UserFinder) GetFollowRequests(ctx context.Context, targetID string) ([]user.FollowRequest, error) {
+	ret := _m.Called(ctx, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFollowRequests")
+	}
+
+	var r0 []user.FollowRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]user.FollowRequest, error)); ok {
+		return rf(ctx, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []user.FollowRequest); ok {
+		r0 = rf(ctx, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.FollowRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowRequestsAmong provides a mock function with given fields: ctx, requesterID, targetIDs
+func (_m *UserFinder) GetFollowRequestsAmong(ctx context.Context, requesterID string, targetIDs []string) ([]string, error) {
+	ret := _m.Called(ctx, requesterID, targetIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFollowRequestsAmong")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return rf(ctx, requesterID, targetIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, requesterID, targetIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, requesterID, targetIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowers provides a mock function with given fields: ctx, id
+func (_m *UserFinder) GetFollowers(ctx context.Context, id string) ([]string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFollowers")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowingAmong provides a mock function with given fields: ctx, followerID, followeeIDs
+func (_m *UserFinder) GetFollowingAmong(ctx context.Context, followerID string, followeeIDs []string) ([]string, error) {
+	ret := _m.Called(ctx, followerID, followeeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFollowingAmong")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return rf(ctx, followerID, followeeIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, followerID, followeeIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, followerID, followeeIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPinnedTweetID provides a mock function with given fields: ctx, id
+func (_m *UserFinder) GetPinnedTweetID(ctx context.Context, id string) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPinnedTweetID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsersUpdatedBetween provides a mock function with given fields: ctx, after, before, limit
+func (_m *UserFinder) GetUsersUpdatedBetween(ctx context.Context, after user.IndexCheckpoint, before time.Time, limit int) ([]user.User, error) {
+	ret := _m.Called(ctx, after, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersUpdatedBetween")
+	}
+
+	var r0 []user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.IndexCheckpoint, time.Time, int) ([]user.User, error)); ok {
+		return rf(ctx, after, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.IndexCheckpoint, time.Time, int) []user.User); ok {
+		r0 = rf(ctx, after, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.IndexCheckpoint, time.Time, int) error); ok {
+		r1 = rf(ctx, after, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasBlockBetween provides a mock function with given fields: ctx, userID, otherID
+func (_m *UserFinder) HasBlockBetween(ctx context.Context, userID string, otherID string) (bool, error) {
+	ret := _m.Called(ctx, userID, otherID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasBlockBetween")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, otherID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, otherID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, otherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasFollowRequest provides a mock function with given fields: ctx, requesterID, targetID
+func (_m *UserFinder) HasFollowRequest(ctx context.Context, requesterID string, targetID string) (bool, error) {
+	ret := _m.Called(ctx, requesterID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasFollowRequest")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, requesterID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, requesterID, targetID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, requesterID, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsBlocking provides a mock function with given fields: ctx, blockerID, blockedID
+func (_m *UserFinder) IsBlocking(ctx context.Context, blockerID string, blockedID string) (bool, error) {
+	ret := _m.Called(ctx, blockerID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlocking")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, blockerID, blockedID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, blockerID, blockedID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, blockerID, blockedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsFollowing provides a mock function with given fields: ctx, followerID, followeeID
 func (_m *UserFinder) IsFollowing(ctx context.Context, followerID string, followeeID string) (bool, error) {
 	ret := _m.Called(ctx, followerID, followeeID)
@@ -97,6 +597,120 @@ func (_m *UserFinder) IsFollowing(ctx context.Context, followerID string, follow
 	return r0, r1
 }
 
+// IsProtected provides a mock function with given fields: ctx, id
+func (_m *UserFinder) IsProtected(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsProtected")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsUsernameHeld provides a mock function with given fields: ctx, skeleton, exceptUserID, since
+func (_m *UserFinder) IsUsernameHeld(ctx context.Context, skeleton string, exceptUserID string, since time.Time) (bool, error) {
+	ret := _m.Called(ctx, skeleton, exceptUserID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsUsernameHeld")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (bool, error)); ok {
+		return rf(ctx, skeleton, exceptUserID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) bool); ok {
+		r0 = rf(ctx, skeleton, exceptUserID, since)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, skeleton, exceptUserID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LastUsernameChange provides a mock function with given fields: ctx, id
+func (_m *UserFinder) LastUsernameChange(ctx context.Context, id string) (time.Time, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastUsernameChange")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUsers provides a mock function with given fields: ctx, viewerID, query, limit
+func (_m *UserFinder) SearchUsers(ctx context.Context, viewerID string, query string, limit int) ([]user.SearchResult, error) {
+	ret := _m.Called(ctx, viewerID, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []user.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]user.SearchResult, error)); ok {
+		return rf(ctx, viewerID, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []user.SearchResult); ok {
+		r0 = rf(ctx, viewerID, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, viewerID, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserFinder creates a new instance of UserFinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserFinder(t interface {
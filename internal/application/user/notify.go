@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+// notifyFollowsAsync notifies the users followerID started following and the
+// protected users it sent a follow request to, and dispatches the
+// user.followed webhooks of the former.
+func (uc *userUseCase) notifyFollowsAsync(ctx context.Context, followerID string, followeeIDs, requestedIDs []string) {
+	logger := twcontext.Logger(ctx)
+
+	for _, followeeID := range followeeIDs {
+		if err := uc.notifier.NotifyFollow(ctx, followerID, followeeID); err != nil {
+			logger.WithError(err).WithField("followee_id", followeeID).Error("failed to notify follow")
+		}
+		uc.dispatchFollow(ctx, followerID, followeeID)
+	}
+
+	for _, targetID := range requestedIDs {
+		if err := uc.notifier.NotifyFollowRequest(ctx, followerID, targetID); err != nil {
+			logger.WithError(err).WithField("target_id", targetID).Error("failed to notify follow request")
+		}
+	}
+}
+
+// dispatchFollow enqueues the user.followed webhooks of followeeID.
+func (uc *userUseCase) dispatchFollow(ctx context.Context, followerID, followeeID string) {
+	if err := uc.webhooks.DispatchUserFollowed(ctx, followerID, followeeID); err != nil {
+		twcontext.Logger(ctx).WithError(err).WithField("followee_id", followeeID).Error("failed to dispatch follow webhooks")
+	}
+}
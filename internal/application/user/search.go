@@ -0,0 +1,192 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+const (
+	DefaultTypeaheadLimit = 8
+	MaxTypeaheadLimit     = 20
+
+	// typeaheadCandidates is how many IDs are read from the index for each
+	// suggestion, since some are dropped once checked against the database.
+	typeaheadCandidates = 3
+
+	// typeaheadBatchSize bounds how many users a single run copies to the
+	// typeahead index.
+	typeaheadBatchSize = 500
+	// typeaheadLag leaves the users updated in the last seconds for the
+	// next run, so that transactions committed late are not skipped.
+	typeaheadLag = 5 * time.Second
+)
+
+// SearchUsers returns the users whose username or display name starts with
+// query, as seen by viewerID. A leading @ is ignored.
+func (uc *userUseCase) SearchUsers(ctx context.Context, viewerID, query string, limit int) ([]SearchResult, error) {
+	if limit <= 0 || limit > MaxSearchLimit {
+		limit = DefaultSearchLimit
+	}
+
+	query, err := normalizeSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := uc.finder.SearchUsers(ctx, viewerID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return results, nil
+}
+
+// Typeahead suggests users as prefix is typed. Candidates come from the
+// typeahead index and are checked against the database, so stale entries
+// and blocked users are dropped. Exact username matches come first, then
+// the users viewerID follows. If the index is unavailable it falls back to
+// SearchUsers.
+func (uc *userUseCase) Typeahead(ctx context.Context, viewerID, prefix string, limit int) ([]SearchResult, error) {
+	if limit <= 0 || limit > MaxTypeaheadLimit {
+		limit = DefaultTypeaheadLimit
+	}
+
+	prefix, err := normalizeSearchQuery(prefix)
+	if err != nil {
+		return nil, err
+	}
+	prefix = strings.ToLower(prefix)
+
+	ids, err := uc.typeahead.FindByPrefix(ctx, prefix, limit*typeaheadCandidates)
+	if err != nil {
+		twcontext.Logger(ctx).WithError(err).Warn("typeahead index unavailable, searching the database")
+		return uc.SearchUsers(ctx, viewerID, prefix, limit)
+	}
+	if len(ids) == 0 {
+		return []SearchResult{}, nil
+	}
+
+	users, err := uc.finder.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	blocked, err := uc.finder.GetBlockedAmong(ctx, viewerID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error checking block relationship: %w", err)
+	}
+
+	following, err := uc.finder.GetFollowingAmong(ctx, viewerID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error checking follow relationship: %w", err)
+	}
+
+	byID := make(map[string]User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+
+	results := []SearchResult{}
+	for _, id := range ids {
+		u, ok := byID[id]
+		if !ok || slices.Contains(blocked, id) || !matchesPrefix(u, prefix) {
+			continue
+		}
+		results = append(results, SearchResult{User: u, Following: slices.Contains(following, id)})
+	}
+
+	// Index order, by term, breaks the ties.
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		if exactA, exactB := strings.ToLower(a.Username) == prefix, strings.ToLower(b.Username) == prefix; exactA != exactB {
+			if exactA {
+				return -1
+			}
+			return 1
+		}
+		if a.Following != b.Following {
+			if a.Following {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// IndexTypeahead copies the users updated since the last run to the
+// typeahead index. When the index is empty, e.g. after Redis lost its data,
+// it starts over from the first user. It returns the number of users
+// indexed.
+func (uc *userUseCase) IndexTypeahead(ctx context.Context) (int, error) {
+	checkpoint, err := uc.typeahead.GetCheckpoint(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get typeahead checkpoint: %w", err)
+	}
+
+	users, err := uc.finder.GetUsersUpdatedBetween(ctx, checkpoint, time.Now().Add(-typeaheadLag), typeaheadBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get updated users: %w", err)
+	}
+	if len(users) == 0 {
+		return 0, nil
+	}
+
+	last := users[len(users)-1]
+	if err := uc.typeahead.IndexUsers(ctx, users, IndexCheckpoint{UpdatedAt: last.UpdatedAt, ID: last.ID}); err != nil {
+		return 0, fmt.Errorf("failed to index users: %w", err)
+	}
+
+	return len(users), nil
+}
+
+// TypeaheadTerms returns the lowercased terms a user is suggested for: the
+// username, the display name and every word of it.
+func TypeaheadTerms(u User) []string {
+	terms := []string{strings.ToLower(u.Username)}
+
+	name := strings.ToLower(u.DisplayName)
+	if name == "" {
+		return terms
+	}
+	terms = append(terms, name)
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+func matchesPrefix(u User, prefix string) bool {
+	for _, term := range TypeaheadTerms(u) {
+		if strings.HasPrefix(term, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeSearchQuery(query string) (string, error) {
+	query = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(query), "@"))
+	if query == "" || utf8.RuneCountInString(query) > MaxSearchQueryLength {
+		return "", ErrInvalidInput
+	}
+
+	return query, nil
+}
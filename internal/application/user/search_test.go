@@ -0,0 +1,311 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type searchDependencies struct {
+	finder    *mocks.UserFinder
+	typeahead *mocks.TypeaheadIndex
+}
+
+func Test_userUseCase_SearchUsers(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		viewerID string
+		query    string
+		limit    int
+	}
+
+	type output struct {
+		results []user.SearchResult
+		err     error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *searchDependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if query is empty",
+			input:        input{ctx: twcontext.NewTestContext(), viewerID: "u1", query: " @ "},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *searchDependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:         "should return error if query is too long",
+			input:        input{ctx: twcontext.NewTestContext(), viewerID: "u1", query: strings.Repeat("a", user.MaxSearchQueryLength+1)},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *searchDependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if finder.SearchUsers returns error",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", query: "ana"},
+			output: output{err: fmt.Errorf("failed to search users: %w", assert.AnError)},
+			dependencies: func(in input, d *searchDependencies) {
+				d.finder.On("SearchUsers", in.ctx, in.viewerID, "ana", user.DefaultSearchLimit).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:  "should search without the leading @",
+			input: input{ctx: twcontext.NewTestContext(), viewerID: "u1", query: " @Ana ", limit: 5},
+			output: output{results: []user.SearchResult{
+				{User: user.User{ID: "u2", Username: "ana"}, Following: true, FollowerCount: 3},
+			}},
+			dependencies: func(in input, d *searchDependencies) {
+				d.finder.On("SearchUsers", in.ctx, in.viewerID, "Ana", 5).Return([]user.SearchResult{
+					{User: user.User{ID: "u2", Username: "ana"}, Following: true, FollowerCount: 3},
+				}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &searchDependencies{
+				finder:    mocks.NewUserFinder(t),
+				typeahead: mocks.NewTypeaheadIndex(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(mocks.NewUserCreator(t), d.finder, mocks.NewTimelineCache(t), user.NewUsernamePolicy(nil), mocks.NewNotifier(t), mocks.NewWebhookDispatcher(t), d.typeahead, mocks.NewSearchIndexer(t))
+			var actual output
+			actual.results, actual.err = uc.SearchUsers(tt.input.ctx, tt.input.viewerID, tt.input.query, tt.input.limit)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_Typeahead(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		viewerID string
+		prefix   string
+		limit    int
+	}
+
+	type output struct {
+		results []user.SearchResult
+		err     error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *searchDependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:         "should return error if prefix is empty",
+			input:        input{ctx: twcontext.NewTestContext(), viewerID: "u1", prefix: ""},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *searchDependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should fall back to the database if the index fails",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", prefix: "An"},
+			output: output{results: []user.SearchResult{{User: user.User{ID: "u2", Username: "ana"}}}},
+			dependencies: func(in input, d *searchDependencies) {
+				d.typeahead.On("FindByPrefix", in.ctx, "an", user.DefaultTypeaheadLimit*3).Return(nil, assert.AnError)
+				d.finder.On("SearchUsers", in.ctx, in.viewerID, "an", user.DefaultTypeaheadLimit).Return([]user.SearchResult{{User: user.User{ID: "u2", Username: "ana"}}}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return no suggestions if the index has none",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", prefix: "zz"},
+			output: output{results: []user.SearchResult{}},
+			dependencies: func(in input, d *searchDependencies) {
+				d.typeahead.On("FindByPrefix", in.ctx, "zz", user.DefaultTypeaheadLimit*3).Return([]string{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if finder.GetBlockedAmong returns error",
+			input:  input{ctx: twcontext.NewTestContext(), viewerID: "u1", prefix: "an"},
+			output: output{err: fmt.Errorf("error checking block relationship: %w", assert.AnError)},
+			dependencies: func(in input, d *searchDependencies) {
+				ids := []string{"u2"}
+				d.typeahead.On("FindByPrefix", in.ctx, "an", user.DefaultTypeaheadLimit*3).Return(ids, nil)
+				d.finder.On("FindByIDs", in.ctx, ids).Return([]user.User{{ID: "u2", Username: "ana"}}, nil)
+				d.finder.On("GetBlockedAmong", in.ctx, in.viewerID, ids).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:  "should drop stale and blocked users and rank exact matches and followees first",
+			input: input{ctx: twcontext.NewTestContext(), viewerID: "u1", prefix: "ana", limit: 3},
+			output: output{results: []user.SearchResult{
+				{User: user.User{ID: "u5", Username: "Ana"}},
+				{User: user.User{ID: "u6", Username: "mario", DisplayName: "Mario Anaya"}, Following: true},
+				{User: user.User{ID: "u2", Username: "anabel"}},
+			}},
+			dependencies: func(in input, d *searchDependencies) {
+				// u3 was renamed since it was indexed, u4 is blocked and u7
+				// no longer exists.
+				ids := []string{"u2", "u3", "u4", "u5", "u6", "u7", "u8"}
+				d.typeahead.On("FindByPrefix", in.ctx, "ana", 9).Return(ids, nil)
+				d.finder.On("FindByIDs", in.ctx, ids).Return([]user.User{
+					{ID: "u2", Username: "anabel"},
+					{ID: "u3", Username: "bruno"},
+					{ID: "u4", Username: "anastasia"},
+					{ID: "u5", Username: "Ana"},
+					{ID: "u6", Username: "mario", DisplayName: "Mario Anaya"},
+					{ID: "u8", Username: "anakin"},
+				}, nil)
+				d.finder.On("GetBlockedAmong", in.ctx, in.viewerID, ids).Return([]string{"u4"}, nil)
+				d.finder.On("GetFollowingAmong", in.ctx, in.viewerID, ids).Return([]string{"u6"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &searchDependencies{
+				finder:    mocks.NewUserFinder(t),
+				typeahead: mocks.NewTypeaheadIndex(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(mocks.NewUserCreator(t), d.finder, mocks.NewTimelineCache(t), user.NewUsernamePolicy(nil), mocks.NewNotifier(t), mocks.NewWebhookDispatcher(t), d.typeahead, mocks.NewSearchIndexer(t))
+			var actual output
+			actual.results, actual.err = uc.Typeahead(tt.input.ctx, tt.input.viewerID, tt.input.prefix, tt.input.limit)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_IndexTypeahead(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type output struct {
+		indexed int
+		err     error
+	}
+
+	tests := []struct {
+		name         string
+		output       output
+		dependencies func(ctx context.Context, d *searchDependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if typeahead.GetCheckpoint returns error",
+			output: output{err: fmt.Errorf("failed to get typeahead checkpoint: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *searchDependencies) {
+				d.typeahead.On("GetCheckpoint", ctx).Return(user.IndexCheckpoint{}, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should do nothing if no user was updated",
+			output: output{indexed: 0},
+			dependencies: func(ctx context.Context, d *searchDependencies) {
+				checkpoint := user.IndexCheckpoint{UpdatedAt: updatedAt, ID: "u1"}
+				d.typeahead.On("GetCheckpoint", ctx).Return(checkpoint, nil)
+				d.finder.On("GetUsersUpdatedBetween", ctx, checkpoint, mock.AnythingOfType("time.Time"), 500).Return([]user.User{}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should index the updated users and move the checkpoint to the last one",
+			output: output{indexed: 2},
+			dependencies: func(ctx context.Context, d *searchDependencies) {
+				users := []user.User{
+					{ID: "u2", Username: "ana", UpdatedAt: updatedAt},
+					{ID: "u1", Username: "bob", UpdatedAt: updatedAt.Add(time.Second)},
+				}
+				d.typeahead.On("GetCheckpoint", ctx).Return(user.IndexCheckpoint{}, nil)
+				d.finder.On("GetUsersUpdatedBetween", ctx, user.IndexCheckpoint{}, mock.AnythingOfType("time.Time"), 500).Return(users, nil)
+				d.typeahead.On("IndexUsers", ctx, users, user.IndexCheckpoint{UpdatedAt: updatedAt.Add(time.Second), ID: "u1"}).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if typeahead.IndexUsers returns error",
+			output: output{err: fmt.Errorf("failed to index users: %w", assert.AnError)},
+			dependencies: func(ctx context.Context, d *searchDependencies) {
+				users := []user.User{{ID: "u2", Username: "ana", UpdatedAt: updatedAt}}
+				d.typeahead.On("GetCheckpoint", ctx).Return(user.IndexCheckpoint{}, nil)
+				d.finder.On("GetUsersUpdatedBetween", ctx, user.IndexCheckpoint{}, mock.AnythingOfType("time.Time"), 500).Return(users, nil)
+				d.typeahead.On("IndexUsers", ctx, users, user.IndexCheckpoint{UpdatedAt: updatedAt, ID: "u2"}).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := twcontext.NewTestContext()
+			d := &searchDependencies{
+				finder:    mocks.NewUserFinder(t),
+				typeahead: mocks.NewTypeaheadIndex(t),
+			}
+			tt.dependencies(ctx, d)
+
+			uc := user.NewUserUseCase(mocks.NewUserCreator(t), d.finder, mocks.NewTimelineCache(t), user.NewUsernamePolicy(nil), mocks.NewNotifier(t), mocks.NewWebhookDispatcher(t), d.typeahead, mocks.NewSearchIndexer(t))
+			var actual output
+			actual.indexed, actual.err = uc.IndexTypeahead(ctx)
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_TypeaheadTerms(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  user.User
+		terms []string
+	}{
+		{name: "should use the username alone", user: user.User{Username: "Ana_B"}, terms: []string{"ana_b"}},
+		{name: "should add the display name and its words", user: user.User{Username: "mario", DisplayName: "Mario Anaya-Ruiz"}, terms: []string{"mario", "mario anaya-ruiz", "anaya", "ruiz"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.terms, user.TypeaheadTerms(tt.user))
+		})
+	}
+}
@@ -3,18 +3,26 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
 )
 
 type userUseCase struct {
-	creator UserCreator
-	finder  UserFinder
-	cache   TimelineCache
+	creator   UserCreator
+	finder    UserFinder
+	cache     TimelineCache
+	username  UsernamePolicy
+	notifier  Notifier
+	webhooks  WebhookDispatcher
+	typeahead TypeaheadIndex
+	search    SearchIndexer
 }
 
-func NewUserUseCase(creator UserCreator, finder UserFinder, cache TimelineCache) *userUseCase {
-	return &userUseCase{creator: creator, finder: finder, cache: cache}
+func NewUserUseCase(creator UserCreator, finder UserFinder, cache TimelineCache, username UsernamePolicy, notifier Notifier, webhooks WebhookDispatcher, typeahead TypeaheadIndex, search SearchIndexer) *userUseCase {
+	return &userUseCase{creator: creator, finder: finder, cache: cache, username: username, notifier: notifier, webhooks: webhooks, typeahead: typeahead, search: search}
 }
 
 func (uc *userUseCase) CreateUser(ctx context.Context, user *User) error {
@@ -22,13 +30,17 @@ func (uc *userUseCase) CreateUser(ctx context.Context, user *User) error {
 		return ErrInvalidInput
 	}
 
-	exist, err := uc.finder.ExistsByUsername(ctx, user.Username)
-	if err != nil {
-		return fmt.Errorf("failed to check username: %w", err)
+	if err := uc.username.Validate(user.Username); err != nil {
+		return err
+	}
+
+	user.DisplayName = strings.TrimSpace(user.DisplayName)
+	if utf8.RuneCountInString(user.DisplayName) > MaxDisplayNameLength {
+		return ErrDisplayNameTooLong
 	}
 
-	if exist {
-		return ErrUsernameExists
+	if err := uc.checkUsernameAvailable(ctx, "", user.Username, UsernameSkeleton(user.Username), time.Now()); err != nil {
+		return err
 	}
 
 	if err := uc.creator.CreateUser(ctx, user); err != nil {
@@ -38,44 +50,91 @@ func (uc *userUseCase) CreateUser(ctx context.Context, user *User) error {
 	return nil
 }
 
-func (uc *userUseCase) FollowUser(ctx context.Context, followerID, followeeID string) error {
-	if followerID == "" || followeeID == "" {
+func (uc *userUseCase) UpdateUser(ctx context.Context, id string, update UserUpdate) error {
+	if id == "" {
 		return ErrInvalidInput
 	}
 
+	if update.DisplayName != nil {
+		displayName := strings.TrimSpace(*update.DisplayName)
+		if utf8.RuneCountInString(displayName) > MaxDisplayNameLength {
+			return ErrDisplayNameTooLong
+		}
+		update.DisplayName = &displayName
+	}
+
+	if exists, err := uc.finder.ExistsByID(ctx, id); err != nil {
+		return fmt.Errorf("failed to check user with ID %s: %w", id, err)
+	} else if !exists {
+		return ErrUserNotFound
+	}
+
+	if err := uc.creator.UpdateUser(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+// FollowUser makes followerID follow followeeID. When the followee is a
+// protected account no follow is created; a pending follow request is
+// stored instead and FollowStatusPending is returned.
+func (uc *userUseCase) FollowUser(ctx context.Context, followerID, followeeID string) (FollowStatus, error) {
+	if followerID == "" || followeeID == "" {
+		return "", ErrInvalidInput
+	}
+
 	if followerID == followeeID {
-		return ErrCannotFollowSelf
+		return "", ErrCannotFollowSelf
 	}
 
 	if followerExists, err := uc.finder.ExistsByID(ctx, followerID); err != nil {
-		return fmt.Errorf("failed to check follower with ID %s: %w", followerID, err)
+		return "", fmt.Errorf("failed to check follower with ID %s: %w", followerID, err)
 	} else if !followerExists {
-		return ErrUserNotFound
+		return "", ErrUserNotFound
 	}
 
 	if followeeExists, err := uc.finder.ExistsByID(ctx, followeeID); err != nil {
-		return fmt.Errorf("failed to check followee with ID %s: %w", followeeID, err)
+		return "", fmt.Errorf("failed to check followee with ID %s: %w", followeeID, err)
 	} else if !followeeExists {
-		return ErrFolloweeNotFound
+		return "", ErrFolloweeNotFound
 	}
 
 	exists, err := uc.finder.IsFollowing(ctx, followerID, followeeID)
 	if err != nil {
-		return fmt.Errorf("error checking follow relationship: %w", err)
+		return "", fmt.Errorf("error checking follow relationship: %w", err)
 	}
 	if exists {
-		return ErrAlreadyFollowing
+		return "", ErrAlreadyFollowing
+	}
+
+	if blocked, err := uc.finder.HasBlockBetween(ctx, followerID, followeeID); err != nil {
+		return "", fmt.Errorf("error checking block relationship: %w", err)
+	} else if blocked {
+		return "", ErrUserBlocked
+	}
+
+	protected, err := uc.finder.IsProtected(ctx, followeeID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check followee with ID %s: %w", followeeID, err)
+	}
+	if protected {
+		return uc.requestFollow(ctx, followerID, followeeID)
 	}
 
 	if err := uc.creator.FollowUser(ctx, followerID, followeeID); err != nil {
-		return fmt.Errorf("error following user: %w", err)
+		return "", fmt.Errorf("error following user: %w", err)
 	}
 
-	go uc.invalidateTimelineAsync(twcontext.NewDetachedWithRequestID(ctx), followerID)
+	detachedCtx := twcontext.NewDetachedWithRequestID(ctx)
+	go uc.invalidateTimelineAsync(detachedCtx, followerID)
+	go uc.notifyFollowsAsync(detachedCtx, followerID, []string{followeeID}, nil)
 
-	return nil
+	return FollowStatusFollowing, nil
 }
 
+// UnfollowUser removes the follow, or withdraws the pending follow request
+// when followerID is still waiting for a protected account to approve it.
 func (uc *userUseCase) UnfollowUser(ctx context.Context, followerID, followeeID string) error {
 	if followerID == "" || followeeID == "" {
 		return ErrInvalidInput
@@ -102,7 +161,7 @@ func (uc *userUseCase) UnfollowUser(ctx context.Context, followerID, followeeID
 		return fmt.Errorf("error checking follow relationship: %w", err)
 	}
 	if !exists {
-		return ErrNotFollowing
+		return uc.withdrawFollowRequest(ctx, followerID, followeeID)
 	}
 
 	if err := uc.creator.UnfollowUser(ctx, followerID, followeeID); err != nil {
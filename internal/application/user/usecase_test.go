@@ -3,6 +3,8 @@ package user_test
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -28,9 +30,11 @@ func Test_userUseCase_CreateUser(t *testing.T) {
 	}
 
 	type dependencies struct {
-		creator *mocks.UserCreator
-		finder  *mocks.UserFinder
-		cache   *mocks.TimelineCache
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
 	}
 
 	tests := []struct {
@@ -52,6 +56,97 @@ func Test_userUseCase_CreateUser(t *testing.T) {
 				assert.Equal(t, expected, actual)
 			},
 		},
+		{
+			name: "should return error if username is too short",
+			input: input{
+				ctx:  twcontext.NewTestContext(),
+				user: &user.User{Username: "al"},
+			},
+			output:       output{err: user.ErrUsernameTooShort},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username is too long",
+			input: input{
+				ctx:  twcontext.NewTestContext(),
+				user: &user.User{Username: "alice_in_wonderland"},
+			},
+			output:       output{err: user.ErrUsernameTooLong},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username has characters outside the grammar",
+			input: input{
+				ctx:  twcontext.NewTestContext(),
+				user: &user.User{Username: "аlice"},
+			},
+			output:       output{err: user.ErrUsernameInvalidCharacters},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username is a look-alike of a reserved name",
+			input: input{
+				ctx:  twcontext.NewTestContext(),
+				user: &user.User{Username: "ADM1N"},
+			},
+			output:       output{err: user.ErrUsernameReserved},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if display name is too long",
+			input: input{
+				ctx:  twcontext.NewTestContext(),
+				user: &user.User{Username: "dave", DisplayName: strings.Repeat("é", user.MaxDisplayNameLength+1)},
+			},
+			output:       output{err: user.ErrDisplayNameTooLong},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username is confusable with an existing one",
+			input: input{
+				ctx:  twcontext.NewTestContext(),
+				user: &user.User{Username: "paypa1"},
+			},
+			output: output{err: user.ErrUsernameConfusable},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByUsername", in.ctx, in.user.Username).Return(false, nil)
+				d.finder.On("ExistsByUsernameSkeleton", in.ctx, "paypal").Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username was recently released by another user",
+			input: input{
+				ctx:  twcontext.NewTestContext(),
+				user: &user.User{Username: "erin"},
+			},
+			output: output{err: user.ErrUsernameUnavailable},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByUsername", in.ctx, in.user.Username).Return(false, nil)
+				d.finder.On("ExistsByUsernameSkeleton", in.ctx, user.UsernameSkeleton(in.user.Username)).Return(false, nil)
+				d.finder.On("IsUsernameHeld", in.ctx, user.UsernameSkeleton(in.user.Username), "", mock.AnythingOfType("time.Time")).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
 		{
 			name: "should return error if finder.ExistsByUsername returns error",
 			input: input{
@@ -89,6 +184,8 @@ func Test_userUseCase_CreateUser(t *testing.T) {
 			output: output{err: fmt.Errorf("failed to create user: %w", assert.AnError)},
 			dependencies: func(in input, d *dependencies) {
 				d.finder.On("ExistsByUsername", in.ctx, in.user.Username).Return(false, nil)
+				d.finder.On("ExistsByUsernameSkeleton", in.ctx, user.UsernameSkeleton(in.user.Username)).Return(false, nil)
+				d.finder.On("IsUsernameHeld", in.ctx, user.UsernameSkeleton(in.user.Username), "", mock.AnythingOfType("time.Time")).Return(false, nil)
 				d.creator.On("CreateUser", in.ctx, in.user).Return(assert.AnError)
 			},
 			assert: func(t *testing.T, expected, actual output) {
@@ -104,6 +201,8 @@ func Test_userUseCase_CreateUser(t *testing.T) {
 			output: output{err: nil},
 			dependencies: func(in input, d *dependencies) {
 				d.finder.On("ExistsByUsername", in.ctx, in.user.Username).Return(false, nil)
+				d.finder.On("ExistsByUsernameSkeleton", in.ctx, user.UsernameSkeleton(in.user.Username)).Return(false, nil)
+				d.finder.On("IsUsernameHeld", in.ctx, user.UsernameSkeleton(in.user.Username), "", mock.AnythingOfType("time.Time")).Return(false, nil)
 				d.creator.On("CreateUser", in.ctx, in.user).Return(nil)
 			},
 			assert: func(t *testing.T, expected, actual output) {
@@ -114,13 +213,15 @@ func Test_userUseCase_CreateUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &dependencies{
-				creator: mocks.NewUserCreator(t),
-				finder:  mocks.NewUserFinder(t),
-				cache:   mocks.NewTimelineCache(t),
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
 			}
 			tt.dependencies(tt.input, d)
 
-			uc := user.NewUserUseCase(d.creator, d.finder, d.cache)
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy([]string{"admin"}), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
 			var actual output
 			actual.err = uc.CreateUser(tt.input.ctx, tt.input.user)
 
@@ -137,13 +238,16 @@ func Test_userUseCase_FollowUser(t *testing.T) {
 	}
 
 	type output struct {
-		err error
+		status user.FollowStatus
+		err    error
 	}
 
 	type dependencies struct {
-		creator *mocks.UserCreator
-		finder  *mocks.UserFinder
-		cache   *mocks.TimelineCache
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
 	}
 
 	tests := []struct {
@@ -287,12 +391,113 @@ func Test_userUseCase_FollowUser(t *testing.T) {
 				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
 				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
 				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasBlockBetween", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("IsProtected", in.ctx, in.followeeID).Return(false, nil)
 				d.creator.On("FollowUser", in.ctx, in.followerID, in.followeeID).Return(assert.AnError)
 			},
 			assert: func(t *testing.T, expected, actual output) {
 				assert.Equal(t, expected.err.Error(), actual.err.Error())
 			},
 		},
+		{
+			name: "should return error if there is a block between users",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeID: "f2",
+			},
+			output: output{err: user.ErrUserBlocked},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasBlockBetween", in.ctx, in.followerID, in.followeeID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if finder.IsProtected returns error",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeID: "f2",
+			},
+			output: output{err: fmt.Errorf("failed to check followee with ID %s: %w", "f2", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasBlockBetween", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("IsProtected", in.ctx, in.followeeID).Return(false, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should return error if follow request is already pending for protected followee",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeID: "f2",
+			},
+			output: output{err: user.ErrFollowRequestPending},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasBlockBetween", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("IsProtected", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("HasFollowRequest", in.ctx, in.followerID, in.followeeID).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if creator.CreateFollowRequest returns error",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeID: "f2",
+			},
+			output: output{err: fmt.Errorf("error creating follow request: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasBlockBetween", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("IsProtected", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("HasFollowRequest", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.creator.On("CreateFollowRequest", in.ctx, in.followerID, in.followeeID).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name: "should create a pending follow request if followee is protected",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeID: "f2",
+			},
+			output: output{status: user.FollowStatusPending, err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasBlockBetween", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("IsProtected", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("HasFollowRequest", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.creator.On("CreateFollowRequest", in.ctx, in.followerID, in.followeeID).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
 		{
 			name: "should follow user successfully",
 			input: input{
@@ -300,11 +505,13 @@ func Test_userUseCase_FollowUser(t *testing.T) {
 				followerID: "f1",
 				followeeID: "f2",
 			},
-			output: output{err: nil},
+			output: output{status: user.FollowStatusFollowing, err: nil},
 			dependencies: func(in input, d *dependencies) {
 				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
 				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
 				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasBlockBetween", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("IsProtected", in.ctx, in.followeeID).Return(false, nil)
 				d.creator.On("FollowUser", in.ctx, in.followerID, in.followeeID).Return(nil)
 			},
 			assert: func(t *testing.T, expected, actual output) {
@@ -315,26 +522,49 @@ func Test_userUseCase_FollowUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &dependencies{
-				creator: mocks.NewUserCreator(t),
-				finder:  mocks.NewUserFinder(t),
-				cache:   mocks.NewTimelineCache(t),
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
 			}
 
+			var wg sync.WaitGroup
 			var done chan struct{}
-			// Synchronize with the goroutine
-			if tt.name == "should follow user successfully" {
+			// Synchronize with the goroutines
+			detachedCtx := twcontext.NewDetachedWithRequestID(tt.input.ctx)
+			switch tt.name {
+			case "should follow user successfully":
+				wg.Add(3)
+				d.cache.On("InvalidateTimeline", detachedCtx, tt.input.followerID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.notifier.On("NotifyFollow", detachedCtx, tt.input.followerID, tt.input.followeeID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+				d.webhooks.On("DispatchUserFollowed", detachedCtx, tt.input.followerID, tt.input.followeeID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			case "should create a pending follow request if followee is protected":
+				wg.Add(1)
+				d.notifier.On("NotifyFollowRequest", detachedCtx, tt.input.followerID, tt.input.followeeID).Return(nil).Run(func(args mock.Arguments) {
+					wg.Done()
+				})
+			}
+			if tt.name == "should follow user successfully" || tt.name == "should create a pending follow request if followee is protected" {
 				done = make(chan struct{})
-				d.cache.On("InvalidateTimeline", twcontext.NewDetachedWithRequestID(tt.input.ctx), tt.input.followerID).Return(nil).Run(func(args mock.Arguments) {
+				go func() {
+					wg.Wait()
 					close(done)
-				})
+				}()
 			}
 			tt.dependencies(tt.input, d)
 
-			uc := user.NewUserUseCase(d.creator, d.finder, d.cache)
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
 			var actual output
-			actual.err = uc.FollowUser(tt.input.ctx, tt.input.followerID, tt.input.followeeID)
+			actual.status, actual.err = uc.FollowUser(tt.input.ctx, tt.input.followerID, tt.input.followeeID)
 
-			// Wait for the goroutine to finish
+			// Wait for the goroutines to finish
 			if done != nil {
 				select {
 				case <-done:
@@ -360,9 +590,11 @@ func Test_userUseCase_UnfollowUser(t *testing.T) {
 	}
 
 	type dependencies struct {
-		creator *mocks.UserCreator
-		finder  *mocks.UserFinder
-		cache   *mocks.TimelineCache
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
 	}
 
 	tests := []struct {
@@ -489,11 +721,50 @@ func Test_userUseCase_UnfollowUser(t *testing.T) {
 				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
 				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
 				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasFollowRequest", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should withdraw the pending follow request if not following yet",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeID: "f2",
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasFollowRequest", in.ctx, in.followerID, in.followeeID).Return(true, nil)
+				d.creator.On("DeleteFollowRequest", in.ctx, in.followerID, in.followeeID).Return(nil)
 			},
 			assert: func(t *testing.T, expected, actual output) {
 				assert.Equal(t, expected, actual)
 			},
 		},
+		{
+			name: "should return error if creator.DeleteFollowRequest returns error",
+			input: input{
+				ctx:        twcontext.NewTestContext(),
+				followerID: "f1",
+				followeeID: "f2",
+			},
+			output: output{err: fmt.Errorf("error withdrawing follow request: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("ExistsByID", in.ctx, in.followerID).Return(true, nil)
+				d.finder.On("ExistsByID", in.ctx, in.followeeID).Return(true, nil)
+				d.finder.On("IsFollowing", in.ctx, in.followerID, in.followeeID).Return(false, nil)
+				d.finder.On("HasFollowRequest", in.ctx, in.followerID, in.followeeID).Return(true, nil)
+				d.creator.On("DeleteFollowRequest", in.ctx, in.followerID, in.followeeID).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
 		{
 			name: "should return error if creator.UnfollowUser returns error",
 			input: input{
@@ -534,9 +805,11 @@ func Test_userUseCase_UnfollowUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := &dependencies{
-				creator: mocks.NewUserCreator(t),
-				finder:  mocks.NewUserFinder(t),
-				cache:   mocks.NewTimelineCache(t),
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
 			}
 
 			var done chan struct{}
@@ -550,7 +823,7 @@ func Test_userUseCase_UnfollowUser(t *testing.T) {
 
 			tt.dependencies(tt.input, d)
 
-			uc := user.NewUserUseCase(d.creator, d.finder, d.cache)
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
 			var actual output
 			actual.err = uc.UnfollowUser(tt.input.ctx, tt.input.followerID, tt.input.followeeID)
 
@@ -7,40 +7,254 @@ import (
 )
 
 var (
-	ErrInvalidInput       = errors.New("invalid input")
-	ErrUsernameExists     = errors.New("username already exists")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrFolloweeNotFound   = errors.New("followee not found")
-	ErrAlreadyFollowing   = errors.New("already following")
-	ErrCannotFollowSelf   = errors.New("cannot follow self")
-	ErrCannotUnfollowSelf = errors.New("cannot unfollow self")
-	ErrNotFollowing       = errors.New("not following")
+	ErrInvalidInput          = errors.New("invalid input")
+	ErrUsernameExists        = errors.New("username already exists")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrFolloweeNotFound      = errors.New("followee not found")
+	ErrAlreadyFollowing      = errors.New("already following")
+	ErrCannotFollowSelf      = errors.New("cannot follow self")
+	ErrCannotUnfollowSelf    = errors.New("cannot unfollow self")
+	ErrNotFollowing          = errors.New("not following")
+	ErrFollowRequestPending  = errors.New("follow request already pending")
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+	ErrProtectedAccount      = errors.New("account is protected")
+	ErrUserBlocked           = errors.New("user is blocked")
+	ErrCannotBlockSelf       = errors.New("cannot block self")
+	ErrAlreadyBlocked        = errors.New("already blocked")
+	ErrNotBlocked            = errors.New("not blocked")
+	ErrUserNotDeactivated    = errors.New("user is not deactivated")
+	ErrReactivationExpired   = errors.New("reactivation period has expired")
+	ErrDisplayNameTooLong    = errors.New("display name is too long")
+	ErrFollowBatchTooLarge   = errors.New("follow batch is too large")
+
+	ErrUsernameTooShort          = errors.New("username is too short")
+	ErrUsernameTooLong           = errors.New("username is too long")
+	ErrUsernameInvalidCharacters = errors.New("username may only contain letters, numbers and underscores")
+	ErrUsernameReserved          = errors.New("username is reserved")
+	ErrUsernameConfusable        = errors.New("username is too similar to an existing username")
+	ErrUsernameUnavailable       = errors.New("username was recently released and is not yet available")
+	ErrUsernameUnchanged         = errors.New("username is unchanged")
+	ErrUsernameChangeTooSoon     = errors.New("username was changed too recently")
+)
+
+// FollowStatus describes the outcome of a follow attempt.
+type FollowStatus string
+
+const (
+	FollowStatusFollowing        FollowStatus = "following"
+	FollowStatusPending          FollowStatus = "pending"
+	FollowStatusAlreadyFollowing FollowStatus = "already_following"
+	FollowStatusAlreadyRequested FollowStatus = "already_requested"
+	FollowStatusUnfollowed       FollowStatus = "unfollowed"
+	FollowStatusWithdrawn        FollowStatus = "withdrawn"
+	FollowStatusNotFollowing     FollowStatus = "not_following"
+	FollowStatusNotFound         FollowStatus = "not_found"
+	FollowStatusSelf             FollowStatus = "self"
+	FollowStatusBlocked          FollowStatus = "blocked"
+)
+
+// SensitiveMedia is how a user wants the sensitive tweets of their home
+// timeline to be shown.
+type SensitiveMedia string
+
+const (
+	// SensitiveMediaBlur keeps the tweets, marked so that clients blur them.
+	SensitiveMediaBlur SensitiveMedia = "blur"
+	// SensitiveMediaHide removes the tweets from the timeline.
+	SensitiveMediaHide SensitiveMedia = "hide"
+	// SensitiveMediaShow shows the tweets as any other.
+	SensitiveMediaShow SensitiveMedia = "show"
+)
+
+// MaxFollowBatchSize is the maximum number of users that can be followed or
+// unfollowed in a single batch.
+const MaxFollowBatchSize = 100
+
+const (
+	// UsernameChangeCooldown is the minimum time between two username
+	// changes of the same account.
+	UsernameChangeCooldown = 7 * 24 * time.Hour
+
+	// UsernameGracePeriod is how long a released username keeps resolving to
+	// the renamed account and stays unavailable to other users.
+	UsernameGracePeriod = 30 * 24 * time.Hour
+
+	// DeactivationGracePeriod is how long a deactivated account can be
+	// reactivated before it is permanently deleted.
+	DeactivationGracePeriod = 30 * 24 * time.Hour
+)
+
+const (
+	// MaxDisplayNameLength is counted in characters.
+	MaxDisplayNameLength = 50
+
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+	// MaxSearchQueryLength is counted in characters, like the display name
+	// it is matched against.
+	MaxSearchQueryLength = MaxDisplayNameLength
 )
 
 type (
 	User struct {
-		ID        string
-		Username  string
-		CreatedAt time.Time
+		ID       string
+		Username string
+		// DisplayName is the optional name shown next to the username.
+		DisplayName string
+		Protected   bool
+		// OpenDMs lets anyone start a direct message conversation with the
+		// user, not only its followers.
+		OpenDMs bool
+		// PinnedTweetID is the tweet shown first on the profile, if any.
+		PinnedTweetID string
+		// SensitiveMedia defaults to SensitiveMediaBlur.
+		SensitiveMedia SensitiveMedia
+		// TimelineOwnTweets includes the user's own tweets in their home
+		// timeline.
+		TimelineOwnTweets bool
+		CreatedAt         time.Time
+		UpdatedAt         time.Time
+	}
+
+	// UserUpdate holds the user fields that can be changed after creation.
+	// Nil fields are left untouched.
+	UserUpdate struct {
+		DisplayName       *string
+		Protected         *bool
+		OpenDMs           *bool
+		SensitiveMedia    *SensitiveMedia
+		TimelineOwnTweets *bool
+	}
+
+	// FollowResult is the per-user outcome of a batch follow or unfollow.
+	FollowResult struct {
+		FolloweeID string
+		Status     FollowStatus
+	}
+
+	// UsernameLookup is the result of resolving a username. PreviousUsername
+	// is set when the username was resolved through the rename history, so
+	// clients can redirect to the current username.
+	UsernameLookup struct {
+		User             User
+		PreviousUsername string
+	}
+
+	// SearchResult is a user found by a search, as seen by the searcher.
+	SearchResult struct {
+		User
+		// Following tells whether the searcher follows the user.
+		Following     bool
+		FollowerCount int
+	}
+
+	// IndexCheckpoint is the position of the last user copied to the
+	// typeahead index, in (updated_at, id) order.
+	IndexCheckpoint struct {
 		UpdatedAt time.Time
+		ID        string
+	}
+
+	FollowRequest struct {
+		RequesterID string
+		TargetID    string
+		CreatedAt   time.Time
 	}
 
 	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
 	UserFinder interface {
 		ExistsByUsername(ctx context.Context, username string) (bool, error)
+		ExistsByUsernameSkeleton(ctx context.Context, skeleton string) (bool, error)
 		ExistsByID(ctx context.Context, id string) (bool, error)
+		FindByID(ctx context.Context, id string) (*User, error)
+		FindByUsername(ctx context.Context, username string) (*User, error)
+		FindByPreviousUsername(ctx context.Context, username string, since time.Time) (*User, error)
+		LastUsernameChange(ctx context.Context, id string) (time.Time, error)
+		IsUsernameHeld(ctx context.Context, skeleton, exceptUserID string, since time.Time) (bool, error)
+		FindByIDs(ctx context.Context, ids []string) ([]User, error)
+		GetPinnedTweetID(ctx context.Context, id string) (string, error)
+		GetDeactivatedAt(ctx context.Context, id string) (time.Time, error)
+		GetDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]string, error)
+		GetFollowers(ctx context.Context, id string) ([]string, error)
+		IsProtected(ctx context.Context, id string) (bool, error)
 		IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+		GetFollowingAmong(ctx context.Context, followerID string, followeeIDs []string) ([]string, error)
+		HasFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error)
+		GetFollowRequestsAmong(ctx context.Context, requesterID string, targetIDs []string) ([]string, error)
+		GetFollowRequests(ctx context.Context, targetID string) ([]FollowRequest, error)
+		IsBlocking(ctx context.Context, blockerID, blockedID string) (bool, error)
+		HasBlockBetween(ctx context.Context, userID, otherID string) (bool, error)
+		GetBlockedAmong(ctx context.Context, userID string, otherIDs []string) ([]string, error)
+		// SearchUsers returns the active users whose username or a word of
+		// whose display name starts with query, skipping the ones with a
+		// block with viewerID in either direction. Exact matches come
+		// first, then the users viewerID follows, then the most followed.
+		SearchUsers(ctx context.Context, viewerID, query string, limit int) ([]SearchResult, error)
+		// GetUsersUpdatedBetween returns the active users updated after the
+		// checkpoint and before the given time, in (updated_at, id) order.
+		GetUsersUpdatedBetween(ctx context.Context, after IndexCheckpoint, before time.Time, limit int) ([]User, error)
 	}
 
 	//go:generate mockery --name=UserCreator --output=mocks --outpkg=mocks --filename=user_creator.go
 	UserCreator interface {
 		CreateUser(ctx context.Context, user *User) error
+		UpdateUser(ctx context.Context, id string, update UserUpdate) error
+		ChangeUsername(ctx context.Context, id, oldUsername, newUsername string) error
+		DeactivateUser(ctx context.Context, id string) error
+		ReactivateUser(ctx context.Context, id string) error
+		DeleteUser(ctx context.Context, id string) error
 		FollowUser(ctx context.Context, followerID, followeeID string) error
 		UnfollowUser(ctx context.Context, followerID, followeeID string) error
+		FollowUsers(ctx context.Context, followerID string, followeeIDs []string) error
+		UnfollowUsers(ctx context.Context, followerID string, followeeIDs []string) error
+		CreateFollowRequests(ctx context.Context, requesterID string, targetIDs []string) error
+		CreateFollowRequest(ctx context.Context, requesterID, targetID string) error
+		ApproveFollowRequest(ctx context.Context, requesterID, targetID string) error
+		DeleteFollowRequest(ctx context.Context, requesterID, targetID string) error
+		DeleteFollowRequests(ctx context.Context, requesterID string, targetIDs []string) error
+		BlockUser(ctx context.Context, blockerID, blockedID string) error
+		UnblockUser(ctx context.Context, blockerID, blockedID string) error
+	}
+
+	//go:generate mockery --name=Notifier --output=mocks --outpkg=mocks --filename=notifier.go
+	Notifier interface {
+		NotifyFollow(ctx context.Context, followerID, followeeID string) error
+		NotifyFollowRequest(ctx context.Context, requesterID, targetID string) error
+	}
+
+	//go:generate mockery --name=WebhookDispatcher --output=mocks --outpkg=mocks --filename=webhook_dispatcher.go
+	WebhookDispatcher interface {
+		DispatchUserFollowed(ctx context.Context, followerID, followeeID string) error
 	}
 
 	//go:generate mockery --name=TimelineCache --output=mocks --outpkg=mocks --filename=timeline_cache_mock.go
 	TimelineCache interface {
 		InvalidateTimeline(ctx context.Context, userID string) error
 	}
+
+	// TypeaheadIndex maps the prefixes of the TypeaheadTerms of every user
+	// to their ID. It is fed in the background, so it may return users
+	// whose terms have changed since, or that no longer exist.
+	//
+	//go:generate mockery --name=TypeaheadIndex --output=mocks --outpkg=mocks --filename=typeahead_index.go
+	TypeaheadIndex interface {
+		// FindByPrefix returns up to limit distinct user IDs with a term
+		// starting with prefix, in term order.
+		FindByPrefix(ctx context.Context, prefix string, limit int) ([]string, error)
+		// GetCheckpoint returns the zero checkpoint if nothing was indexed.
+		GetCheckpoint(ctx context.Context) (IndexCheckpoint, error)
+		// IndexUsers replaces the terms of the users and moves the
+		// checkpoint.
+		IndexUsers(ctx context.Context, users []User, checkpoint IndexCheckpoint) error
+	}
+
+	// SearchIndexer is told about the users whose tweets must leave the
+	// search index or come back to it.
+	//
+	//go:generate mockery --name=SearchIndexer --output=mocks --outpkg=mocks --filename=search_indexer.go
+	SearchIndexer interface {
+		UserDeactivated(ctx context.Context, id string) error
+		UserReactivated(ctx context.Context, id string) error
+		UserDeleted(ctx context.Context, id string) error
+	}
 )
@@ -0,0 +1,56 @@
+package user
+
+import (
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/confusables"
+)
+
+const (
+	MinUsernameLength = 3
+	MaxUsernameLength = 15
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// UsernamePolicy holds the rules a username must satisfy on registration.
+type UsernamePolicy struct {
+	reserved map[string]bool
+}
+
+// NewUsernamePolicy builds a policy that rejects the given reserved names.
+// Reserved names are compared by confusable skeleton, so "Adm1n" is
+// rejected when "admin" is reserved.
+func NewUsernamePolicy(reserved []string) UsernamePolicy {
+	policy := UsernamePolicy{reserved: make(map[string]bool, len(reserved))}
+	for _, name := range reserved {
+		policy.reserved[UsernameSkeleton(name)] = true
+	}
+
+	return policy
+}
+
+// Validate checks the username grammar and the reserved-name list.
+func (p UsernamePolicy) Validate(username string) error {
+	length := utf8.RuneCountInString(username)
+	switch {
+	case length < MinUsernameLength:
+		return ErrUsernameTooShort
+	case length > MaxUsernameLength:
+		return ErrUsernameTooLong
+	case !usernamePattern.MatchString(username):
+		return ErrUsernameInvalidCharacters
+	case p.reserved[UsernameSkeleton(username)]:
+		return ErrUsernameReserved
+	}
+
+	return nil
+}
+
+// UsernameSkeleton returns the value used to detect case-insensitive and
+// look-alike duplicates. It is stored alongside the username and has a
+// unique index in the database.
+func UsernameSkeleton(username string) string {
+	return confusables.Skeleton(username)
+}
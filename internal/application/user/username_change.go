@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ChangeUsername renames the user. The previous username is kept in the
+// rename history so lookups keep resolving it during UsernameGracePeriod,
+// and it cannot be claimed by other users until that period ends.
+func (uc *userUseCase) ChangeUsername(ctx context.Context, id, username string) error {
+	if id == "" || username == "" {
+		return ErrInvalidInput
+	}
+
+	if err := uc.username.Validate(username); err != nil {
+		return err
+	}
+
+	current, err := uc.finder.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to find user with ID %s: %w", id, err)
+	}
+
+	if current.Username == username {
+		return ErrUsernameUnchanged
+	}
+
+	now := time.Now()
+	lastChange, err := uc.finder.LastUsernameChange(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check last username change: %w", err)
+	}
+	if !lastChange.IsZero() && now.Sub(lastChange) < UsernameChangeCooldown {
+		return ErrUsernameChangeTooSoon
+	}
+
+	// A change that only differs in case or look-alike characters keeps the
+	// same skeleton, which already belongs to this user.
+	skeleton := UsernameSkeleton(username)
+	if skeleton != UsernameSkeleton(current.Username) {
+		if err := uc.checkUsernameAvailable(ctx, id, username, skeleton, now); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.creator.ChangeUsername(ctx, id, current.Username, username); err != nil {
+		return fmt.Errorf("failed to change username: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserByUsername resolves a username to its account. When no account
+// currently uses it, recently released usernames are resolved through the
+// rename history.
+func (uc *userUseCase) GetUserByUsername(ctx context.Context, username string) (*UsernameLookup, error) {
+	if username == "" {
+		return nil, ErrInvalidInput
+	}
+
+	lookup := &UsernameLookup{}
+	u, err := uc.finder.FindByUsername(ctx, username)
+	if errors.Is(err, ErrUserNotFound) {
+		lookup.PreviousUsername = username
+		u, err = uc.finder.FindByPreviousUsername(ctx, username, time.Now().Add(-UsernameGracePeriod))
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user by previous username: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	// pinned_tweet_id is not cleared when the tweet is soft deleted.
+	if u.PinnedTweetID != "" {
+		if u.PinnedTweetID, err = uc.finder.GetPinnedTweetID(ctx, u.ID); err != nil {
+			return nil, fmt.Errorf("failed to find pinned tweet: %w", err)
+		}
+	}
+	lookup.User = *u
+
+	return lookup, nil
+}
+
+func (uc *userUseCase) checkUsernameAvailable(ctx context.Context, id, username, skeleton string, now time.Time) error {
+	exist, err := uc.finder.ExistsByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to check username: %w", err)
+	}
+	if exist {
+		return ErrUsernameExists
+	}
+
+	confusable, err := uc.finder.ExistsByUsernameSkeleton(ctx, skeleton)
+	if err != nil {
+		return fmt.Errorf("failed to check username: %w", err)
+	}
+	if confusable {
+		return ErrUsernameConfusable
+	}
+
+	held, err := uc.finder.IsUsernameHeld(ctx, skeleton, id, now.Add(-UsernameGracePeriod))
+	if err != nil {
+		return fmt.Errorf("failed to check username history: %w", err)
+	}
+	if held {
+		return ErrUsernameUnavailable
+	}
+
+	return nil
+}
@@ -0,0 +1,388 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_userUseCase_ChangeUsername(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		id       string
+		username string
+	}
+
+	type output struct {
+		err error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	since := mock.AnythingOfType("time.Time")
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if id or username is empty",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+				id:  "u1",
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if new username breaks the grammar",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "al!ce",
+			},
+			output:       output{err: user.ErrUsernameInvalidCharacters},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "alice",
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(nil, user.ErrUserNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username is unchanged",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "alice",
+			},
+			output: output{err: user.ErrUsernameUnchanged},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(&user.User{ID: in.id, Username: "alice"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username was changed within the cooldown",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "alice2",
+			},
+			output: output{err: user.ErrUsernameChangeTooSoon},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(&user.User{ID: in.id, Username: "alice"}, nil)
+				d.finder.On("LastUsernameChange", in.ctx, in.id).Return(time.Now().Add(-time.Hour), nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username is taken",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "bob",
+			},
+			output: output{err: user.ErrUsernameExists},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(&user.User{ID: in.id, Username: "alice"}, nil)
+				d.finder.On("LastUsernameChange", in.ctx, in.id).Return(time.Time{}, nil)
+				d.finder.On("ExistsByUsername", in.ctx, in.username).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if username is held by another user's rename history",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "bob",
+			},
+			output: output{err: user.ErrUsernameUnavailable},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(&user.User{ID: in.id, Username: "alice"}, nil)
+				d.finder.On("LastUsernameChange", in.ctx, in.id).Return(time.Now().Add(-2*user.UsernameChangeCooldown), nil)
+				d.finder.On("ExistsByUsername", in.ctx, in.username).Return(false, nil)
+				d.finder.On("ExistsByUsernameSkeleton", in.ctx, "bob").Return(false, nil)
+				d.finder.On("IsUsernameHeld", in.ctx, "bob", in.id, since).Return(true, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should skip availability checks when only the case changes",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "Alice",
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(&user.User{ID: in.id, Username: "alice"}, nil)
+				d.finder.On("LastUsernameChange", in.ctx, in.id).Return(time.Time{}, nil)
+				d.creator.On("ChangeUsername", in.ctx, in.id, "alice", in.username).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if creator.ChangeUsername returns error",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "carol",
+			},
+			output: output{err: fmt.Errorf("failed to change username: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(&user.User{ID: in.id, Username: "alice"}, nil)
+				d.finder.On("LastUsernameChange", in.ctx, in.id).Return(time.Time{}, nil)
+				d.finder.On("ExistsByUsername", in.ctx, in.username).Return(false, nil)
+				d.finder.On("ExistsByUsernameSkeleton", in.ctx, "carol").Return(false, nil)
+				d.finder.On("IsUsernameHeld", in.ctx, "carol", in.id, since).Return(false, nil)
+				d.creator.On("ChangeUsername", in.ctx, in.id, "alice", in.username).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should change username successfully",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				id:       "u1",
+				username: "carol",
+			},
+			output: output{err: nil},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByID", in.ctx, in.id).Return(&user.User{ID: in.id, Username: "alice"}, nil)
+				d.finder.On("LastUsernameChange", in.ctx, in.id).Return(time.Now().Add(-2*user.UsernameChangeCooldown), nil)
+				d.finder.On("ExistsByUsername", in.ctx, in.username).Return(false, nil)
+				d.finder.On("ExistsByUsernameSkeleton", in.ctx, "carol").Return(false, nil)
+				d.finder.On("IsUsernameHeld", in.ctx, "carol", in.id, since).Return(false, nil)
+				d.creator.On("ChangeUsername", in.ctx, in.id, "alice", in.username).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.err = uc.ChangeUsername(tt.input.ctx, tt.input.id, tt.input.username)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_userUseCase_GetUserByUsername(t *testing.T) {
+	type input struct {
+		ctx      context.Context
+		username string
+	}
+
+	type output struct {
+		lookup *user.UsernameLookup
+		err    error
+	}
+
+	type dependencies struct {
+		creator  *mocks.UserCreator
+		finder   *mocks.UserFinder
+		cache    *mocks.TimelineCache
+		notifier *mocks.Notifier
+		webhooks *mocks.WebhookDispatcher
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if username is empty",
+			input: input{
+				ctx: twcontext.NewTestContext(),
+			},
+			output:       output{err: user.ErrInvalidInput},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return user by current username",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				username: "alice",
+			},
+			output: output{lookup: &user.UsernameLookup{User: user.User{ID: "u1", Username: "alice"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByUsername", in.ctx, in.username).Return(&user.User{ID: "u1", Username: "alice"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return the pinned tweet if it still exists",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				username: "alice",
+			},
+			output: output{lookup: &user.UsernameLookup{User: user.User{ID: "u1", Username: "alice", PinnedTweetID: "t1"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByUsername", in.ctx, in.username).Return(&user.User{ID: "u1", Username: "alice", PinnedTweetID: "t1"}, nil)
+				d.finder.On("GetPinnedTweetID", in.ctx, "u1").Return("t1", nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should drop a pinned tweet that was deleted",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				username: "alice",
+			},
+			output: output{lookup: &user.UsernameLookup{User: user.User{ID: "u1", Username: "alice"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByUsername", in.ctx, in.username).Return(&user.User{ID: "u1", Username: "alice", PinnedTweetID: "t1"}, nil)
+				d.finder.On("GetPinnedTweetID", in.ctx, "u1").Return("", nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if finder.GetPinnedTweetID returns error",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				username: "alice",
+			},
+			output: output{err: fmt.Errorf("failed to find pinned tweet: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByUsername", in.ctx, in.username).Return(&user.User{ID: "u1", Username: "alice", PinnedTweetID: "t1"}, nil)
+				d.finder.On("GetPinnedTweetID", in.ctx, "u1").Return("", assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if finder.FindByUsername returns error",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				username: "alice",
+			},
+			output: output{err: fmt.Errorf("failed to find user: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByUsername", in.ctx, in.username).Return(nil, assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should resolve a previous username with a redirect hint",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				username: "old_alice",
+			},
+			output: output{lookup: &user.UsernameLookup{User: user.User{ID: "u1", Username: "alice"}, PreviousUsername: "old_alice"}},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByUsername", in.ctx, in.username).Return(nil, user.ErrUserNotFound)
+				d.finder.On("FindByPreviousUsername", in.ctx, in.username, mock.AnythingOfType("time.Time")).Return(&user.User{ID: "u1", Username: "alice"}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return not found if username is neither current nor recently released",
+			input: input{
+				ctx:      twcontext.NewTestContext(),
+				username: "ghost",
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.finder.On("FindByUsername", in.ctx, in.username).Return(nil, user.ErrUserNotFound)
+				d.finder.On("FindByPreviousUsername", in.ctx, in.username, mock.AnythingOfType("time.Time")).Return(nil, user.ErrUserNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				creator:  mocks.NewUserCreator(t),
+				finder:   mocks.NewUserFinder(t),
+				cache:    mocks.NewTimelineCache(t),
+				notifier: mocks.NewNotifier(t),
+				webhooks: mocks.NewWebhookDispatcher(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := user.NewUserUseCase(d.creator, d.finder, d.cache, user.NewUsernamePolicy(nil), d.notifier, d.webhooks, mocks.NewTypeaheadIndex(t), mocks.NewSearchIndexer(t))
+			var actual output
+			actual.lookup, actual.err = uc.GetUserByUsername(tt.input.ctx, tt.input.username)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
@@ -0,0 +1,445 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+)
+
+const (
+	// dispatchBatchSize bounds how many deliveries a single dispatch run
+	// sends.
+	dispatchBatchSize = 20
+	// claimLease is how long claimed deliveries stay hidden from other
+	// dispatchers. It must exceed the time needed to send a whole batch.
+	claimLease = 10 * time.Minute
+
+	DefaultDeliveriesLimit = 50
+	MaxDeliveriesLimit     = 100
+
+	// maxErrorLength truncates the error stored in the delivery log.
+	maxErrorLength = 500
+)
+
+// envelope is the JSON body posted to subscribers.
+type envelope struct {
+	Event     EventType `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+type usecase struct {
+	userFinder    UserFinder
+	subscriptions SubscriptionRepository
+	deliveries    DeliveryRepository
+	sender        Sender
+}
+
+func NewWebhookUseCase(userFinder UserFinder, subscriptions SubscriptionRepository, deliveries DeliveryRepository, sender Sender) *usecase {
+	return &usecase{userFinder: userFinder, subscriptions: subscriptions, deliveries: deliveries, sender: sender}
+}
+
+// CreateSubscription registers a callback URL for the given event types. The
+// returned subscription carries the signing secret, which is not exposed
+// again afterwards.
+func (uc *usecase) CreateSubscription(ctx context.Context, userID, rawURL string, events []EventType) (*Subscription, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	if err := validateURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	events, err := normalizeEvents(events)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, err := uc.userFinder.ExistsByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to check user with ID %s: %w", userID, err)
+	} else if !exists {
+		return nil, user.ErrUserNotFound
+	}
+
+	count, err := uc.subscriptions.CountSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook subscriptions: %w", err)
+	}
+	if count >= MaxSubscriptionsPerUser {
+		return nil, ErrTooManySubscriptions
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	subscription := &Subscription{
+		UserID: userID,
+		URL:    rawURL,
+		Secret: secret,
+		Events: events,
+		Active: true,
+	}
+	if err := uc.subscriptions.CreateSubscription(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (uc *usecase) GetSubscriptions(ctx context.Context, userID string) ([]Subscription, error) {
+	if userID == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	subscriptions, err := uc.subscriptions.GetSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (uc *usecase) DeleteSubscription(ctx context.Context, userID, id string) error {
+	if userID == "" || id == "" {
+		return user.ErrInvalidInput
+	}
+
+	if err := uc.subscriptions.DeleteSubscription(ctx, userID, id); err != nil {
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// EnableSubscription reactivates a subscription that was disabled after
+// repeated failures. Deliveries failed while it was disabled are not
+// retried.
+func (uc *usecase) EnableSubscription(ctx context.Context, userID, id string) (*Subscription, error) {
+	subscription, err := uc.getSubscription(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Active {
+		return subscription, nil
+	}
+
+	if err := uc.subscriptions.EnableSubscription(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to enable webhook subscription: %w", err)
+	}
+
+	subscription.Active = true
+	subscription.ConsecutiveFailures = 0
+	subscription.DisabledAt = nil
+
+	return subscription, nil
+}
+
+// GetDeliveries returns the most recent deliveries of a subscription, newest
+// first.
+func (uc *usecase) GetDeliveries(ctx context.Context, userID, subscriptionID string, limit int) ([]Delivery, error) {
+	if _, err := uc.getSubscription(ctx, userID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > MaxDeliveriesLimit {
+		limit = DefaultDeliveriesLimit
+	}
+
+	deliveries, err := uc.deliveries.GetDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// DispatchTweetCreated enqueues a tweet.created delivery for every active
+// subscription of the author.
+func (uc *usecase) DispatchTweetCreated(ctx context.Context, t tweet.Tweet) error {
+	return uc.enqueue(ctx, t.UserID, EventTweetCreated, TweetCreatedData{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Content:   t.Content,
+		CreatedAt: t.CreatedAt,
+	})
+}
+
+// DispatchUserFollowed enqueues a user.followed delivery for every active
+// subscription of the followed user.
+func (uc *usecase) DispatchUserFollowed(ctx context.Context, followerID, followeeID string) error {
+	return uc.enqueue(ctx, followeeID, EventUserFollowed, UserFollowedData{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		FollowedAt: time.Now().UTC(),
+	})
+}
+
+// DeliverPending sends the deliveries that are due and schedules retries for
+// the ones that fail. It returns the number of attempted deliveries.
+func (uc *usecase) DeliverPending(ctx context.Context) (int, error) {
+	deliveries, err := uc.deliveries.ClaimDueDeliveries(ctx, time.Now(), claimLease, dispatchBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(deliveries))
+	seen := make(map[string]bool, len(deliveries))
+	for _, d := range deliveries {
+		if !seen[d.SubscriptionID] {
+			seen[d.SubscriptionID] = true
+			ids = append(ids, d.SubscriptionID)
+		}
+	}
+
+	subscriptions, err := uc.subscriptions.GetSubscriptionsByIDs(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	byID := make(map[string]*Subscription, len(subscriptions))
+	for i := range subscriptions {
+		byID[subscriptions[i].ID] = &subscriptions[i]
+	}
+
+	for i := range deliveries {
+		if err := uc.deliver(ctx, byID[deliveries[i].SubscriptionID], &deliveries[i]); err != nil {
+			return i, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (uc *usecase) deliver(ctx context.Context, subscription *Subscription, delivery *Delivery) error {
+	now := time.Now()
+
+	if subscription == nil || !subscription.Active {
+		delivery.Status = DeliveryFailed
+		delivery.Error = "subscription disabled"
+		delivery.NextAttemptAt = now
+		return uc.updateDelivery(ctx, delivery)
+	}
+
+	status, sendErr := uc.sender.Send(ctx, subscription.URL, signedHeaders(subscription.Secret, delivery, now), delivery.Payload)
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = status
+
+	if sendErr == nil && status >= 200 && status < 300 {
+		delivery.Status = DeliverySucceeded
+		delivery.Error = ""
+		if err := uc.updateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		if subscription.ConsecutiveFailures > 0 {
+			if err := uc.subscriptions.ResetFailures(ctx, subscription.ID); err != nil {
+				return fmt.Errorf("failed to reset webhook failures: %w", err)
+			}
+			subscription.ConsecutiveFailures = 0
+		}
+		return nil
+	}
+
+	if sendErr != nil {
+		delivery.Error = truncate(sendErr.Error(), maxErrorLength)
+	} else {
+		delivery.Error = fmt.Sprintf("unexpected response status %d", status)
+	}
+
+	failures, err := uc.subscriptions.RecordFailure(ctx, subscription.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	subscription.ConsecutiveFailures = failures
+
+	switch {
+	case failures >= DisableAfterFailures:
+		delivery.Status = DeliveryFailed
+		if err := uc.updateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		if err := uc.subscriptions.DisableSubscription(ctx, subscription.ID, now); err != nil {
+			return fmt.Errorf("failed to disable webhook subscription: %w", err)
+		}
+		subscription.Active = false
+		subscription.DisabledAt = &now
+
+		twcontext.Logger(ctx).
+			WithField("subscription_id", subscription.ID).
+			WithField("failures", failures).
+			Warn("webhook subscription disabled after repeated failures")
+		return nil
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status = DeliveryFailed
+	default:
+		delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts))
+	}
+
+	return uc.updateDelivery(ctx, delivery)
+}
+
+func (uc *usecase) enqueue(ctx context.Context, ownerID string, event EventType, data any) error {
+	subscriptions, err := uc.subscriptions.GetActiveSubscriptions(ctx, ownerID, event)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(envelope{Event: event, CreatedAt: now.UTC(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	deliveries := make([]Delivery, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		deliveries = append(deliveries, Delivery{
+			SubscriptionID: s.ID,
+			EventType:      event,
+			Payload:        payload,
+			Status:         DeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+
+	if err := uc.deliveries.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *usecase) getSubscription(ctx context.Context, userID, id string) (*Subscription, error) {
+	if userID == "" || id == "" {
+		return nil, user.ErrInvalidInput
+	}
+
+	subscription, err := uc.subscriptions.GetSubscription(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (uc *usecase) updateDelivery(ctx context.Context, delivery *Delivery) error {
+	if err := uc.deliveries.UpdateDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to update webhook delivery %s: %w", delivery.ID, err)
+	}
+
+	return nil
+}
+
+// Backoff returns the delay before the next attempt after the given number
+// of failed attempts.
+func Backoff(attempts int) time.Duration {
+	delay := InitialBackoff
+	for i := 1; i < attempts && delay < MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, MaxBackoff)
+}
+
+// Sign returns the value of SignatureHeader for a body sent at the given
+// time.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func signedHeaders(secret string, delivery *Delivery, now time.Time) map[string]string {
+	return map[string]string{
+		"Content-Type":  "application/json",
+		SignatureHeader: Sign(secret, now, delivery.Payload),
+		EventHeader:     string(delivery.EventType),
+		DeliveryHeader:  delivery.ID,
+	}
+}
+
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return ErrInvalidURL
+	}
+
+	return nil
+}
+
+// normalizeEvents rejects unknown event types and drops duplicates.
+func normalizeEvents(events []EventType) ([]EventType, error) {
+	if len(events) == 0 {
+		return nil, ErrInvalidEventType
+	}
+
+	known := make(map[EventType]bool, len(EventTypes))
+	for _, e := range EventTypes {
+		known[e] = true
+	}
+
+	normalized := make([]EventType, 0, len(events))
+	seen := make(map[EventType]bool, len(events))
+	for _, e := range events {
+		if !known[e] {
+			return nil, ErrInvalidEventType
+		}
+		if !seen[e] {
+			seen[e] = true
+			normalized = append(normalized, e)
+		}
+	}
+
+	return normalized, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,534 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/tweet"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/user"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/internal/application/webhook/mocks"
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type dependencies struct {
+	userFinder    *mocks.UserFinder
+	subscriptions *mocks.SubscriptionRepository
+	deliveries    *mocks.DeliveryRepository
+	sender        *mocks.Sender
+}
+
+func init() {
+	twcontext.NewLogger()
+}
+
+func Test_usecase_CreateSubscription(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		url    string
+		events []webhook.EventType
+	}
+
+	type output struct {
+		subscription *webhook.Subscription
+		err          error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name: "should return error if the URL is not http or https",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				url:    "ftp://example.com/hook",
+				events: []webhook.EventType{webhook.EventTweetCreated},
+			},
+			output:       output{err: webhook.ErrInvalidURL},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if an event type is unknown",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				url:    "https://example.com/hook",
+				events: []webhook.EventType{"tweet.deleted"},
+			},
+			output:       output{err: webhook.ErrInvalidEventType},
+			dependencies: func(in input, d *dependencies) {},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if user does not exist",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				url:    "https://example.com/hook",
+				events: []webhook.EventType{webhook.EventTweetCreated},
+			},
+			output: output{err: user.ErrUserNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(false, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should return error if the user reached the subscription limit",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				url:    "https://example.com/hook",
+				events: []webhook.EventType{webhook.EventTweetCreated},
+			},
+			output: output{err: webhook.ErrTooManySubscriptions},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.subscriptions.On("CountSubscriptions", in.ctx, in.userID).Return(webhook.MaxSubscriptionsPerUser, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name: "should create an active subscription with a generated secret",
+			input: input{
+				ctx:    twcontext.NewTestContext(),
+				userID: "u1",
+				url:    "https://example.com/hook",
+				events: []webhook.EventType{webhook.EventUserFollowed, webhook.EventTweetCreated, webhook.EventUserFollowed},
+			},
+			output: output{subscription: &webhook.Subscription{
+				ID:     "s1",
+				UserID: "u1",
+				URL:    "https://example.com/hook",
+				Events: []webhook.EventType{webhook.EventUserFollowed, webhook.EventTweetCreated},
+				Active: true,
+			}},
+			dependencies: func(in input, d *dependencies) {
+				d.userFinder.On("ExistsByID", in.ctx, in.userID).Return(true, nil)
+				d.subscriptions.On("CountSubscriptions", in.ctx, in.userID).Return(2, nil)
+				d.subscriptions.On("CreateSubscription", in.ctx, mock.AnythingOfType("*webhook.Subscription")).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*webhook.Subscription).ID = "s1"
+				})
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.NoError(t, actual.err)
+				assert.True(t, strings.HasPrefix(actual.subscription.Secret, "whsec_"))
+				actual.subscription.Secret = ""
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				subscriptions: mocks.NewSubscriptionRepository(t),
+				deliveries:    mocks.NewDeliveryRepository(t),
+				sender:        mocks.NewSender(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := webhook.NewWebhookUseCase(d.userFinder, d.subscriptions, d.deliveries, d.sender)
+			var actual output
+			actual.subscription, actual.err = uc.CreateSubscription(tt.input.ctx, tt.input.userID, tt.input.url, tt.input.events)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_EnableSubscription(t *testing.T) {
+	type input struct {
+		ctx    context.Context
+		userID string
+		id     string
+	}
+
+	type output struct {
+		subscription *webhook.Subscription
+		err          error
+	}
+
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if the subscription does not belong to the user",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "s1"},
+			output: output{err: webhook.ErrSubscriptionNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.subscriptions.On("GetSubscription", in.ctx, in.userID, in.id).Return(nil, webhook.ErrSubscriptionNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should reactivate a disabled subscription and clear its failures",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", id: "s1"},
+			output: output{subscription: &webhook.Subscription{ID: "s1", UserID: "u1", Active: true}},
+			dependencies: func(in input, d *dependencies) {
+				d.subscriptions.On("GetSubscription", in.ctx, in.userID, in.id).Return(&webhook.Subscription{
+					ID: "s1", UserID: "u1", ConsecutiveFailures: webhook.DisableAfterFailures, DisabledAt: &disabledAt,
+				}, nil)
+				d.subscriptions.On("EnableSubscription", in.ctx, in.id).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				subscriptions: mocks.NewSubscriptionRepository(t),
+				deliveries:    mocks.NewDeliveryRepository(t),
+				sender:        mocks.NewSender(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := webhook.NewWebhookUseCase(d.userFinder, d.subscriptions, d.deliveries, d.sender)
+			var actual output
+			actual.subscription, actual.err = uc.EnableSubscription(tt.input.ctx, tt.input.userID, tt.input.id)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_GetDeliveries(t *testing.T) {
+	type input struct {
+		ctx            context.Context
+		userID         string
+		subscriptionID string
+		limit          int
+	}
+
+	type output struct {
+		deliveries []webhook.Delivery
+		err        error
+	}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should return error if the subscription does not belong to the user",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", subscriptionID: "s1"},
+			output: output{err: webhook.ErrSubscriptionNotFound},
+			dependencies: func(in input, d *dependencies) {
+				d.subscriptions.On("GetSubscription", in.ctx, in.userID, in.subscriptionID).Return(nil, webhook.ErrSubscriptionNotFound)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should fall back to the default limit",
+			input:  input{ctx: twcontext.NewTestContext(), userID: "u1", subscriptionID: "s1", limit: 1000},
+			output: output{deliveries: []webhook.Delivery{{ID: "d1", SubscriptionID: "s1"}}},
+			dependencies: func(in input, d *dependencies) {
+				d.subscriptions.On("GetSubscription", in.ctx, in.userID, in.subscriptionID).Return(&webhook.Subscription{ID: "s1"}, nil)
+				d.deliveries.On("GetDeliveries", in.ctx, in.subscriptionID, webhook.DefaultDeliveriesLimit).Return([]webhook.Delivery{{ID: "d1", SubscriptionID: "s1"}}, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				subscriptions: mocks.NewSubscriptionRepository(t),
+				deliveries:    mocks.NewDeliveryRepository(t),
+				sender:        mocks.NewSender(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := webhook.NewWebhookUseCase(d.userFinder, d.subscriptions, d.deliveries, d.sender)
+			var actual output
+			actual.deliveries, actual.err = uc.GetDeliveries(tt.input.ctx, tt.input.userID, tt.input.subscriptionID, tt.input.limit)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_DispatchTweetCreated(t *testing.T) {
+	type input struct {
+		ctx   context.Context
+		tweet tweet.Tweet
+	}
+
+	type output struct {
+		err error
+	}
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should not create deliveries if the author has no subscriptions",
+			input:  input{ctx: twcontext.NewTestContext(), tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi"}},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.subscriptions.On("GetActiveSubscriptions", in.ctx, "u1", webhook.EventTweetCreated).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should return error if repo.CreateDeliveries returns error",
+			input:  input{ctx: twcontext.NewTestContext(), tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi"}},
+			output: output{err: fmt.Errorf("failed to create webhook deliveries: %w", assert.AnError)},
+			dependencies: func(in input, d *dependencies) {
+				d.subscriptions.On("GetActiveSubscriptions", in.ctx, "u1", webhook.EventTweetCreated).Return([]webhook.Subscription{{ID: "s1"}}, nil)
+				d.deliveries.On("CreateDeliveries", in.ctx, mock.Anything).Return(assert.AnError)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected.err.Error(), actual.err.Error())
+			},
+		},
+		{
+			name:   "should enqueue a pending delivery per subscription",
+			input:  input{ctx: twcontext.NewTestContext(), tweet: tweet.Tweet{ID: "t1", UserID: "u1", Content: "hi", CreatedAt: createdAt}},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.subscriptions.On("GetActiveSubscriptions", in.ctx, "u1", webhook.EventTweetCreated).Return([]webhook.Subscription{{ID: "s1"}, {ID: "s2"}}, nil)
+				d.deliveries.On("CreateDeliveries", in.ctx, mock.MatchedBy(func(deliveries []webhook.Delivery) bool {
+					if len(deliveries) != 2 || deliveries[0].SubscriptionID != "s1" || deliveries[1].SubscriptionID != "s2" {
+						return false
+					}
+
+					var body struct {
+						Event webhook.EventType        `json:"event"`
+						Data  webhook.TweetCreatedData `json:"data"`
+					}
+					if err := json.Unmarshal(deliveries[0].Payload, &body); err != nil {
+						return false
+					}
+
+					return deliveries[0].Status == webhook.DeliveryPending &&
+						deliveries[0].EventType == webhook.EventTweetCreated &&
+						body.Event == webhook.EventTweetCreated &&
+						body.Data == webhook.TweetCreatedData{ID: "t1", UserID: "u1", Content: "hi", CreatedAt: createdAt}
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				subscriptions: mocks.NewSubscriptionRepository(t),
+				deliveries:    mocks.NewDeliveryRepository(t),
+				sender:        mocks.NewSender(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := webhook.NewWebhookUseCase(d.userFinder, d.subscriptions, d.deliveries, d.sender)
+			var actual output
+			actual.err = uc.DispatchTweetCreated(tt.input.ctx, tt.input.tweet)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func Test_usecase_DeliverPending(t *testing.T) {
+	type input struct {
+		ctx context.Context
+	}
+
+	type output struct {
+		delivered int
+		err       error
+	}
+
+	payload := json.RawMessage(`{"event":"tweet.created"}`)
+	newDelivery := func(attempts int) webhook.Delivery {
+		return webhook.Delivery{
+			ID:             "d1",
+			SubscriptionID: "s1",
+			EventType:      webhook.EventTweetCreated,
+			Payload:        payload,
+			Status:         webhook.DeliveryPending,
+			Attempts:       attempts,
+		}
+	}
+	subscription := webhook.Subscription{ID: "s1", URL: "https://example.com/hook", Secret: "secret", Active: true}
+
+	tests := []struct {
+		name         string
+		input        input
+		output       output
+		dependencies func(in input, d *dependencies)
+		assert       func(t *testing.T, expected, actual output)
+	}{
+		{
+			name:   "should do nothing if no delivery is due",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{},
+			dependencies: func(in input, d *dependencies) {
+				d.deliveries.On("ClaimDueDeliveries", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should send a signed payload and mark the delivery as succeeded",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{delivered: 1},
+			dependencies: func(in input, d *dependencies) {
+				failing := subscription
+				failing.ConsecutiveFailures = 3
+				d.deliveries.On("ClaimDueDeliveries", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]webhook.Delivery{newDelivery(0)}, nil)
+				d.subscriptions.On("GetSubscriptionsByIDs", in.ctx, []string{"s1"}).Return([]webhook.Subscription{failing}, nil)
+				d.sender.On("Send", in.ctx, subscription.URL, mock.MatchedBy(func(headers map[string]string) bool {
+					ts, _, _ := strings.Cut(strings.TrimPrefix(headers[webhook.SignatureHeader], "t="), ",")
+					var unix int64
+					fmt.Sscan(ts, &unix)
+					return headers[webhook.SignatureHeader] == webhook.Sign("secret", time.Unix(unix, 0), payload) &&
+						headers[webhook.EventHeader] == string(webhook.EventTweetCreated) &&
+						headers[webhook.DeliveryHeader] == "d1"
+				}), []byte(payload)).Return(200, nil)
+				d.deliveries.On("UpdateDelivery", in.ctx, mock.MatchedBy(func(delivery *webhook.Delivery) bool {
+					return delivery.Status == webhook.DeliverySucceeded && delivery.Attempts == 1 && delivery.ResponseStatus == 200
+				})).Return(nil)
+				d.subscriptions.On("ResetFailures", in.ctx, "s1").Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should schedule a retry with backoff if the receiver fails",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{delivered: 1},
+			dependencies: func(in input, d *dependencies) {
+				d.deliveries.On("ClaimDueDeliveries", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]webhook.Delivery{newDelivery(2)}, nil)
+				d.subscriptions.On("GetSubscriptionsByIDs", in.ctx, []string{"s1"}).Return([]webhook.Subscription{subscription}, nil)
+				d.sender.On("Send", in.ctx, subscription.URL, mock.Anything, []byte(payload)).Return(503, nil)
+				d.subscriptions.On("RecordFailure", in.ctx, "s1").Return(1, nil)
+				d.deliveries.On("UpdateDelivery", in.ctx, mock.MatchedBy(func(delivery *webhook.Delivery) bool {
+					wait := time.Until(delivery.NextAttemptAt)
+					return delivery.Status == webhook.DeliveryPending &&
+						delivery.Attempts == 3 &&
+						delivery.Error == "unexpected response status 503" &&
+						wait > webhook.Backoff(3)-time.Minute && wait <= webhook.Backoff(3)
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should give up on a delivery after the last attempt",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{delivered: 1},
+			dependencies: func(in input, d *dependencies) {
+				d.deliveries.On("ClaimDueDeliveries", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]webhook.Delivery{newDelivery(webhook.MaxAttempts - 1)}, nil)
+				d.subscriptions.On("GetSubscriptionsByIDs", in.ctx, []string{"s1"}).Return([]webhook.Subscription{subscription}, nil)
+				d.sender.On("Send", in.ctx, subscription.URL, mock.Anything, []byte(payload)).Return(0, assert.AnError)
+				d.subscriptions.On("RecordFailure", in.ctx, "s1").Return(webhook.MaxAttempts, nil)
+				d.deliveries.On("UpdateDelivery", in.ctx, mock.MatchedBy(func(delivery *webhook.Delivery) bool {
+					return delivery.Status == webhook.DeliveryFailed && delivery.Error == assert.AnError.Error()
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "should disable the subscription after repeated failures",
+			input:  input{ctx: twcontext.NewTestContext()},
+			output: output{delivered: 2},
+			dependencies: func(in input, d *dependencies) {
+				second := newDelivery(0)
+				second.ID = "d2"
+				d.deliveries.On("ClaimDueDeliveries", in.ctx, mock.Anything, mock.Anything, mock.Anything).Return([]webhook.Delivery{newDelivery(0), second}, nil)
+				d.subscriptions.On("GetSubscriptionsByIDs", in.ctx, []string{"s1"}).Return([]webhook.Subscription{subscription}, nil)
+				d.sender.On("Send", in.ctx, subscription.URL, mock.Anything, []byte(payload)).Return(500, nil).Once()
+				d.subscriptions.On("RecordFailure", in.ctx, "s1").Return(webhook.DisableAfterFailures, nil)
+				d.subscriptions.On("DisableSubscription", in.ctx, "s1", mock.Anything).Return(nil)
+				d.deliveries.On("UpdateDelivery", in.ctx, mock.MatchedBy(func(delivery *webhook.Delivery) bool {
+					return delivery.ID == "d1" && delivery.Status == webhook.DeliveryFailed && delivery.Attempts == 1
+				})).Return(nil)
+				d.deliveries.On("UpdateDelivery", in.ctx, mock.MatchedBy(func(delivery *webhook.Delivery) bool {
+					return delivery.ID == "d2" && delivery.Status == webhook.DeliveryFailed && delivery.Attempts == 0
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, expected, actual output) {
+				assert.Equal(t, expected, actual)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dependencies{
+				userFinder:    mocks.NewUserFinder(t),
+				subscriptions: mocks.NewSubscriptionRepository(t),
+				deliveries:    mocks.NewDeliveryRepository(t),
+				sender:        mocks.NewSender(t),
+			}
+			tt.dependencies(tt.input, d)
+
+			uc := webhook.NewWebhookUseCase(d.userFinder, d.subscriptions, d.deliveries, d.sender)
+			var actual output
+			actual.delivered, actual.err = uc.DeliverPending(tt.input.ctx)
+
+			tt.assert(t, tt.output, actual)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhook.Backoff(1))
+	assert.Equal(t, time.Minute, webhook.Backoff(2))
+	assert.Equal(t, 4*time.Minute, webhook.Backoff(4))
+	assert.Equal(t, webhook.MaxBackoff, webhook.Backoff(20))
+}
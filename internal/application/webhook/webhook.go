@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrInvalidURL           = errors.New("invalid webhook URL")
+	ErrInvalidEventType     = errors.New("invalid webhook event type")
+	ErrTooManySubscriptions = errors.New("too many webhook subscriptions")
+)
+
+// EventType names an event a subscription can receive.
+type EventType string
+
+const (
+	// EventTweetCreated is emitted when the subscription owner posts a tweet.
+	EventTweetCreated EventType = "tweet.created"
+	// EventUserFollowed is emitted when someone follows the subscription
+	// owner.
+	EventUserFollowed EventType = "user.followed"
+)
+
+// EventTypes lists the supported event types.
+var EventTypes = []EventType{EventTweetCreated, EventUserFollowed}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+const (
+	MaxSubscriptionsPerUser = 10
+
+	// MaxAttempts is how many times a delivery is tried before giving up.
+	MaxAttempts = 8
+	// InitialBackoff is the delay before the first retry; it doubles with
+	// every attempt up to MaxBackoff.
+	InitialBackoff = 30 * time.Second
+	MaxBackoff     = time.Hour
+	// DisableAfterFailures is how many consecutive failed attempts disable
+	// a subscription.
+	DisableAfterFailures = 15
+
+	// SignatureHeader carries "t=<unix timestamp>,v1=<hex HMAC-SHA256>" of
+	// "<timestamp>.<body>" keyed with the subscription secret.
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+type (
+	Subscription struct {
+		ID                  string
+		UserID              string
+		URL                 string
+		Secret              string
+		Events              []EventType
+		Active              bool
+		ConsecutiveFailures int
+		DisabledAt          *time.Time
+		CreatedAt           time.Time
+	}
+
+	Delivery struct {
+		ID             string
+		SubscriptionID string
+		EventType      EventType
+		Payload        json.RawMessage
+		Status         DeliveryStatus
+		Attempts       int
+		ResponseStatus int
+		Error          string
+		NextAttemptAt  time.Time
+		LastAttemptAt  *time.Time
+		CreatedAt      time.Time
+	}
+
+	TweetCreatedData struct {
+		ID        string    `json:"id"`
+		UserID    string    `json:"user_id"`
+		Content   string    `json:"content"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	UserFollowedData struct {
+		FollowerID string    `json:"follower_id"`
+		FolloweeID string    `json:"followee_id"`
+		FollowedAt time.Time `json:"followed_at"`
+	}
+
+	//go:generate mockery --name=UserFinder --output=mocks --outpkg=mocks --filename=user_finder.go
+	UserFinder interface {
+		ExistsByID(ctx context.Context, id string) (bool, error)
+	}
+
+	//go:generate mockery --name=SubscriptionRepository --output=mocks --outpkg=mocks --filename=subscription_repository.go
+	SubscriptionRepository interface {
+		CreateSubscription(ctx context.Context, subscription *Subscription) error
+		CountSubscriptions(ctx context.Context, userID string) (int, error)
+		GetSubscriptions(ctx context.Context, userID string) ([]Subscription, error)
+		GetSubscription(ctx context.Context, userID, id string) (*Subscription, error)
+		GetSubscriptionsByIDs(ctx context.Context, ids []string) ([]Subscription, error)
+		GetActiveSubscriptions(ctx context.Context, userID string, eventType EventType) ([]Subscription, error)
+		DeleteSubscription(ctx context.Context, userID, id string) error
+		// EnableSubscription reactivates the subscription and clears its
+		// failure count.
+		EnableSubscription(ctx context.Context, id string) error
+		// RecordFailure increments the consecutive failures of the
+		// subscription and returns the new count.
+		RecordFailure(ctx context.Context, id string) (int, error)
+		ResetFailures(ctx context.Context, id string) error
+		// DisableSubscription deactivates the subscription and fails its
+		// pending deliveries.
+		DisableSubscription(ctx context.Context, id string, at time.Time) error
+	}
+
+	//go:generate mockery --name=DeliveryRepository --output=mocks --outpkg=mocks --filename=delivery_repository.go
+	DeliveryRepository interface {
+		CreateDeliveries(ctx context.Context, deliveries []Delivery) error
+		// ClaimDueDeliveries returns up to limit pending deliveries due at
+		// now and postpones them by lease, so that other instances do not
+		// pick them up while they are being sent.
+		ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+		UpdateDelivery(ctx context.Context, delivery *Delivery) error
+		GetDeliveries(ctx context.Context, subscriptionID string, limit int) ([]Delivery, error)
+	}
+
+	// Sender posts a signed payload to a subscriber and returns the HTTP
+	// status of the response.
+	//
+	//go:generate mockery --name=Sender --output=mocks --outpkg=mocks --filename=sender.go
+	Sender interface {
+		Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+	}
+)
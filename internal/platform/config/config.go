@@ -16,6 +16,9 @@ const (
 	partialProductionScope = "prod"
 
 	AppPathEnv = "APP_PATH"
+
+	SearchBackendPostgres = "postgres"
+	SearchBackendEmbedded = "embedded"
 )
 
 type (
@@ -24,6 +27,63 @@ type (
 		Scope      string
 		Database   Database
 		Cache      Cache
+		Users      Users
+		BlobStore  BlobStore
+		Exports    Exports
+		Stream     Stream
+		Webhooks   Webhooks
+		Tweets     Tweets
+		Links      Links
+		Search     Search
+	}
+
+	BlobStore struct {
+		Path string
+	}
+
+	Exports struct {
+		PurgeInterval time.Duration
+	}
+
+	Stream struct {
+		HeartbeatInterval time.Duration
+		BufferSize        int
+		HistorySize       int
+		HistoryTTL        time.Duration
+	}
+
+	Webhooks struct {
+		DispatchInterval time.Duration
+		Timeout          time.Duration
+		// AllowPrivateNetworks lets webhooks target loopback and private
+		// addresses. Only meant for local development.
+		AllowPrivateNetworks bool
+	}
+
+	Links struct {
+		PreviewFetchInterval time.Duration
+		PreviewTimeout       time.Duration
+		// AllowPrivateNetworks lets previews be fetched from loopback and
+		// private addresses. Only meant for local development.
+		AllowPrivateNetworks bool
+	}
+
+	Search struct {
+		// Backend is SearchBackendPostgres or SearchBackendEmbedded.
+		Backend string
+		// IndexPath is the directory of the embedded index.
+		IndexPath string
+	}
+
+	Tweets struct {
+		PublishInterval    time.Duration
+		DraftPurgeInterval time.Duration
+	}
+
+	Users struct {
+		ReservedUsernames      []string
+		PurgeInterval          time.Duration
+		TypeaheadIndexInterval time.Duration
 	}
 
 	Cache struct {
@@ -32,6 +92,8 @@ type (
 		DB                int
 		TTL               time.Duration
 		DefaultExpiration time.Duration
+		SuggestionsTTL    time.Duration
+		PollTalliesTTL    time.Duration
 	}
 
 	Database struct {
@@ -84,10 +146,53 @@ func NewConfig() (Configuration, error) {
 			DB:                getEnvInt("CACHE_DB", 0),
 			TTL:               time.Duration(getEnvInt("CACHE_TTL", 60)) * time.Second,
 			DefaultExpiration: time.Duration(getEnvInt("CACHE_DEFAULT_EXPIRATION", 3600)) * time.Second,
+			SuggestionsTTL:    time.Duration(getEnvInt("CACHE_SUGGESTIONS_TTL", 600)) * time.Second,
+			PollTalliesTTL:    time.Duration(getEnvInt("CACHE_POLL_TALLIES_TTL", 60)) * time.Second,
+		},
+		Users: Users{
+			ReservedUsernames:      getEnvList("RESERVED_USERNAMES", defaultReservedUsernames),
+			PurgeInterval:          time.Duration(getEnvInt("ACCOUNT_PURGE_INTERVAL", 3600)) * time.Second,
+			TypeaheadIndexInterval: time.Duration(getEnvInt("USER_TYPEAHEAD_INDEX_INTERVAL", 10)) * time.Second,
+		},
+		BlobStore: BlobStore{
+			Path: getEnv("BLOB_STORE_PATH", "data/blobs"),
+		},
+		Exports: Exports{
+			PurgeInterval: time.Duration(getEnvInt("EXPORT_PURGE_INTERVAL", 3600)) * time.Second,
+		},
+		Stream: Stream{
+			HeartbeatInterval: time.Duration(getEnvInt("STREAM_HEARTBEAT_INTERVAL", 15)) * time.Second,
+			BufferSize:        getEnvInt("STREAM_BUFFER_SIZE", 64),
+			HistorySize:       getEnvInt("STREAM_HISTORY_SIZE", 200),
+			HistoryTTL:        time.Duration(getEnvInt("STREAM_HISTORY_TTL", 86400)) * time.Second,
+		},
+		Webhooks: Webhooks{
+			DispatchInterval:     time.Duration(getEnvInt("WEBHOOK_DISPATCH_INTERVAL", 5)) * time.Second,
+			Timeout:              time.Duration(getEnvInt("WEBHOOK_TIMEOUT", 10)) * time.Second,
+			AllowPrivateNetworks: getEnvBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		Links: Links{
+			PreviewFetchInterval: time.Duration(getEnvInt("LINK_PREVIEW_FETCH_INTERVAL", 5)) * time.Second,
+			PreviewTimeout:       time.Duration(getEnvInt("LINK_PREVIEW_TIMEOUT", 5)) * time.Second,
+			AllowPrivateNetworks: getEnvBool("LINK_PREVIEW_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		Tweets: Tweets{
+			PublishInterval:    time.Duration(getEnvInt("SCHEDULED_TWEET_PUBLISH_INTERVAL", 15)) * time.Second,
+			DraftPurgeInterval: time.Duration(getEnvInt("DRAFT_PURGE_INTERVAL", 3600)) * time.Second,
+		},
+		Search: Search{
+			Backend:   getEnv("SEARCH_BACKEND", SearchBackendPostgres),
+			IndexPath: getEnv("SEARCH_INDEX_PATH", "data/search"),
 		},
 	}, nil
 }
 
+var defaultReservedUsernames = []string{
+	"about", "admin", "administrator", "api", "help", "home", "login", "logout",
+	"me", "moderator", "notifications", "root", "search", "settings", "signup",
+	"support", "system", "twitter",
+}
+
 func getEnv(key string, defaultValue string) string {
 	if value, found := os.LookupEnv(key); found {
 		return value
@@ -120,6 +225,20 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getEnvList(key string, defaultValue []string) []string {
+	if value, found := os.LookupEnv(key); found {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list
+	}
+
+	return defaultValue
+}
+
 func IsLocalScope() bool {
 	return getEnv(scopeEnv, localScope) == localScope
 }
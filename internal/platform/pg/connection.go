@@ -20,7 +20,7 @@ func NewDBConnections(cfg config.Database) (Connections, error) {
 		return Connections{}, err
 	}
 
-	db, err := gorm.Open(dialector, &gorm.Config{})
+	db, err := gorm.Open(dialector, &gorm.Config{TranslateError: true})
 	if err != nil {
 		return Connections{}, err
 	}
@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	twcontext "github.com/oscarsalomon89/scalable-microblogging-platform/pkg/context"
+	"go.uber.org/fx"
+)
+
+// Job is a unit of background work run periodically by Schedule.
+type Job func(ctx context.Context) error
+
+// Schedule runs job every interval while the application is running. Each run
+// gets its own request ID for logging; the context is cancelled on shutdown.
+func Schedule(lc fx.Lifecycle, name string, interval time.Duration, job Job) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go run(ctx, name, interval, job)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+func run(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			jobCtx := twcontext.NewWithRequestID(ctx)
+			if err := job(jobCtx); err != nil {
+				twcontext.Logger(jobCtx).WithError(err).WithField("job", name).Error("scheduled job failed")
+			}
+		}
+	}
+}
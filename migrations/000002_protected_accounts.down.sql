@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_follow_requests_target_created_at;
+DROP TABLE IF EXISTS follow_requests;
+ALTER TABLE users DROP COLUMN IF EXISTS protected;
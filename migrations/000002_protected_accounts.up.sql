@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN protected BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE follow_requests (
+    requester_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (requester_id, target_id)
+);
+
+CREATE INDEX idx_follow_requests_target_created_at ON follow_requests (target_id, created_at DESC);
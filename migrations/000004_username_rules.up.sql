@@ -0,0 +1,26 @@
+-- Backfill mirrors confusables.Skeleton for the ASCII usernames accepted by the grammar.
+ALTER TABLE users ADD COLUMN username_skeleton TEXT;
+
+UPDATE users
+SET username_skeleton = replace(replace(translate(lower(username), '01i', 'oll'), 'rn', 'm'), 'vv', 'w');
+
+-- Existing usernames were never checked against the new rules: keep the oldest
+-- account of each skeleton (which also covers case-only duplicates) and rename
+-- the others to a name derived from their ID, which they can change later.
+WITH ranked AS (
+    SELECT id, row_number() OVER (PARTITION BY username_skeleton ORDER BY created_at, id) AS position
+    FROM users
+)
+UPDATE users
+SET username = 'user_' || left(replace(users.id::text, '-', ''), 10)
+FROM ranked
+WHERE users.id = ranked.id AND ranked.position > 1;
+
+UPDATE users
+SET username_skeleton = replace(replace(translate(lower(username), '01i', 'oll'), 'rn', 'm'), 'vv', 'w')
+WHERE username LIKE 'user\_%';
+
+ALTER TABLE users ALTER COLUMN username_skeleton SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_users_username_lower ON users (lower(username));
+CREATE UNIQUE INDEX idx_users_username_skeleton ON users (username_skeleton);
@@ -0,0 +1,11 @@
+CREATE TABLE username_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username TEXT NOT NULL,
+    username_skeleton TEXT NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_username_history_username ON username_history (lower(username), changed_at DESC);
+CREATE INDEX idx_username_history_skeleton ON username_history (username_skeleton, changed_at DESC);
+CREATE INDEX idx_username_history_user ON username_history (user_id, changed_at DESC);
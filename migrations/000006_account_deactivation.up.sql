@@ -0,0 +1 @@
+CREATE INDEX idx_users_deactivated ON users (deleted_at) WHERE deleted_at IS NOT NULL;
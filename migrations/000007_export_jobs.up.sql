@@ -0,0 +1,15 @@
+CREATE TABLE export_jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    blob_key TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_export_jobs_user ON export_jobs (user_id, created_at DESC);
+CREATE INDEX idx_export_jobs_expires_at ON export_jobs (expires_at) WHERE status = 'completed';
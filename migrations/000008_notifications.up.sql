@@ -0,0 +1,12 @@
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY,
+    recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    tweet_id UUID REFERENCES tweets(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_notifications_recipient ON notifications (recipient_id, created_at DESC, id DESC);
+CREATE INDEX idx_notifications_unread ON notifications (recipient_id) WHERE read_at IS NULL;
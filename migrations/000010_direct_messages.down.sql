@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS direct_messages;
+DROP TABLE IF EXISTS conversation_participants;
+DROP TABLE IF EXISTS conversations;
+
+ALTER TABLE users DROP COLUMN IF EXISTS open_dms;
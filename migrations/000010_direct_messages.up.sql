@@ -0,0 +1,32 @@
+ALTER TABLE users ADD COLUMN open_dms BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE conversations (
+    id UUID PRIMARY KEY,
+    is_group BOOLEAN NOT NULL DEFAULT false,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- direct_key identifies one-to-one conversations ("<lower id>:<higher id>")
+    -- so that there is at most one per pair of users.
+    direct_key TEXT UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_message_at TIMESTAMPTZ
+);
+
+CREATE TABLE conversation_participants (
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_read_at TIMESTAMPTZ,
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX idx_conversation_participants_user ON conversation_participants (user_id);
+
+CREATE TABLE direct_messages (
+    id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_direct_messages_conversation ON direct_messages (conversation_id, created_at DESC, id DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE scheduled_tweets (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    publish_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_scheduled_tweets_user ON scheduled_tweets (user_id, publish_at);
+CREATE INDEX idx_scheduled_tweets_publish_at ON scheduled_tweets (publish_at);
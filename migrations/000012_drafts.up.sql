@@ -0,0 +1,10 @@
+CREATE TABLE drafts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parts JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_drafts_user ON drafts (user_id, updated_at DESC);
+CREATE INDEX idx_drafts_updated_at ON drafts (updated_at);
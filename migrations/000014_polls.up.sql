@@ -0,0 +1,19 @@
+CREATE TABLE polls (
+    tweet_id UUID PRIMARY KEY REFERENCES tweets(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- options holds the option texts, ordered by position.
+    options JSONB NOT NULL,
+    results_visibility TEXT NOT NULL CHECK (results_visibility IN ('after_vote', 'after_close')),
+    ends_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE poll_votes (
+    tweet_id UUID NOT NULL REFERENCES polls(tweet_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tweet_id, user_id)
+);
+
+CREATE INDEX idx_poll_votes_user ON poll_votes (user_id);
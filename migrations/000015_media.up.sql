@@ -0,0 +1,22 @@
+CREATE TABLE media (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    blob_key TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_media_user ON media (user_id);
+
+CREATE TABLE tweet_media (
+    tweet_id UUID NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    PRIMARY KEY (tweet_id, media_id)
+);
+
+CREATE INDEX idx_tweet_media_media ON tweet_media (media_id);
@@ -0,0 +1,7 @@
+ALTER TABLE users DROP COLUMN IF EXISTS sensitive_media;
+
+ALTER TABLE tweets DROP COLUMN IF EXISTS sensitive;
+
+ALTER TABLE media
+    DROP COLUMN IF EXISTS sensitive,
+    DROP COLUMN IF EXISTS alt_text;
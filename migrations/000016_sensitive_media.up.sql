@@ -0,0 +1,9 @@
+ALTER TABLE media
+    ADD COLUMN alt_text TEXT NOT NULL DEFAULT '',
+    ADD COLUMN sensitive BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE tweets ADD COLUMN sensitive BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE users
+    ADD COLUMN sensitive_media TEXT NOT NULL DEFAULT 'blur'
+    CHECK (sensitive_media IN ('blur', 'hide', 'show'));
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS link_previews;
+DROP TABLE IF EXISTS tweet_links;
+
+ALTER TABLE tweets ADD CONSTRAINT tweets_content_check CHECK (length(content) <= 280) NOT VALID;
@@ -0,0 +1,25 @@
+-- Links count as a fixed length, so the length of a tweet is checked by the
+-- application.
+ALTER TABLE tweets DROP CONSTRAINT IF EXISTS tweets_content_check;
+
+CREATE TABLE tweet_links (
+    tweet_id UUID NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    url TEXT NOT NULL,
+    start_offset INTEGER NOT NULL,
+    end_offset INTEGER NOT NULL,
+    PRIMARY KEY (tweet_id, position)
+);
+
+CREATE TABLE link_previews (
+    url TEXT PRIMARY KEY,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'ready', 'failed')),
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    fetched_at TIMESTAMPTZ,
+    next_fetch_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_link_previews_queue ON link_previews (next_fetch_at) WHERE next_fetch_at IS NOT NULL;
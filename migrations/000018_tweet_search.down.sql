@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tweets_search_vector;
+
+ALTER TABLE tweets
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS language;
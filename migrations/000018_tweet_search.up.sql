@@ -0,0 +1,22 @@
+-- language is the optional ISO 639-1 code of the tweet. search_vector holds
+-- the words of the content as typed, plus their stems when the language is
+-- known, so that both unstemmed and language-aware queries use the index.
+-- Being generated, it is kept up to date on every insert and update.
+ALTER TABLE tweets
+    ADD COLUMN language TEXT NOT NULL DEFAULT ''
+    CHECK (language IN ('', 'de', 'en', 'es', 'fr', 'it', 'pt'));
+
+ALTER TABLE tweets ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector('simple', content) ||
+    CASE language
+        WHEN 'de' THEN to_tsvector('german', content)
+        WHEN 'en' THEN to_tsvector('english', content)
+        WHEN 'es' THEN to_tsvector('spanish', content)
+        WHEN 'fr' THEN to_tsvector('french', content)
+        WHEN 'it' THEN to_tsvector('italian', content)
+        WHEN 'pt' THEN to_tsvector('portuguese', content)
+        ELSE ''::tsvector
+    END
+) STORED;
+
+CREATE INDEX idx_tweets_search_vector ON tweets USING GIN (search_vector);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN timeline_own_tweets BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_tweets DROP COLUMN IF EXISTS failed_at;
+ALTER TABLE scheduled_tweets DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_tweets ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE scheduled_tweets ADD COLUMN failed_at TIMESTAMPTZ;
@@ -0,0 +1,45 @@
+// Package confusables computes a simplified UTS #39 skeleton of a string so
+// that visually confusable identifiers ("paypal", "PayPa1", "paypai") map to
+// the same value.
+//
+// Only ASCII folds are listed because usernames are restricted to
+// [A-Za-z0-9_]; homoglyphs from other scripts are rejected by that grammar
+// before a skeleton is ever computed. The migration that backfills
+// users.username_skeleton mirrors these rules and must change with them.
+package confusables
+
+import (
+	"strings"
+	"unicode"
+)
+
+// runeMap folds single characters that are commonly mistaken for a Latin
+// letter into that letter. It is applied after lowercasing.
+var runeMap = map[rune]rune{
+	'0': 'o',
+	'1': 'l',
+	'i': 'l',
+}
+
+// sequenceReplacer folds character sequences that render like a single letter.
+var sequenceReplacer = strings.NewReplacer(
+	"rn", "m",
+	"vv", "w",
+)
+
+// Skeleton returns the confusable skeleton of s. Two strings with the same
+// skeleton should be treated as the same identifier.
+func Skeleton(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range s {
+		r = unicode.ToLower(r)
+		if mapped, ok := runeMap[r]; ok {
+			r = mapped
+		}
+		b.WriteRune(r)
+	}
+
+	return sequenceReplacer.Replace(b.String())
+}
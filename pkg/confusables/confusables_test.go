@@ -0,0 +1,49 @@
+package confusables_test
+
+import (
+	"testing"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/confusables"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Skeleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		skeleton string
+	}{
+		{name: "should fold a lowercase name", input: "alice", skeleton: "allce"},
+		{name: "should lowercase", input: "ALICE", skeleton: "allce"},
+		{name: "should fold zero into o", input: "b0b", skeleton: "bob"},
+		{name: "should fold one into l", input: "paypa1", skeleton: "paypal"},
+		{name: "should fold i into l", input: "paypai", skeleton: "paypal"},
+		{name: "should fold uppercase I into l", input: "PAYPAI", skeleton: "paypal"},
+		{name: "should fold rn into m", input: "rnodern", skeleton: "modem"},
+		{name: "should fold vv into w", input: "vvalter", skeleton: "walter"},
+		{name: "should keep digits without a fold", input: "user_42", skeleton: "user_42"},
+		{name: "should keep an empty string", input: "", skeleton: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.skeleton, confusables.Skeleton(tt.input))
+		})
+	}
+}
+
+func Test_Skeleton_confusablePairs(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{name: "should match case-only variants", a: "Alice", b: "aLiCe"},
+		{name: "should match digit homoglyphs", a: "paypal", b: "PayPa1"},
+		{name: "should match sequence homoglyphs", a: "modern", b: "rnodern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, confusables.Skeleton(tt.a), confusables.Skeleton(tt.b))
+		})
+	}
+}
@@ -30,6 +30,12 @@ func NewDetachedWithRequestID(ctx context.Context) context.Context {
 	return context.WithValue(context.Background(), requestIDKey, requestID)
 }
 
+// NewWithRequestID derives a context carrying a fresh request ID, for work
+// that does not originate from an HTTP request (e.g. scheduled jobs).
+func NewWithRequestID(parent context.Context) context.Context {
+	return context.WithValue(parent, requestIDKey, newRequestID())
+}
+
 func NewTestContext() context.Context {
 	return context.WithValue(context.Background(), requestIDKey, newRequestID())
 }
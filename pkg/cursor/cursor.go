@@ -0,0 +1,40 @@
+// Package cursor encodes the keyset cursors used to page through lists
+// sorted by creation time: an opaque token holding the creation time and ID
+// of the last item of a page.
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalid = errors.New("invalid cursor")
+
+// Encode returns the token of the item created at createdAt with the given ID.
+func Encode(createdAt time.Time, id string) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode returns the creation time and ID held by a token built by Encode.
+func Decode(s string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return time.Time{}, "", ErrInvalid
+	}
+
+	nanos, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return time.Time{}, "", ErrInvalid
+	}
+
+	ts, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", ErrInvalid
+	}
+
+	return time.Unix(0, ts).UTC(), id, nil
+}
@@ -0,0 +1,37 @@
+package cursor_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/cursor"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Encode(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC)
+
+	gotCreatedAt, gotID, err := cursor.Decode(cursor.Encode(createdAt, "t1"))
+	assert.NoError(t, err)
+	assert.Equal(t, createdAt, gotCreatedAt)
+	assert.Equal(t, "t1", gotID)
+}
+
+func Test_Decode(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "should reject a token that is not base64", token: "%%%"},
+		{name: "should reject a token without separator", token: base64.RawURLEncoding.EncodeToString([]byte("123"))},
+		{name: "should reject a token without ID", token: base64.RawURLEncoding.EncodeToString([]byte("123|"))},
+		{name: "should reject a token with an invalid time", token: base64.RawURLEncoding.EncodeToString([]byte("abc|t1"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := cursor.Decode(tt.token)
+			assert.ErrorIs(t, err, cursor.ErrInvalid)
+		})
+	}
+}
@@ -8,15 +8,17 @@ import (
 type APIErrorType string
 
 const (
-	ErrNotFound     APIErrorType = "NOT_FOUND"
-	ErrConflict     APIErrorType = "CONFLICT"
-	ErrBadRequest   APIErrorType = "BAD_REQUEST"
-	ErrInternal     APIErrorType = "INTERNAL_ERROR"
-	ErrValidation   APIErrorType = "VALIDATION_ERROR"
-	ErrUnauthorized APIErrorType = "UNAUTHORIZED"
-	ErrTimeout      APIErrorType = "TIMEOUT"
-	ErrUnavailable  APIErrorType = "SERVICE_UNAVAILABLE"
-	ErrForbidden    APIErrorType = "FORBIDDEN"
+	ErrNotFound        APIErrorType = "NOT_FOUND"
+	ErrConflict        APIErrorType = "CONFLICT"
+	ErrBadRequest      APIErrorType = "BAD_REQUEST"
+	ErrInternal        APIErrorType = "INTERNAL_ERROR"
+	ErrValidation      APIErrorType = "VALIDATION_ERROR"
+	ErrUnauthorized    APIErrorType = "UNAUTHORIZED"
+	ErrTimeout         APIErrorType = "TIMEOUT"
+	ErrUnavailable     APIErrorType = "SERVICE_UNAVAILABLE"
+	ErrForbidden       APIErrorType = "FORBIDDEN"
+	ErrTooManyRequests APIErrorType = "TOO_MANY_REQUESTS"
+	ErrTooLarge        APIErrorType = "PAYLOAD_TOO_LARGE"
 )
 
 // APIError represents the structure of an HTTP error for APIs.
@@ -38,15 +40,17 @@ func (e *APIError) Error() string {
 
 // HTTP status code mapping for each error type.
 var httpStatus = map[APIErrorType]int{
-	ErrBadRequest:   http.StatusBadRequest,
-	ErrNotFound:     http.StatusNotFound,
-	ErrConflict:     http.StatusConflict,
-	ErrInternal:     http.StatusInternalServerError,
-	ErrValidation:   http.StatusBadRequest,
-	ErrUnauthorized: http.StatusUnauthorized,
-	ErrTimeout:      http.StatusGatewayTimeout,
-	ErrUnavailable:  http.StatusServiceUnavailable,
-	ErrForbidden:    http.StatusForbidden,
+	ErrBadRequest:      http.StatusBadRequest,
+	ErrNotFound:        http.StatusNotFound,
+	ErrConflict:        http.StatusConflict,
+	ErrInternal:        http.StatusInternalServerError,
+	ErrValidation:      http.StatusBadRequest,
+	ErrUnauthorized:    http.StatusUnauthorized,
+	ErrTimeout:         http.StatusGatewayTimeout,
+	ErrUnavailable:     http.StatusServiceUnavailable,
+	ErrForbidden:       http.StatusForbidden,
+	ErrTooManyRequests: http.StatusTooManyRequests,
+	ErrTooLarge:        http.StatusRequestEntityTooLarge,
 }
 
 // New creates a new APIError with the given type, message, and optional details/context.
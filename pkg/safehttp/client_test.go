@@ -0,0 +1,95 @@
+package safehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsPublic(t *testing.T) {
+	tests := []struct {
+		name   string
+		addr   string
+		public bool
+	}{
+		{name: "should accept a public IPv4 address", addr: "93.184.216.34", public: true},
+		{name: "should accept a public IPv6 address", addr: "2606:2800:220:1:248:1893:25c8:1946", public: true},
+		{name: "should reject IPv4 loopback", addr: "127.0.0.1"},
+		{name: "should reject the rest of the IPv4 loopback range", addr: "127.1.2.3"},
+		{name: "should reject IPv6 loopback", addr: "::1"},
+		{name: "should reject RFC 1918 10/8", addr: "10.0.0.1"},
+		{name: "should reject RFC 1918 172.16/12", addr: "172.31.255.255"},
+		{name: "should reject RFC 1918 192.168/16", addr: "192.168.1.1"},
+		{name: "should reject the link-local metadata address", addr: "169.254.169.254"},
+		{name: "should reject IPv6 link-local", addr: "fe80::1"},
+		{name: "should reject IPv6 unique local", addr: "fd00::1"},
+		{name: "should reject carrier-grade NAT", addr: "100.64.0.1"},
+		{name: "should reject the unspecified address", addr: "0.0.0.0"},
+		{name: "should reject multicast", addr: "224.0.0.1"},
+		{name: "should reject IPv4-mapped loopback", addr: "::ffff:127.0.0.1"},
+		{name: "should reject IPv4-mapped metadata address", addr: "::ffff:169.254.169.254"},
+		{name: "should reject IPv4-mapped private address", addr: "::ffff:10.0.0.1"},
+		{name: "should accept IPv4-mapped public address", addr: "::ffff:93.184.216.34", public: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.public, IsPublic(netip.MustParseAddr(tt.addr)))
+		})
+	}
+
+	t.Run("should reject the zero address", func(t *testing.T) {
+		assert.False(t, IsPublic(netip.Addr{}))
+	})
+}
+
+func Test_checkAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		allowed bool
+	}{
+		{name: "should allow a public IPv4 address", address: "93.184.216.34:443", allowed: true},
+		{name: "should allow a public IPv6 address", address: "[2606:2800:220:1:248:1893:25c8:1946]:443", allowed: true},
+		{name: "should refuse loopback", address: "127.0.0.1:80"},
+		{name: "should refuse RFC 1918", address: "192.168.0.10:8080"},
+		{name: "should refuse the link-local metadata address", address: "169.254.169.254:80"},
+		{name: "should refuse IPv6 unique local", address: "[fd12:3456::1]:80"},
+		{name: "should refuse IPv4-mapped loopback", address: "[::ffff:127.0.0.1]:80"},
+		{name: "should refuse an address without a port", address: "93.184.216.34"},
+		{name: "should refuse a hostname", address: "localhost:80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAddress("tcp", tt.address, nil)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrForbiddenAddress)
+			}
+		})
+	}
+}
+
+func Test_NewClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("should refuse to connect to a loopback server", func(t *testing.T) {
+		_, err := NewClient(time.Second, false).Get(server.URL)
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+	})
+
+	t.Run("should connect to a loopback server when private addresses are allowed", func(t *testing.T) {
+		resp, err := NewClient(time.Second, true).Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
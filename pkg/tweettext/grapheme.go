@@ -0,0 +1,143 @@
+package tweettext
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+// extend holds the code points that never start a cluster besides the
+// nonspacing and enclosing marks: the zero width non-joiner, emoji
+// modifiers and tag characters.
+var extend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x200C, Hi: 0x200C, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F3FB, Hi: 0x1F3FF, Stride: 1},
+		{Lo: 0xE0020, Hi: 0xE007F, Stride: 1},
+	},
+}
+
+// pictographic approximates the Extended_Pictographic property, which the
+// standard library does not provide.
+var pictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00A9, Stride: 1},
+		{Lo: 0x00AE, Hi: 0x00AE, Stride: 1},
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21A9, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x23CF, Hi: 0x23CF, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x23F8, Hi: 0x23FA, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25AB, Stride: 1},
+		{Lo: 0x25B6, Hi: 0x25B6, Stride: 1},
+		{Lo: 0x25C0, Hi: 0x25C0, Stride: 1},
+		{Lo: 0x25FB, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1F1E5, Stride: 1},
+		{Lo: 0x1F200, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x1FC00, Hi: 0x1FFFD, Stride: 1},
+	},
+}
+
+// graphemeLen returns the length in bytes of the first grapheme cluster of
+// s. It implements the rules of UAX #29 that matter for tweets: CR LF,
+// combining marks, emoji modifier and ZWJ sequences, flags and Hangul
+// syllables. Prepend characters are not handled.
+func graphemeLen(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	if r == '\r' && len(s) > n && s[n] == '\n' {
+		return n + 1
+	}
+	if isControl(r) {
+		return n
+	}
+
+	prev := r
+	emoji := unicode.Is(pictographic, r)
+	flags := 0
+	if isRegionalIndicator(r) {
+		flags = 1
+	}
+
+	for n < len(s) {
+		next, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case isExtend(next) || next == zeroWidthJoiner || unicode.Is(unicode.Mc, next):
+		case prev == zeroWidthJoiner && emoji && unicode.Is(pictographic, next):
+		case flags == 1 && isRegionalIndicator(next):
+			flags++
+		case joinsHangul(prev, next):
+		default:
+			return n
+		}
+		prev = next
+		n += size
+	}
+
+	return n
+}
+
+func isControl(r rune) bool {
+	return r == '\r' || r == '\n' || unicode.In(r, unicode.Cc, unicode.Zl, unicode.Zp)
+}
+
+func isExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, extend)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// hangulType returns the Hangul syllable type of r: L, V, T, LV, LVT or ""
+// for other code points.
+func hangulType(r rune) string {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return "L"
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return "V"
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return "T"
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return "LV"
+		}
+		return "LVT"
+	}
+
+	return ""
+}
+
+func joinsHangul(prev, next rune) bool {
+	p, n := hangulType(prev), hangulType(next)
+	switch p {
+	case "L":
+		return n == "L" || n == "V" || n == "LV" || n == "LVT"
+	case "LV", "V":
+		return n == "V" || n == "T"
+	case "LVT", "T":
+		return n == "T"
+	}
+
+	return false
+}
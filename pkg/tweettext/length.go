@@ -0,0 +1,66 @@
+// Package tweettext measures the length of a tweet the way users perceive
+// it: a grapheme cluster (an emoji sequence, a letter with its combining
+// marks) counts as one character, CJK and emoji count as two, and every URL
+// counts as URLLength whatever its actual length. The weights follow the
+// ones published for twitter-text v3.
+package tweettext
+
+import "unicode/utf8"
+
+const (
+	// MaxLength is the weighted length limit of a tweet.
+	MaxLength = 280
+	// URLLength is what every URL counts toward the length.
+	URLLength = 23
+)
+
+// lightRanges are the code points that weigh 1: Latin, Greek, Cyrillic,
+// Hebrew, Arabic, Indic scripts and the most common punctuation. Any other
+// code point weighs 2.
+var lightRanges = [][2]rune{
+	{0x0000, 0x10FF},
+	{0x2000, 0x200D},
+	{0x2010, 0x201F},
+	{0x2032, 0x2037},
+}
+
+// Length returns the weighted length of text.
+func Length(text string) int {
+	length := 0
+	start := 0
+	for _, span := range FindURLs(text) {
+		length += weigh(text[start:span[0]]) + URLLength
+		start = span[1]
+	}
+
+	return length + weigh(text[start:])
+}
+
+// Remaining returns how many characters are left before MaxLength. It is
+// negative when text is too long.
+func Remaining(text string) int {
+	return MaxLength - Length(text)
+}
+
+// weigh adds the weight of every grapheme cluster of text, which is the
+// weight of its first code point.
+func weigh(text string) int {
+	length := 0
+	for text != "" {
+		r, _ := utf8.DecodeRuneInString(text)
+		length += weight(r)
+		text = text[graphemeLen(text):]
+	}
+
+	return length
+}
+
+func weight(r rune) int {
+	for _, lr := range lightRanges {
+		if r >= lr[0] && r <= lr[1] {
+			return 1
+		}
+	}
+
+	return 2
+}
@@ -0,0 +1,60 @@
+package tweettext_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/tweettext"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Length(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		length int
+	}{
+		{name: "should count Latin letters as one", text: "hello", length: 5},
+		{name: "should count accented letters as one", text: "¡olé!", length: 5},
+		{name: "should count a combining sequence as one", text: "e\u0323\u0301", length: 1},
+		{name: "should count CR LF as one", text: "a\r\nb", length: 3},
+		{name: "should count CJK as two", text: "日本語", length: 6},
+		{name: "should count Hangul syllables as two", text: "한국", length: 4},
+		{name: "should count decomposed Hangul as one syllable", text: "\u1112\u1161\u11ab", length: 2},
+		{name: "should count an emoji as two", text: "\U0001F600", length: 2},
+		{name: "should count an emoji with skin tone as two", text: "\U0001F44D\U0001F3FD", length: 2},
+		{name: "should count a ZWJ family as two", text: "\U0001F468\u200d\U0001F469\u200d\U0001F467\u200d\U0001F466", length: 2},
+		{name: "should count a flag as two", text: "\U0001F1E6\U0001F1F7", length: 2},
+		{name: "should count each flag of a sequence", text: "\U0001F1E6\U0001F1F7\U0001F1FA\U0001F1FE\U0001F1E6", length: 6},
+		{name: "should count a keycap as one", text: "1\ufe0f\u20e3", length: 1},
+		{name: "should count a URL as URLLength", text: "see https://example.com/" + strings.Repeat("a", 100), length: 4 + tweettext.URLLength},
+		{name: "should count a short URL as URLLength", text: "http://a.co.", length: tweettext.URLLength + 1},
+		{name: "should count a URL with credentials as text", text: "http://u:p@a.co", length: 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.length, tweettext.Length(tt.text))
+			assert.Equal(t, tweettext.MaxLength-tt.length, tweettext.Remaining(tt.text))
+		})
+	}
+}
+
+func Test_FindURLs(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		spans [][2]int
+	}{
+		{name: "should find nothing in plain text", text: "hello example.com"},
+		{name: "should trim trailing punctuation", text: "(see http://a.com/x).", spans: [][2]int{{5, 19}}},
+		{name: "should keep brackets opened by the URL", text: "https://en.wikipedia.org/wiki/Go_(language)", spans: [][2]int{{0, 43}}},
+		{name: "should find several URLs", text: "http://a.com, HTTPS://B.COM", spans: [][2]int{{0, 12}, {14, 27}}},
+		{name: "should skip URLs without host", text: "http:// x"},
+		{name: "should skip URLs that are too long", text: "https://a.com/" + strings.Repeat("a", tweettext.MaxURLLength)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.spans, tweettext.FindURLs(tt.text))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package tweettext
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MaxURLLength is the longest URL counted as a URL. Longer ones are
+// counted as plain text.
+const MaxURLLength = 2048
+
+// urlPattern matches http and https URLs up to the next space or quote.
+// Trailing punctuation is trimmed afterwards by trimURL.
+var urlPattern = regexp.MustCompile("(?i)\\bhttps?://[^\\s<>\"'`]+")
+
+// FindURLs returns the byte offsets of the start and end of every URL of
+// text. Only URLs with an http or https scheme and a host are found, so
+// "example.com" alone is plain text. URLs with credentials are skipped.
+func FindURLs(text string) [][2]int {
+	var spans [][2]int
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		raw := trimURL(text[loc[0]:loc[1]])
+		if !isURL(raw) {
+			continue
+		}
+		spans = append(spans, [2]int{loc[0], loc[0] + len(raw)})
+	}
+
+	return spans
+}
+
+// trimURL drops the punctuation that usually ends the sentence rather than
+// the URL. Closing brackets are kept when the URL opened them, as in
+// Wikipedia links.
+func trimURL(raw string) string {
+	for raw != "" {
+		last := raw[len(raw)-1]
+		switch {
+		case strings.IndexByte(".,:;!?'\"", last) >= 0:
+		case last == ')' && strings.Count(raw, "(") < strings.Count(raw, ")"):
+		case last == ']' && strings.Count(raw, "[") < strings.Count(raw, "]"):
+		default:
+			return raw
+		}
+		raw = raw[:len(raw)-1]
+	}
+
+	return raw
+}
+
+func isURL(raw string) bool {
+	if len(raw) > MaxURLLength {
+		return false
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+
+	return u.User == nil && strings.TrimSuffix(u.Hostname(), ".") != ""
+}
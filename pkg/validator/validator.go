@@ -4,6 +4,7 @@ package validator
 import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/oscarsalomon89/scalable-microblogging-platform/pkg/tweettext"
 )
 
 type ValidationErrors = validator.ValidationErrors
@@ -19,8 +20,13 @@ func validateUUIDFormat(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+func validateTweetLength(fl validator.FieldLevel) bool {
+	return tweettext.Length(fl.Field().String()) <= tweettext.MaxLength
+}
+
 var validationMap = map[string]func(validator.FieldLevel) bool{
 	"validUUIDFormat": validateUUIDFormat,
+	"tweetLength":     validateTweetLength,
 }
 
 var registered bool